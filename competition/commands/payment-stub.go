@@ -0,0 +1,182 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	paymentConfig "arkavidia-backend-8.0/competition/config/payment"
+	"arkavidia-backend-8.0/competition/utils/payment"
+)
+
+// Payment provider tiruan untuk pengujian lokal, dijalankan dengan `go run . payment-stub [address]`
+// lalu arahkan PAYMENT_PROVIDER_URL ke alamat tersebut
+// NOTE: Buka payment_url kemudian tambahkan ?status=PAID atau ?status=EXPIRED untuk mengirim webhook bertanda tangan ke callback_url
+type stubTransaction struct {
+	request  payment.TransactionRequest
+	response payment.TransactionResponse
+}
+
+func PaymentStub() {
+	config := paymentConfig.Config.GetMetadata()
+
+	address := ":9000"
+	if len(os.Args) > 2 {
+		address = os.Args[2]
+	}
+	baseURL := fmt.Sprintf("http://localhost%s", address)
+	if !strings.HasPrefix(address, ":") {
+		baseURL = fmt.Sprintf("http://%s", address)
+	}
+
+	transactions := map[string]*stubTransaction{}
+	mutex := sync.Mutex{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/invoices", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "METHOD NOT ALLOWED", http.StatusMethodNotAllowed)
+			return
+		}
+		if username, _, ok := r.BasicAuth(); !ok || username != config.ServerKey {
+			http.Error(w, "UNAUTHORIZED", http.StatusUnauthorized)
+			return
+		}
+
+		request := payment.TransactionRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.ExternalID == "" || request.Amount == 0 {
+			http.Error(w, "BAD REQUEST", http.StatusBadRequest)
+			return
+		}
+
+		id := uuid.NewString()
+		transaction := &stubTransaction{request: request, response: payment.TransactionResponse{
+			ID:         id,
+			ExternalID: request.ExternalID,
+			Amount:     request.Amount,
+			Status:     payment.StatusPending,
+			InvoiceURL: fmt.Sprintf("%s/pay/%s", baseURL, id),
+			ExpiryDate: time.Now().Add(time.Duration(request.InvoiceDuration) * time.Second).UTC(),
+		}}
+
+		mutex.Lock()
+		transactions[id] = transaction
+		mutex.Unlock()
+
+		log.Printf("INFO: TRANSACTION %s CREATED FOR %s", id, request.ExternalID)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(transaction.response)
+	})
+	mux.HandleFunc("/v2/invoices/", func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v2/invoices/"), "/expire")
+		if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/expire") {
+			http.Error(w, "NOT FOUND", http.StatusNotFound)
+			return
+		}
+		if username, _, ok := r.BasicAuth(); !ok || username != config.ServerKey {
+			http.Error(w, "UNAUTHORIZED", http.StatusUnauthorized)
+			return
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		transaction, ok := transactions[id]
+		if !ok {
+			http.Error(w, "TRANSACTION NOT FOUND", http.StatusNotFound)
+			return
+		}
+		if transaction.response.Status == payment.StatusPaid {
+			http.Error(w, "TRANSACTION ALREADY PAID", http.StatusConflict)
+			return
+		}
+
+		transaction.response.Status = payment.StatusExpired
+		log.Printf("INFO: TRANSACTION %s EXPIRED FOR %s", id, transaction.request.ExternalID)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(transaction.response)
+	})
+	mux.HandleFunc("/pay/", func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/pay/")
+
+		mutex.Lock()
+		transaction, ok := transactions[id]
+		mutex.Unlock()
+		if !ok {
+			http.Error(w, "TRANSACTION NOT FOUND", http.StatusNotFound)
+			return
+		}
+
+		status := strings.ToUpper(r.URL.Query().Get("status"))
+		if status == "" {
+			fmt.Fprintf(w, "%s: %d (%s)\n", transaction.request.Description, transaction.request.Amount, transaction.response.Status)
+			return
+		}
+		if status != payment.StatusPaid && status != payment.StatusExpired {
+			http.Error(w, "INVALID STATUS", http.StatusBadRequest)
+			return
+		}
+		if transaction.response.Status != payment.StatusPending {
+			http.Error(w, "TRANSACTION IS NO LONGER PENDING", http.StatusConflict)
+			return
+		}
+
+		payload := payment.WebhookPayload{ID: id, ExternalID: transaction.request.ExternalID, Amount: transaction.request.Amount, Status: status}
+		if status == payment.StatusPaid {
+			paidAt := time.Now().UTC()
+			payload.PaidAt = &paidAt
+		}
+
+		statusCode, err := sendStubWebhook(transaction.request.CallbackURL, config.WebhookSecret, payload)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+
+		mutex.Lock()
+		transaction.response.Status = status
+		mutex.Unlock()
+
+		log.Printf("INFO: WEBHOOK %s SENT FOR %s WITH STATUS %d", status, transaction.request.ExternalID, statusCode)
+		fmt.Fprintf(w, "WEBHOOK %s SENT WITH STATUS %d\n", status, statusCode)
+	})
+
+	log.Printf("INFO: PAYMENT STUB LISTENING ON %s", address)
+	if err := http.ListenAndServe(address, mux); err != nil {
+		panic(err)
+	}
+}
+
+func sendStubWebhook(callbackURL string, secret string, payload payment.WebhookPayload) (int, error) {
+	if callbackURL == "" {
+		return 0, fmt.Errorf("ERROR: CALLBACK URL NOT PROVIDED")
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return 0, err
+	}
+
+	request, err := http.NewRequest(http.MethodPost, callbackURL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(payment.SignatureHeader, payment.Sign(secret, body))
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	response, err := client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	return response.StatusCode, nil
+}
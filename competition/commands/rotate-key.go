@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"log"
+
+	"gorm.io/gorm"
+
+	"arkavidia-backend-8.0/competition/models"
+	databaseService "arkavidia-backend-8.0/competition/services/database"
+	"arkavidia-backend-8.0/competition/utils/encryption"
+)
+
+// Melakukan wrap ulang seluruh data key dengan master key yang baru (ENCRYPTION_MASTER_KEY)
+// NOTE: Master key lama harus diisi pada ENCRYPTION_PREVIOUS_MASTER_KEY selama proses rotasi
+func RotateKey() error {
+	db := databaseService.DB.GetConnection()
+
+	photos := []models.Photo{}
+	if err := db.Where("wrapped_key IS NOT NULL").Find(&photos).Error; err != nil {
+		return err
+	}
+
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		for _, photo := range photos {
+			wrappedKey, err := encryption.Envelope.RewrapKey(photo.WrappedKey)
+			if err != nil {
+				return err
+			}
+
+			if err := tx.Model(&photo).UpdateColumn("wrapped_key", wrappedKey).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	log.Printf("SUCCESS: %d DATA KEYS ROTATED", len(photos))
+	return nil
+}
@@ -10,10 +10,12 @@ import (
 )
 
 type AuthMetadata struct {
-	ApplicationName         string
-	LoginExpirationDuration time.Duration
-	JWTSigningMethod        jwt.SigningMethod
-	JWTSignatureKey         []byte
+	ApplicationName                     string
+	LoginExpirationDuration             time.Duration
+	ParticipantAccessExpirationDuration time.Duration
+	ParticipantAccessURL                string
+	JWTSigningMethod                    jwt.SigningMethod
+	JWTSignatureKey                     []byte
 }
 
 type AuthConfig struct {
@@ -30,11 +32,19 @@ func (authConfig *AuthConfig) lazyInit() {
 			panic(err)
 		}
 		loginExpirationDuration := time.Duration(numberOfSeconds) * time.Second
+		numberOfSeconds, err = strconv.Atoi(os.Getenv("PARTICIPANT_ACCESS_EXPIRATION_DURATION"))
+		if err != nil {
+			panic(err)
+		}
+		participantAccessExpirationDuration := time.Duration(numberOfSeconds) * time.Second
+		participantAccessURL := os.Getenv("PARTICIPANT_ACCESS_URL")
 		jwtSigningMethod := jwt.SigningMethodHS256
 		jwtSignatureKey := []byte(os.Getenv("JWT_SIGNATURE_KEY"))
 
 		authConfig.metadata.ApplicationName = applicationName
 		authConfig.metadata.LoginExpirationDuration = loginExpirationDuration
+		authConfig.metadata.ParticipantAccessExpirationDuration = participantAccessExpirationDuration
+		authConfig.metadata.ParticipantAccessURL = participantAccessURL
 		authConfig.metadata.JWTSigningMethod = jwtSigningMethod
 		authConfig.metadata.JWTSignatureKey = jwtSignatureKey
 	})
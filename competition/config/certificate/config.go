@@ -0,0 +1,35 @@
+package certificate
+
+import (
+	"os"
+	"sync"
+)
+
+type CertificateMetadata struct {
+	EventName string
+	VerifyURL string
+}
+
+type CertificateConfig struct {
+	metadata CertificateMetadata
+	once     sync.Once
+}
+
+// Private
+func (certificateConfig *CertificateConfig) lazyInit() {
+	certificateConfig.once.Do(func() {
+		eventName := os.Getenv("CERTIFICATE_EVENT_NAME")
+		verifyURL := os.Getenv("CERTIFICATE_VERIFY_URL")
+
+		certificateConfig.metadata.EventName = eventName
+		certificateConfig.metadata.VerifyURL = verifyURL
+	})
+}
+
+// Public
+func (certificateConfig *CertificateConfig) GetMetadata() CertificateMetadata {
+	certificateConfig.lazyInit()
+	return certificateConfig.metadata
+}
+
+var Config = &CertificateConfig{}
@@ -0,0 +1,55 @@
+package encryption
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"sync"
+)
+
+type EncryptionMetadata struct {
+	MasterKey         []byte
+	PreviousMasterKey []byte
+}
+
+type EncryptionConfig struct {
+	metadata EncryptionMetadata
+	once     sync.Once
+}
+
+// Private
+func isValidKeySize(key []byte) bool {
+	return len(key) == 16 || len(key) == 24 || len(key) == 32
+}
+
+func (encryptionConfig *EncryptionConfig) lazyInit() {
+	encryptionConfig.once.Do(func() {
+		masterKey, err := base64.StdEncoding.DecodeString(os.Getenv("ENCRYPTION_MASTER_KEY"))
+		if err != nil {
+			panic(err)
+		}
+		previousMasterKey, err := base64.StdEncoding.DecodeString(os.Getenv("ENCRYPTION_PREVIOUS_MASTER_KEY"))
+		if err != nil {
+			panic(err)
+		}
+
+		// Master key wajib berupa kunci AES yang valid agar kesalahan konfigurasi terdeteksi saat aplikasi dijalankan
+		if !isValidKeySize(masterKey) {
+			panic(fmt.Errorf("ERROR: ENCRYPTION MASTER KEY MUST BE 16, 24, OR 32 BYTES"))
+		}
+		if len(previousMasterKey) > 0 && !isValidKeySize(previousMasterKey) {
+			panic(fmt.Errorf("ERROR: ENCRYPTION PREVIOUS MASTER KEY MUST BE 16, 24, OR 32 BYTES"))
+		}
+
+		encryptionConfig.metadata.MasterKey = masterKey
+		encryptionConfig.metadata.PreviousMasterKey = previousMasterKey
+	})
+}
+
+// Public
+func (encryptionConfig *EncryptionConfig) GetMetadata() EncryptionMetadata {
+	encryptionConfig.lazyInit()
+	return encryptionConfig.metadata
+}
+
+var Config = &EncryptionConfig{}
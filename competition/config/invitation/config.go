@@ -0,0 +1,41 @@
+package invitation
+
+import (
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type InvitationMetadata struct {
+	ExpirationDuration time.Duration
+	URL                string
+}
+
+type InvitationConfig struct {
+	metadata InvitationMetadata
+	once     sync.Once
+}
+
+// Private
+func (invitationConfig *InvitationConfig) lazyInit() {
+	invitationConfig.once.Do(func() {
+		numberOfSeconds, err := strconv.Atoi(os.Getenv("INVITATION_EXPIRATION_DURATION"))
+		if err != nil {
+			panic(err)
+		}
+		expirationDuration := time.Duration(numberOfSeconds) * time.Second
+		url := os.Getenv("INVITATION_URL")
+
+		invitationConfig.metadata.ExpirationDuration = expirationDuration
+		invitationConfig.metadata.URL = url
+	})
+}
+
+// Public
+func (invitationConfig *InvitationConfig) GetMetadata() InvitationMetadata {
+	invitationConfig.lazyInit()
+	return invitationConfig.metadata
+}
+
+var Config = &InvitationConfig{}
@@ -12,6 +12,7 @@ type EmailMetadata struct {
 	SenderName   string
 	AuthEmail    string
 	AuthPassword string
+	AdminEmail   string
 }
 
 type EmailConfig struct {
@@ -30,12 +31,14 @@ func (emailConfig *EmailConfig) lazyInit() {
 		senderName := os.Getenv("CONFIG_SENDER_NAME")
 		authEmail := os.Getenv("CONFIG_AUTH_EMAIL")
 		authPassword := os.Getenv("CONFIG_AUTH_PASSWORD")
+		adminEmail := os.Getenv("CONFIG_ADMIN_EMAIL")
 
 		emailConfig.metadata.SMTPHost = smtpHost
 		emailConfig.metadata.SMTPPort = smtpPort
 		emailConfig.metadata.SenderName = senderName
 		emailConfig.metadata.AuthEmail = authEmail
 		emailConfig.metadata.AuthPassword = authPassword
+		emailConfig.metadata.AdminEmail = adminEmail
 	})
 }
 
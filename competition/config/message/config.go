@@ -1,6 +1,7 @@
 package message
 
 import (
+	"fmt"
 	"os"
 	"strconv"
 	"sync"
@@ -8,9 +9,11 @@ import (
 )
 
 type MessageMetadata struct {
-	BufferSize int
-	Timeout    time.Duration
-	WorkerSize int
+	BufferSize   int
+	Timeout      time.Duration
+	WorkerSize   int
+	MaxRetry     int
+	RetryBackoff time.Duration
 }
 
 type MessageConfig struct {
@@ -35,9 +38,24 @@ func (messageConfig *MessageConfig) lazyInit() {
 			panic(err)
 		}
 
+		maxRetry, err := strconv.Atoi(os.Getenv("MESSAGE_MAX_RETRY"))
+		if err != nil {
+			panic(err)
+		}
+		if maxRetry < 1 {
+			panic(fmt.Errorf("ERROR: MESSAGE MAX RETRY MUST BE AT LEAST 1"))
+		}
+		numberOfBackoffSeconds, err := strconv.Atoi(os.Getenv("MESSAGE_RETRY_BACKOFF"))
+		if err != nil {
+			panic(err)
+		}
+		retryBackoff := time.Duration(numberOfBackoffSeconds) * time.Second
+
 		messageConfig.metadata.BufferSize = bufferSize
 		messageConfig.metadata.Timeout = timeout
 		messageConfig.metadata.WorkerSize = workerSize
+		messageConfig.metadata.MaxRetry = maxRetry
+		messageConfig.metadata.RetryBackoff = retryBackoff
 	})
 }
 
@@ -0,0 +1,50 @@
+package payment
+
+import (
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type PaymentMetadata struct {
+	ProviderURL     string
+	ServerKey       string
+	WebhookSecret   string
+	CallbackURL     string
+	InvoiceDuration time.Duration
+}
+
+type PaymentConfig struct {
+	metadata PaymentMetadata
+	once     sync.Once
+}
+
+// Private
+func (paymentConfig *PaymentConfig) lazyInit() {
+	paymentConfig.once.Do(func() {
+		providerURL := os.Getenv("PAYMENT_PROVIDER_URL")
+		serverKey := os.Getenv("PAYMENT_SERVER_KEY")
+		webhookSecret := os.Getenv("PAYMENT_WEBHOOK_SECRET")
+		callbackURL := os.Getenv("PAYMENT_CALLBACK_URL")
+		numberOfSeconds, err := strconv.Atoi(os.Getenv("PAYMENT_INVOICE_DURATION"))
+		if err != nil {
+			panic(err)
+		}
+		invoiceDuration := time.Duration(numberOfSeconds) * time.Second
+
+		paymentConfig.metadata.ProviderURL = providerURL
+		paymentConfig.metadata.ServerKey = serverKey
+		paymentConfig.metadata.WebhookSecret = webhookSecret
+		paymentConfig.metadata.CallbackURL = callbackURL
+		paymentConfig.metadata.InvoiceDuration = invoiceDuration
+	})
+}
+
+// Public
+func (paymentConfig *PaymentConfig) GetMetadata() PaymentMetadata {
+	paymentConfig.lazyInit()
+	return paymentConfig.metadata
+}
+
+var Config = &PaymentConfig{}
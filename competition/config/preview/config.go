@@ -0,0 +1,38 @@
+package preview
+
+import (
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type PreviewMetadata struct {
+	RenderTimeout time.Duration
+}
+
+type PreviewConfig struct {
+	metadata PreviewMetadata
+	once     sync.Once
+}
+
+// Private
+func (previewConfig *PreviewConfig) lazyInit() {
+	previewConfig.once.Do(func() {
+		numberOfTimeoutSeconds, err := strconv.Atoi(os.Getenv("PREVIEW_RENDER_TIMEOUT"))
+		if err != nil {
+			panic(err)
+		}
+		renderTimeout := time.Duration(numberOfTimeoutSeconds) * time.Second
+
+		previewConfig.metadata.RenderTimeout = renderTimeout
+	})
+}
+
+// Public
+func (previewConfig *PreviewConfig) GetMetadata() PreviewMetadata {
+	previewConfig.lazyInit()
+	return previewConfig.metadata
+}
+
+var Config = &PreviewConfig{}
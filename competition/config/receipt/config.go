@@ -0,0 +1,44 @@
+package receipt
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"sync"
+)
+
+type ReceiptMetadata struct {
+	SigningKey ed25519.PrivateKey
+	VerifyURL  string
+}
+
+type ReceiptConfig struct {
+	metadata ReceiptMetadata
+	once     sync.Once
+}
+
+// Private
+func (receiptConfig *ReceiptConfig) lazyInit() {
+	receiptConfig.once.Do(func() {
+		seed, err := base64.StdEncoding.DecodeString(os.Getenv("RECEIPT_SIGNING_KEY"))
+		if err != nil {
+			panic(err)
+		}
+		if len(seed) != ed25519.SeedSize {
+			panic(fmt.Errorf("ERROR: RECEIPT SIGNING KEY MUST BE %d BYTES", ed25519.SeedSize))
+		}
+		verifyURL := os.Getenv("RECEIPT_VERIFY_URL")
+
+		receiptConfig.metadata.SigningKey = ed25519.NewKeyFromSeed(seed)
+		receiptConfig.metadata.VerifyURL = verifyURL
+	})
+}
+
+// Public
+func (receiptConfig *ReceiptConfig) GetMetadata() ReceiptMetadata {
+	receiptConfig.lazyInit()
+	return receiptConfig.metadata
+}
+
+var Config = &ReceiptConfig{}
@@ -0,0 +1,38 @@
+package scoreboard
+
+import (
+	"os"
+	"sync"
+)
+
+type ScoreboardMetadata struct {
+	EventFeedURL      string
+	EventFeedUsername string
+	EventFeedPassword string
+}
+
+type ScoreboardConfig struct {
+	metadata ScoreboardMetadata
+	once     sync.Once
+}
+
+// Private
+func (scoreboardConfig *ScoreboardConfig) lazyInit() {
+	scoreboardConfig.once.Do(func() {
+		eventFeedURL := os.Getenv("EVENT_FEED_URL")
+		eventFeedUsername := os.Getenv("EVENT_FEED_USERNAME")
+		eventFeedPassword := os.Getenv("EVENT_FEED_PASSWORD")
+
+		scoreboardConfig.metadata.EventFeedURL = eventFeedURL
+		scoreboardConfig.metadata.EventFeedUsername = eventFeedUsername
+		scoreboardConfig.metadata.EventFeedPassword = eventFeedPassword
+	})
+}
+
+// Public
+func (scoreboardConfig *ScoreboardConfig) GetMetadata() ScoreboardMetadata {
+	scoreboardConfig.lazyInit()
+	return scoreboardConfig.metadata
+}
+
+var Config = &ScoreboardConfig{}
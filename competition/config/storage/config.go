@@ -7,11 +7,13 @@ import (
 )
 
 type StorageMetadata struct {
-	FileTimeout   int
-	StorageHost   string
-	BucketName    string
-	PhotoDir      string
-	SubmissionDir string
+	FileTimeout    int
+	StorageHost    string
+	BucketName     string
+	PhotoDir       string
+	SubmissionDir  string
+	PreviewDir     string
+	GroundTruthDir string
 }
 
 type StorageConfig struct {
@@ -30,12 +32,16 @@ func (storageConfig *StorageConfig) lazyInit() {
 		bucketName := os.Getenv("BUCKET_NAME")
 		photoDir := os.Getenv("PHOTO_DIR")
 		submissionDir := os.Getenv("SUBMISSION_DIR")
+		previewDir := os.Getenv("PREVIEW_DIR")
+		groundTruthDir := os.Getenv("GROUND_TRUTH_DIR")
 
 		storageConfig.metadata.FileTimeout = fileTimeout
 		storageConfig.metadata.StorageHost = storageHost
 		storageConfig.metadata.BucketName = bucketName
 		storageConfig.metadata.PhotoDir = photoDir
 		storageConfig.metadata.SubmissionDir = submissionDir
+		storageConfig.metadata.PreviewDir = previewDir
+		storageConfig.metadata.GroundTruthDir = groundTruthDir
 	})
 }
 
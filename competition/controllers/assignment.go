@@ -0,0 +1,252 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"arkavidia-backend-8.0/competition/middlewares"
+	"arkavidia-backend-8.0/competition/models"
+	"arkavidia-backend-8.0/competition/repository"
+	databaseService "arkavidia-backend-8.0/competition/services/database"
+)
+
+func GetAssignmentsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[[]models.Assignment]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				query := repository.GetAssignmentQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				condition := models.Assignment{JudgeID: query.JudgeID}
+				assignments := []models.Assignment{}
+				if err := db.Preload("Scores").Where(&condition).Find(&assignments).Error; err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = assignments
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		case middlewares.Judge:
+			{
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				// Judge hanya mendapatkan tampilan blind agar identitas team tidak memengaruhi penilaian
+				response := repository.Response[[]models.BlindAssignment]{}
+				judgeID := value.(uint)
+				condition := models.Assignment{JudgeID: judgeID}
+				assignments := []models.Assignment{}
+				if err := db.Preload("Submission").Preload("Scores").Where(&condition).Find(&assignments).Error; err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				blindAssignments := []models.BlindAssignment{}
+				for _, assignment := range assignments {
+					blindAssignments = append(blindAssignments, assignment.Blind())
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = blindAssignments
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+func AddAssignmentHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[models.Assignment]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				request := repository.AddAssignmentRequest{}
+				if err := c.ShouldBindJSON(&request); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				adminID := value.(uint)
+				assignment := models.Assignment{JudgeID: request.JudgeID, SubmissionID: request.SubmissionID, AdminID: adminID}
+				if err := db.Create(&assignment).Error; err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = assignment
+				c.JSON(http.StatusCreated, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+func DeleteAssignmentHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[models.Assignment]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				request := repository.DeleteAssignmentRequest{}
+				if err := c.ShouldBindJSON(&request); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				condition := models.Assignment{Model: gorm.Model{ID: request.AssignmentID}}
+				assignment := models.Assignment{}
+				if err := db.Where(&condition).Delete(&assignment).Error; err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+func AutoAssignHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[[]models.Assignment]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				request := repository.AutoAssignRequest{}
+				if err := c.ShouldBindJSON(&request); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				adminID := value.(uint)
+				assignments := []models.Assignment{}
+				if err := db.Transaction(func(tx *gorm.DB) error {
+					result, err := models.AutoAssign(tx, request.TeamCategory, request.Stage, request.JudgesPerSubmission, adminID)
+					if err != nil {
+						return err
+					}
+
+					assignments = result
+					return nil
+				}); err != nil {
+					response.Message = err.Error()
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = assignments
+				c.JSON(http.StatusCreated, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
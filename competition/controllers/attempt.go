@@ -0,0 +1,278 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"arkavidia-backend-8.0/competition/middlewares"
+	"arkavidia-backend-8.0/competition/models"
+	"arkavidia-backend-8.0/competition/repository"
+	databaseService "arkavidia-backend-8.0/competition/services/database"
+)
+
+func GetAttemptHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[models.Attempt]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Team:
+			{
+				query := repository.ExamQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				teamID := value.(uint)
+				condition := models.Attempt{ExamID: query.ExamID, TeamID: teamID}
+				attempt := models.Attempt{}
+				if err := db.Preload("Exam").Preload("Answers").Where(&condition).First(&attempt).Error; err != nil {
+					response.Message = "ERROR: ATTEMPT NOT FOUND"
+					c.AbortWithStatusJSON(http.StatusNotFound, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = attempt.HideResult(attempt.Exam)
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+// Waktu mulai dan waktu berakhir attempt ditentukan oleh server, bukan oleh client
+func StartAttemptHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[models.Attempt]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Team:
+			{
+				query := repository.ExamQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				teamID := value.(uint)
+				attempt := models.Attempt{ExamID: query.ExamID, TeamID: teamID}
+				if err := db.Create(&attempt).Error; err != nil {
+					response.Message = err.Error()
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = attempt
+				c.JSON(http.StatusCreated, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+// Jawaban disimpan secara otomatis (autosave) oleh client selama attempt masih berlangsung
+func SaveAnswersHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[[]models.Answer]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Team:
+			{
+				query := repository.AttemptQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				request := repository.SaveAnswersRequest{}
+				if err := c.ShouldBindJSON(&request); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				teamID := value.(uint)
+				answers := []models.Answer{}
+				if err := db.Transaction(func(tx *gorm.DB) error {
+					conditionAttempt := models.Attempt{Model: gorm.Model{ID: query.AttemptID}, TeamID: teamID}
+					attempt := models.Attempt{}
+					if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(&conditionAttempt).First(&attempt).Error; err != nil {
+						return fmt.Errorf("ERROR: ATTEMPT NOT FOUND")
+					}
+
+					for _, answerRequest := range request.Answers {
+						condition := models.Answer{AttemptID: attempt.ID, QuestionID: answerRequest.QuestionID}
+						answer := models.Answer{}
+						if err := tx.Where(&condition).FirstOrInit(&answer).Error; err != nil {
+							return err
+						}
+
+						answer.OptionID = answerRequest.OptionID
+						answer.Text = answerRequest.Text
+						if err := tx.Save(&answer).Error; err != nil {
+							return err
+						}
+						answers = append(answers, answer)
+					}
+					return nil
+				}); err != nil {
+					response.Message = err.Error()
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = answers
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+func SubmitAttemptHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[models.Attempt]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Team:
+			{
+				query := repository.AttemptQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				teamID := value.(uint)
+				attempt := models.Attempt{}
+				if err := db.Transaction(func(tx *gorm.DB) error {
+					condition := models.Attempt{Model: gorm.Model{ID: query.AttemptID}, TeamID: teamID}
+					if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(&condition).First(&attempt).Error; err != nil {
+						return fmt.Errorf("ERROR: ATTEMPT NOT FOUND")
+					}
+					if attempt.SubmittedAt != nil {
+						return fmt.Errorf("ERROR: ATTEMPT ALREADY SUBMITTED")
+					}
+
+					conditionExam := models.Exam{Model: gorm.Model{ID: attempt.ExamID}}
+					if err := tx.Where(&conditionExam).First(&attempt.Exam).Error; err != nil {
+						return err
+					}
+
+					return attempt.Grade(tx)
+				}); err != nil {
+					response.Message = err.Error()
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = attempt.HideResult(attempt.Exam)
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,292 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"arkavidia-backend-8.0/competition/middlewares"
+	"arkavidia-backend-8.0/competition/models"
+	"arkavidia-backend-8.0/competition/repository"
+	databaseService "arkavidia-backend-8.0/competition/services/database"
+	"arkavidia-backend-8.0/competition/types"
+	"arkavidia-backend-8.0/competition/utils/certificate"
+)
+
+// NOTE: Penerbitan dan pengiriman sertifikat berjalan di background worker sehingga status batch perlu dicek secara berkala
+func GenerateCertificatesHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[models.CertificateBatch]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				request := repository.GenerateCertificatesRequest{}
+				if err := c.ShouldBindJSON(&request); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				adminID := value.(uint)
+				batch := models.CertificateBatch{TeamCategory: request.TeamCategory, Status: types.BatchPending, AdminID: adminID}
+				if err := db.Create(&batch).Error; err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+				certificate.Broker.AddBatchToBroker(certificate.CertificateParameters{BatchID: batch.ID})
+
+				response.Message = "SUCCESS"
+				response.Data = batch
+				c.JSON(http.StatusAccepted, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+func GetCertificateBatchesHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[[]models.CertificateBatch]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				query := repository.GetCertificateBatchesQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				condition := models.CertificateBatch{TeamCategory: query.TeamCategory}
+				batches := []models.CertificateBatch{}
+				if err := db.Where(&condition).Order("created_at DESC").Find(&batches).Error; err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = batches
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+func GetCertificatesHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[[]models.Certificate]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		query := repository.GetCertificatesQuery{}
+		if err := c.ShouldBindQuery(&query); err != nil {
+			response.Message = "ERROR: BAD REQUEST"
+			c.AbortWithStatusJSON(http.StatusBadRequest, response)
+			return
+		}
+
+		condition := models.Certificate{BatchID: query.BatchID, ParticipantID: query.ParticipantID, TeamCategory: query.TeamCategory}
+		switch role {
+		case middlewares.Admin:
+			{
+				// Admin dapat mengakses sertifikat seluruh participant
+			}
+		case middlewares.Team:
+			{
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				condition.TeamID = value.(uint)
+			}
+		case middlewares.Participant:
+			{
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				condition.ParticipantID = value.(uint)
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+
+		certificates := []models.Certificate{}
+		if err := db.Where(&condition).Order("issued_at DESC").Find(&certificates).Error; err != nil {
+			response.Message = "ERROR: BAD REQUEST"
+			c.AbortWithStatusJSON(http.StatusBadRequest, response)
+			return
+		}
+
+		response.Message = "SUCCESS"
+		response.Data = certificates
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+func DownloadCertificateHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[string]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		query := repository.CertificateSerialQuery{}
+		if err := c.ShouldBindQuery(&query); err != nil {
+			response.Message = "ERROR: BAD REQUEST"
+			c.AbortWithStatusJSON(http.StatusBadRequest, response)
+			return
+		}
+
+		condition := models.Certificate{Serial: uuid.MustParse(query.Serial)}
+		switch role {
+		case middlewares.Admin:
+			{
+				// Admin dapat mengakses sertifikat seluruh participant
+			}
+		case middlewares.Team:
+			{
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				condition.TeamID = value.(uint)
+			}
+		case middlewares.Participant:
+			{
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				condition.ParticipantID = value.(uint)
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+
+		participantCertificate := models.Certificate{}
+		if err := db.Where(&condition).First(&participantCertificate).Error; err != nil {
+			response.Message = "ERROR: CERTIFICATE NOT FOUND"
+			c.AbortWithStatusJSON(http.StatusNotFound, response)
+			return
+		}
+
+		content, err := certificate.GeneratePDF(participantCertificate)
+		if err != nil {
+			response.Message = "ERROR: CERTIFICATE CANNOT BE GENERATED"
+			c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+			return
+		}
+
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", certificate.GetFilename(participantCertificate)))
+		c.Data(http.StatusOK, "application/pdf", content)
+	}
+}
+
+// NOTE: Endpoint verifikasi dapat diakses secara publik melalui QR code pada sertifikat
+func VerifyCertificateHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[models.VerifiedCertificate]{}
+
+		query := repository.CertificateSerialQuery{}
+		if err := c.ShouldBindQuery(&query); err != nil {
+			response.Message = "ERROR: BAD REQUEST"
+			c.AbortWithStatusJSON(http.StatusBadRequest, response)
+			return
+		}
+
+		condition := models.Certificate{Serial: uuid.MustParse(query.Serial)}
+		participantCertificate := models.Certificate{}
+		if err := db.Where(&condition).First(&participantCertificate).Error; err != nil {
+			response.Message = "ERROR: CERTIFICATE NOT FOUND"
+			c.AbortWithStatusJSON(http.StatusNotFound, response)
+			return
+		}
+
+		response.Message = "SUCCESS"
+		response.Data = participantCertificate.Verify()
+		c.JSON(http.StatusOK, response)
+	}
+}
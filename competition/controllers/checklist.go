@@ -0,0 +1,185 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"arkavidia-backend-8.0/competition/middlewares"
+	"arkavidia-backend-8.0/competition/models"
+	"arkavidia-backend-8.0/competition/repository"
+	databaseService "arkavidia-backend-8.0/competition/services/database"
+)
+
+func GetChecklistHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[models.TeamChecklist]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				query := repository.GetChecklistQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				teamChecklist, err := models.BuildTeamChecklist(db, query.TeamID)
+				if err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = teamChecklist
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		case middlewares.Team:
+			{
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				teamID := value.(uint)
+				teamChecklist, err := models.BuildTeamChecklist(db, teamID)
+				if err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = teamChecklist
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+func GetAllChecklistsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[[]models.TeamChecklist]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				query := repository.GetAllChecklistsQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				// Tanpa filter item belum lengkap, team dipaginasi langsung di database sehingga hanya checklist satu halaman yang dihitung
+				// Dengan filter, team dipindai per batch berurutan dan pemindaian berhenti setelah satu halaman terpenuhi
+				offset := (query.Page - 1) * query.Size
+				isFiltered := query.IsIncomplete || query.Incomplete != ""
+				teamChecklists := []models.TeamChecklist{}
+				if err := db.Transaction(func(tx *gorm.DB) error {
+					queryTeam := tx.Model(&models.Team{})
+					if query.TeamCategory != "" {
+						queryTeam = queryTeam.Joins("JOIN enrolments ON enrolments.team_id = teams.id AND enrolments.deleted_at IS NULL").Where("enrolments.team_category = ?", query.TeamCategory)
+					}
+
+					if !isFiltered {
+						teams := []models.Team{}
+						if err := queryTeam.Order("teams.id").Offset(offset).Limit(query.Size).Find(&teams).Error; err != nil {
+							return err
+						}
+
+						for _, team := range teams {
+							teamChecklist, err := models.BuildTeamChecklist(tx, team.ID)
+							if err != nil {
+								return err
+							}
+							teamChecklists = append(teamChecklists, teamChecklist)
+						}
+						return nil
+					}
+
+					skipped := 0
+					var lastTeamID uint
+					for len(teamChecklists) < query.Size {
+						teams := []models.Team{}
+						if err := queryTeam.Session(&gorm.Session{}).Where("teams.id > ?", lastTeamID).Order("teams.id").Limit(query.Size).Find(&teams).Error; err != nil {
+							return err
+						}
+						if len(teams) == 0 {
+							break
+						}
+
+						for _, team := range teams {
+							lastTeamID = team.ID
+							teamChecklist, err := models.BuildTeamChecklist(tx, team.ID)
+							if err != nil {
+								return err
+							}
+							if !teamChecklist.HasIncomplete(query.Incomplete) {
+								continue
+							}
+							if skipped < offset {
+								skipped++
+								continue
+							}
+
+							teamChecklists = append(teamChecklists, teamChecklist)
+							if len(teamChecklists) == query.Size {
+								break
+							}
+						}
+					}
+					return nil
+				}); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = teamChecklists
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"arkavidia-backend-8.0/competition/models"
+	"arkavidia-backend-8.0/competition/repository"
+)
+
+// Pelanggaran aturan komposisi team dikembalikan secara terstruktur beserta daftar pelanggarannya
+func abortWithCompositionError(c *gin.Context, err error) bool {
+	compositionError := &models.CompositionError{}
+	if !errors.As(err, &compositionError) {
+		return false
+	}
+
+	response := repository.Response[[]models.CompositionViolation]{}
+	response.Message = "ERROR: TEAM COMPOSITION VIOLATED"
+	response.Data = compositionError.Violations
+	c.AbortWithStatusJSON(http.StatusUnprocessableEntity, response)
+	return true
+}
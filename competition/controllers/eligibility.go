@@ -0,0 +1,25 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"arkavidia-backend-8.0/competition/models"
+	"arkavidia-backend-8.0/competition/repository"
+)
+
+// Alasan team tidak memenuhi syarat dikembalikan secara terstruktur agar dapat ditampilkan kepada team
+func abortWithEligibilityError(c *gin.Context, err error) bool {
+	eligibilityError := &models.EligibilityError{}
+	if !errors.As(err, &eligibilityError) {
+		return false
+	}
+
+	response := repository.Response[[]models.EligibilityReason]{}
+	response.Message = "ERROR: TEAM IS NOT ELIGIBLE"
+	response.Data = eligibilityError.Reasons
+	c.AbortWithStatusJSON(http.StatusUnprocessableEntity, response)
+	return true
+}
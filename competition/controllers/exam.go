@@ -0,0 +1,632 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"arkavidia-backend-8.0/competition/middlewares"
+	"arkavidia-backend-8.0/competition/models"
+	"arkavidia-backend-8.0/competition/repository"
+	databaseService "arkavidia-backend-8.0/competition/services/database"
+	"arkavidia-backend-8.0/competition/types"
+	"arkavidia-backend-8.0/competition/utils/mail"
+)
+
+func GetExamsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[[]models.Exam]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin, middlewares.Team:
+			{
+				exams := []models.Exam{}
+				if err := db.Order("start_at").Find(&exams).Error; err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = exams
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+func AddExamHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[models.Exam]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				request := repository.AddExamRequest{}
+				if err := c.ShouldBindJSON(&request); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				adminID := value.(uint)
+				exam := models.Exam{Name: request.Name, Stage: request.Stage, StartAt: request.StartAt, EndAt: request.EndAt, Duration: request.Duration, AdminID: adminID}
+				if err := db.Transaction(func(tx *gorm.DB) error {
+					if err := tx.Create(&exam).Error; err != nil {
+						return err
+					}
+
+					for _, questionRequest := range request.Questions {
+						question := models.Question{ExamID: exam.ID, Type: questionRequest.Type, Content: questionRequest.Content, Point: questionRequest.Point, Order: questionRequest.Order, Key: questionRequest.Key}
+						for _, optionRequest := range questionRequest.Options {
+							question.Options = append(question.Options, models.QuestionOption{Content: optionRequest.Content, IsCorrect: optionRequest.IsCorrect})
+						}
+						if err := tx.Create(&question).Error; err != nil {
+							return err
+						}
+						exam.Questions = append(exam.Questions, question)
+					}
+					return nil
+				}); err != nil {
+					response.Message = err.Error()
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = exam
+				c.JSON(http.StatusCreated, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+// Team hanya dapat melihat soal selama attempt miliknya masih berlangsung
+func GetExamQuestionsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[[]models.Question]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		query := repository.ExamQuery{}
+		if err := c.ShouldBindQuery(&query); err != nil {
+			response.Message = "ERROR: BAD REQUEST"
+			c.AbortWithStatusJSON(http.StatusBadRequest, response)
+			return
+		}
+
+		switch role {
+		case middlewares.Admin:
+			{
+				condition := models.Question{ExamID: query.ExamID}
+				questions := []models.Question{}
+				if err := db.Preload("Options").Where(&condition).Order("\"order\", id").Find(&questions).Error; err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = questions
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		case middlewares.Team:
+			{
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				teamID := value.(uint)
+				conditionAttempt := models.Attempt{ExamID: query.ExamID, TeamID: teamID}
+				attempt := models.Attempt{}
+				if err := db.Where(&conditionAttempt).First(&attempt).Error; err != nil {
+					response.Message = "ERROR: ATTEMPT NOT FOUND"
+					c.AbortWithStatusJSON(http.StatusNotFound, response)
+					return
+				}
+				if !attempt.IsOpen(db.NowFunc()) {
+					response.Message = "ERROR: ATTEMPT IS CLOSED"
+					c.AbortWithStatusJSON(http.StatusForbidden, response)
+					return
+				}
+
+				condition := models.Question{ExamID: query.ExamID}
+				questions := []models.Question{}
+				if err := db.Preload("Options").Where(&condition).Order("\"order\", id").Find(&questions).Error; err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = questions
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+// Ekspor seluruh jawaban beserta kunci jawaban dalam format CSV untuk penilaian manual
+func ExportAnswersHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[string]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				query := repository.ExamQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				answers := []models.Answer{}
+				if err := db.Preload("Attempt.Team").Preload("Question.Options").Joins("JOIN attempts ON attempts.id = answers.attempt_id AND attempts.deleted_at IS NULL").Where("attempts.exam_id = ?", query.ExamID).Order("answers.attempt_id, answers.question_id").Find(&answers).Error; err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				buffer := new(bytes.Buffer)
+				writer := csv.NewWriter(buffer)
+				writer.Write([]string{"answer_id", "attempt_id", "team_id", "team_name", "question_id", "type", "question", "answer", "key", "is_correct", "point", "max_point"})
+				for _, answer := range answers {
+					answerText := answer.Text
+					key := answer.Question.Key
+					for _, option := range answer.Question.Options {
+						if answer.OptionID != nil && option.ID == *answer.OptionID {
+							answerText = option.Content
+						}
+						if answer.Question.Type == types.MultipleChoice && option.IsCorrect {
+							key = option.Content
+						}
+					}
+
+					isCorrect := ""
+					if answer.IsCorrect != nil {
+						isCorrect = strconv.FormatBool(*answer.IsCorrect)
+					}
+					point := ""
+					if answer.Point != nil {
+						point = strconv.FormatFloat(*answer.Point, 'f', -1, 64)
+					}
+
+					writer.Write([]string{
+						strconv.FormatUint(uint64(answer.ID), 10),
+						strconv.FormatUint(uint64(answer.AttemptID), 10),
+						strconv.FormatUint(uint64(answer.Attempt.TeamID), 10),
+						answer.Attempt.Team.TeamName,
+						strconv.FormatUint(uint64(answer.QuestionID), 10),
+						string(answer.Question.Type),
+						answer.Question.Content,
+						answerText,
+						key,
+						isCorrect,
+						point,
+						strconv.FormatFloat(answer.Question.Point, 'f', -1, 64),
+					})
+				}
+				writer.Flush()
+				if err := writer.Error(); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=exam-%d-answers.csv", query.ExamID))
+				c.Data(http.StatusOK, "text/csv", buffer.Bytes())
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+// Penilaian manual untuk soal isian singkat yang tidak memiliki kunci jawaban
+func GradeAnswerHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[models.Attempt]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				query := repository.GradeAnswerQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				request := repository.GradeAnswerRequest{}
+				if err := c.ShouldBindJSON(&request); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				attempt := models.Attempt{}
+				if err := db.Transaction(func(tx *gorm.DB) error {
+					conditionAnswer := models.Answer{Model: gorm.Model{ID: query.AnswerID}}
+					answer := models.Answer{}
+					if err := tx.Preload("Question").Where(&conditionAnswer).First(&answer).Error; err != nil {
+						return fmt.Errorf("ERROR: ANSWER NOT FOUND")
+					}
+					if answer.Question.IsAutoGraded() {
+						return fmt.Errorf("ERROR: ANSWER IS AUTO GRADED")
+					}
+					if *request.Point > answer.Question.Point {
+						return fmt.Errorf("ERROR: POINT EXCEEDS QUESTION POINT")
+					}
+
+					conditionAttempt := models.Attempt{Model: gorm.Model{ID: answer.AttemptID}}
+					if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(&conditionAttempt).First(&attempt).Error; err != nil {
+						return err
+					}
+					if attempt.IsOpen(tx.NowFunc()) {
+						return fmt.Errorf("ERROR: ATTEMPT IS STILL OPEN")
+					}
+
+					if err := tx.Model(&answer).UpdateColumns(map[string]interface{}{"is_correct": *request.Point > 0, "point": *request.Point}).Error; err != nil {
+						return err
+					}
+
+					return attempt.Grade(tx)
+				}); err != nil {
+					response.Message = err.Error()
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = attempt
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+func GetExamResultHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[[]models.ExamResult]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				query := repository.ExamQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				condition := models.Exam{Model: gorm.Model{ID: query.ExamID}}
+				exam := models.Exam{}
+				if err := db.Where(&condition).First(&exam).Error; err != nil {
+					response.Message = "ERROR: EXAM NOT FOUND"
+					c.AbortWithStatusJSON(http.StatusNotFound, response)
+					return
+				}
+
+				results, err := models.GetExamResults(db, exam)
+				if err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = results
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+// Seluruh attempt dinilai setelah ujian berakhir, termasuk attempt yang tidak dikumpulkan hingga waktunya habis
+func CloseExamHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[[]models.ExamResult]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				request := repository.CloseExamRequest{}
+				if err := c.ShouldBindJSON(&request); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				results := []models.ExamResult{}
+				if err := db.Transaction(func(tx *gorm.DB) error {
+					condition := models.Exam{Model: gorm.Model{ID: request.ExamID}}
+					exam := models.Exam{}
+					if err := tx.Where(&condition).First(&exam).Error; err != nil {
+						return fmt.Errorf("ERROR: EXAM NOT FOUND")
+					}
+					if tx.NowFunc().Before(exam.EndAt) {
+						return fmt.Errorf("ERROR: EXAM IS STILL OPEN")
+					}
+
+					if err := models.GradeExam(tx, exam); err != nil {
+						return err
+					}
+
+					var err error
+					results, err = models.GetExamResults(tx, exam)
+					return err
+				}); err != nil {
+					response.Message = err.Error()
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = results
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+// Team dengan peringkat teratas sebanyak PassedCount lolos, sisanya serta team yang tidak mengikuti ujian tereliminasi
+func DecideExamHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[[]models.ExamResult]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				request := repository.DecideExamRequest{}
+				if err := c.ShouldBindJSON(&request); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				adminID := value.(uint)
+				results := []models.ExamResult{}
+				stageTransitions := []models.StageTransition{}
+				emails := map[uint][]string{}
+				if err := db.Transaction(func(tx *gorm.DB) error {
+					conditionExam := models.Exam{Model: gorm.Model{ID: request.ExamID}}
+					exam := models.Exam{}
+					if err := tx.Where(&conditionExam).First(&exam).Error; err != nil {
+						return fmt.Errorf("ERROR: EXAM NOT FOUND")
+					}
+					if tx.NowFunc().Before(exam.EndAt) {
+						return fmt.Errorf("ERROR: EXAM IS STILL OPEN")
+					}
+
+					if err := models.GradeExam(tx, exam); err != nil {
+						return err
+					}
+
+					var err error
+					results, err = models.GetExamResults(tx, exam)
+					if err != nil {
+						return err
+					}
+
+					statuses := map[uint]types.TeamStatus{}
+					for _, result := range results {
+						if !result.IsGraded {
+							return fmt.Errorf("ERROR: EXAM NOT FULLY GRADED")
+						}
+
+						statuses[result.TeamID] = types.Eliminated
+						if result.Rank <= request.PassedCount {
+							statuses[result.TeamID] = types.Passed
+						}
+					}
+
+					conditionEnrolment := models.Enrolment{TeamCategory: types.Arkalogica}
+					enrolments := []models.Enrolment{}
+					if err := tx.Where(&conditionEnrolment).Find(&enrolments).Error; err != nil {
+						return err
+					}
+
+					for _, enrolment := range enrolments {
+						// Enrolment yang telah berpindah dari stage ini tidak diputuskan ulang
+						if enrolment.GetCurrentStage() != exam.Stage || enrolment.Status != types.WaitingForEvaluation {
+							continue
+						}
+
+						status, exists := statuses[enrolment.TeamID]
+						if !exists {
+							status = types.Eliminated
+						}
+
+						stageTransition, err := models.TransitionEnrolment(tx, enrolment.ID, status, adminID)
+						if err != nil {
+							return err
+						}
+						stageTransitions = append(stageTransitions, stageTransition)
+
+						emails[enrolment.TeamID], err = models.GetMemberEmails(tx, enrolment.TeamID)
+						if err != nil {
+							return err
+						}
+					}
+
+					// Keputusan ujian sekaligus mempublikasikan nilai attempt kepada team
+					return tx.Model(&exam).Update("result_published_at", tx.NowFunc()).Error
+				}); err != nil {
+					response.Message = err.Error()
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				// Asynchronously mail the stage result to every member of each team
+				for _, stageTransition := range stageTransitions {
+					if subject, template, ok := stageTransition.GetMailTemplate(); ok {
+						for _, email := range emails[stageTransition.TeamID] {
+							mail.Broker.AddMailToBroker(mail.MailParameters{Email: email, Subject: subject, Template: template, Data: stageTransition.GetMailData()})
+						}
+					}
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = results
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,361 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	invitationConfig "arkavidia-backend-8.0/competition/config/invitation"
+	"arkavidia-backend-8.0/competition/middlewares"
+	"arkavidia-backend-8.0/competition/models"
+	"arkavidia-backend-8.0/competition/repository"
+	databaseService "arkavidia-backend-8.0/competition/services/database"
+	"arkavidia-backend-8.0/competition/types"
+	"arkavidia-backend-8.0/competition/utils/mail"
+)
+
+func GetInvitationsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[[]models.Invitation]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				query := repository.GetInvitationsQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				condition := models.Invitation{TeamID: query.TeamID}
+				invitations := []models.Invitation{}
+				if err := db.Preload("Team").Where(&condition).Order("created_at DESC").Find(&invitations).Error; err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = invitations
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		case middlewares.Team:
+			{
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				teamID := value.(uint)
+				invitations := []models.Invitation{}
+				if err := db.Transaction(func(tx *gorm.DB) error {
+					if err := models.ExpireInvitations(tx, teamID); err != nil {
+						return err
+					}
+
+					condition := models.Invitation{TeamID: teamID}
+					if err := tx.Preload("Team").Where(&condition).Order("created_at DESC").Find(&invitations).Error; err != nil {
+						return err
+					}
+
+					return nil
+				}); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = invitations
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+func InviteMemberHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[models.Invitation]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Team:
+			{
+				request := repository.InviteMemberRequest{}
+				if err := c.ShouldBindJSON(&request); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				teamID := value.(uint)
+				config := invitationConfig.Config.GetMetadata()
+				participant := models.Participant{}
+				invitation := models.Invitation{}
+				if err := db.Transaction(func(tx *gorm.DB) error {
+					if err := models.ExpireInvitations(tx, teamID); err != nil {
+						return err
+					}
+
+					// Data participant hanya digunakan apabila email belum pernah terdaftar
+					email := models.NormalizeEmail(request.Email)
+					newParticipant := models.Participant{Name: request.Name, Email: email, CareerInterest: request.CareerInterests, Institution: request.Institution, EducationLevel: request.EducationLevel, StudentIDNumber: request.StudentIDNumber, GraduationYear: request.GraduationYear, Phone: request.Phone, Status: types.WaitingForVerification}
+					if err := tx.Where("LOWER(TRIM(email)) = ?", email).Attrs(&newParticipant).FirstOrCreate(&participant).Error; err != nil {
+						return err
+					}
+
+					var err error
+					invitation, err = models.CreateInvitation(tx, teamID, participant, request.Role, time.Now().Add(config.ExpirationDuration))
+					if err != nil {
+						return err
+					}
+
+					return models.ValidateComposition(tx, teamID)
+				}); err != nil {
+					if abortWithCompositionError(c, err) {
+						return
+					}
+					response.Message = err.Error()
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				mailInvitation(invitation, participant.Name)
+
+				response.Message = "SUCCESS"
+				response.Data = invitation
+				c.JSON(http.StatusCreated, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+func RevokeInvitationHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[models.Invitation]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Team:
+			{
+				request := repository.RevokeInvitationRequest{}
+				if err := c.ShouldBindJSON(&request); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				teamID := value.(uint)
+				invitation := models.Invitation{}
+				if err := db.Transaction(func(tx *gorm.DB) error {
+					condition := models.Invitation{Model: gorm.Model{ID: request.InvitationID}, TeamID: teamID}
+					if err := tx.Where(&condition).First(&invitation).Error; err != nil {
+						return err
+					}
+					if invitation.Status != types.InvitationPending {
+						return fmt.Errorf("ERROR: INVITATION IS NO LONGER PENDING")
+					}
+
+					return invitation.Close(tx, types.InvitationRevoked)
+				}); err != nil {
+					response.Message = err.Error()
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = invitation
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+func GetInvitationDetailHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[models.Invitation]{}
+
+		query := repository.InvitationTokenQuery{}
+		if err := c.ShouldBindQuery(&query); err != nil {
+			response.Message = "ERROR: BAD REQUEST"
+			c.AbortWithStatusJSON(http.StatusBadRequest, response)
+			return
+		}
+
+		condition := models.Invitation{Token: query.Token}
+		invitation := models.Invitation{}
+		if err := db.Preload("Team").Where(&condition).First(&invitation).Error; err != nil {
+			response.Message = "ERROR: INVITATION NOT FOUND"
+			c.AbortWithStatusJSON(http.StatusNotFound, response)
+			return
+		}
+		if invitation.IsExpired() {
+			invitation.Status = types.InvitationExpired
+		}
+
+		response.Message = "SUCCESS"
+		response.Data = invitation
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+func AcceptInvitationHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[models.Invitation]{}
+
+		query := repository.InvitationTokenQuery{}
+		if err := c.ShouldBindQuery(&query); err != nil {
+			response.Message = "ERROR: BAD REQUEST"
+			c.AbortWithStatusJSON(http.StatusBadRequest, response)
+			return
+		}
+
+		invitation := models.Invitation{}
+		if err := db.Transaction(func(tx *gorm.DB) error {
+			condition := models.Invitation{Token: query.Token}
+			if err := tx.Preload("Team").Where(&condition).First(&invitation).Error; err != nil {
+				return fmt.Errorf("ERROR: INVITATION NOT FOUND")
+			}
+
+			if err := invitation.Accept(tx); err != nil {
+				return err
+			}
+
+			return models.ValidateComposition(tx, invitation.TeamID)
+		}); err != nil {
+			if abortWithCompositionError(c, err) {
+				return
+			}
+			response.Message = err.Error()
+			c.AbortWithStatusJSON(http.StatusBadRequest, response)
+			return
+		}
+
+		response.Message = "SUCCESS"
+		response.Data = invitation
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+func DeclineInvitationHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[models.Invitation]{}
+
+		query := repository.InvitationTokenQuery{}
+		if err := c.ShouldBindQuery(&query); err != nil {
+			response.Message = "ERROR: BAD REQUEST"
+			c.AbortWithStatusJSON(http.StatusBadRequest, response)
+			return
+		}
+
+		invitation := models.Invitation{}
+		if err := db.Transaction(func(tx *gorm.DB) error {
+			condition := models.Invitation{Token: query.Token}
+			if err := tx.Preload("Team").Where(&condition).First(&invitation).Error; err != nil {
+				return fmt.Errorf("ERROR: INVITATION NOT FOUND")
+			}
+			if invitation.IsExpired() {
+				return fmt.Errorf("ERROR: INVITATION EXPIRED")
+			}
+			if invitation.Status != types.InvitationPending {
+				return fmt.Errorf("ERROR: INVITATION IS NO LONGER PENDING")
+			}
+
+			return invitation.Close(tx, types.InvitationDeclined)
+		}); err != nil {
+			response.Message = err.Error()
+			c.AbortWithStatusJSON(http.StatusBadRequest, response)
+			return
+		}
+
+		response.Message = "SUCCESS"
+		response.Data = invitation
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// Asynchronously mail the invitation links to the invited participant
+func mailInvitation(invitation models.Invitation, name string) {
+	config := invitationConfig.Config.GetMetadata()
+	mail.Broker.AddMailToBroker(mail.MailParameters{Email: invitation.Email, Subject: "Team Invitation", Template: "invitation", Data: map[string]interface{}{
+		"Name":       name,
+		"TeamName":   invitation.Team.TeamName,
+		"Role":       invitation.Role,
+		"AcceptURL":  fmt.Sprintf("%s?token=%s&action=accept", config.URL, invitation.Token),
+		"DeclineURL": fmt.Sprintf("%s?token=%s&action=decline", config.URL, invitation.Token),
+		"ExpiresAt":  invitation.ExpiresAt.Format(time.RFC1123),
+	}})
+}
@@ -0,0 +1,278 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"arkavidia-backend-8.0/competition/middlewares"
+	"arkavidia-backend-8.0/competition/models"
+	"arkavidia-backend-8.0/competition/repository"
+	databaseService "arkavidia-backend-8.0/competition/services/database"
+	"arkavidia-backend-8.0/competition/types"
+	"arkavidia-backend-8.0/competition/utils/payment"
+)
+
+func GetInvoicesHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[[]models.Invoice]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		query := repository.GetInvoicesQuery{}
+		if err := c.ShouldBindQuery(&query); err != nil {
+			response.Message = "ERROR: BAD REQUEST"
+			c.AbortWithStatusJSON(http.StatusBadRequest, response)
+			return
+		}
+
+		switch role {
+		case middlewares.Admin:
+			{
+				condition := models.Invoice{TeamID: query.TeamID, TeamCategory: query.TeamCategory, Status: query.Status}
+				invoices := []models.Invoice{}
+				if err := db.Where(&condition).Order("created_at DESC").Find(&invoices).Error; err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = invoices
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		case middlewares.Team:
+			{
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				teamID := value.(uint)
+				condition := models.Invoice{TeamID: teamID, TeamCategory: query.TeamCategory, Status: query.Status}
+				invoices := []models.Invoice{}
+				if err := db.Where(&condition).Order("created_at DESC").Find(&invoices).Error; err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = invoices
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+// NOTE: Invoice yang masih aktif dikembalikan kembali sehingga team tidak membuat transaksi ganda pada provider,
+// kecuali team menerapkan kode voucher pada invoice yang belum dibayar atau transaksi invoice sebelumnya gagal dibuat
+func CreateInvoiceHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[models.Invoice]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Team:
+			{
+				request := repository.CreateInvoiceRequest{}
+				if err := c.ShouldBindJSON(&request); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				teamID := value.(uint)
+				enrolment, err := models.FindEnrolment(db, teamID, request.TeamCategory)
+				if err != nil {
+					response.Message = err.Error()
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				// Voucher hanya dapat diterapkan setelah transaksi invoice sebelumnya dibatalkan pada provider
+				if request.VoucherCode != "" {
+					if err := expireActiveInvoice(db, enrolment, request.VoucherCode); err != nil {
+						response.Message = err.Error()
+						c.AbortWithStatusJSON(http.StatusBadRequest, response)
+						return
+					}
+				}
+
+				// Invoice disimpan terlebih dahulu, kemudian transaksi pada provider dibuat di luar transaksi basis data
+				// agar lock enrolment tidak ditahan selama pemanggilan HTTP ke provider
+				invoice := models.Invoice{}
+				if err := db.Transaction(func(tx *gorm.DB) error {
+					var err error
+					invoice, err = models.IssueInvoice(tx, enrolment.ID, request.VoucherCode)
+					return err
+				}); err != nil {
+					response.Message = err.Error()
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				if invoice.RequiresTransaction(db.NowFunc()) {
+					transaction, err := payment.CreateTransaction(payment.TransactionRequest{ExternalID: invoice.ExternalID, Amount: invoice.Amount, Description: fmt.Sprintf("Arkavidia 8.0 %s Registration Fee", string(invoice.TeamCategory))})
+					if err != nil {
+						response.Message = "ERROR: PAYMENT PROVIDER CANNOT BE ACCESSED"
+						c.AbortWithStatusJSON(http.StatusBadGateway, response)
+						return
+					}
+
+					attached := false
+					if err := db.Transaction(func(tx *gorm.DB) error {
+						var err error
+						attached, err = models.AttachPaymentTransaction(tx, &invoice, transaction.ID, transaction.InvoiceURL, transaction.ExpiryDate)
+						return err
+					}); err != nil {
+						payment.ExpireTransaction(transaction.ID)
+						response.Message = "ERROR: BAD REQUEST"
+						c.AbortWithStatusJSON(http.StatusBadRequest, response)
+						return
+					}
+
+					// Transaksi ganda dari permintaan yang berjalan bersamaan dibatalkan pada provider
+					if !attached {
+						payment.ExpireTransaction(transaction.ID)
+					}
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = invoice
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+// Transaksi invoice yang belum dibayar dibatalkan pada provider sebelum invoice tersebut digantikan,
+// kode voucher dicek terlebih dahulu agar transaksi tidak dibatalkan untuk voucher yang tidak valid
+func expireActiveInvoice(db *gorm.DB, enrolment models.Enrolment, voucherCode string) error {
+	invoice, err := models.FindActiveInvoice(db, enrolment.ID)
+	if err != nil {
+		return err
+	}
+	if invoice.Status != types.InvoicePending || invoice.ProviderID == "" || invoice.VoucherID != 0 || invoice.IsExpired(db.NowFunc()) {
+		return nil
+	}
+
+	if _, err := models.FindRedeemableVoucher(db, voucherCode, enrolment.TeamID, enrolment.TeamCategory); err != nil {
+		return err
+	}
+	if err := payment.ExpireTransaction(invoice.ProviderID); err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		_, err := models.SettleInvoice(tx, invoice.ExternalID, types.InvoiceCancelled, 0, time.Time{})
+		return err
+	})
+}
+
+// NOTE: Webhook dipanggil oleh payment provider tanpa autentikasi sehingga keaslian payload diverifikasi melalui tanda tangan HMAC
+func PaymentWebhookHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		response := repository.Response[models.Invoice]{}
+
+		body, err := c.GetRawData()
+		if err != nil {
+			response.Message = "ERROR: BAD REQUEST"
+			c.AbortWithStatusJSON(http.StatusBadRequest, response)
+			return
+		}
+
+		if !payment.VerifySignature(body, c.GetHeader(payment.SignatureHeader)) {
+			response.Message = "ERROR: INVALID SIGNATURE"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		payload := payment.WebhookPayload{}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			response.Message = "ERROR: BAD REQUEST"
+			c.AbortWithStatusJSON(http.StatusBadRequest, response)
+			return
+		}
+
+		status := types.InvoiceStatus("")
+		switch payload.Status {
+		case payment.StatusPaid:
+			status = types.InvoicePaid
+		case payment.StatusExpired:
+			status = types.InvoiceExpired
+		default:
+			// Status lain tidak mengubah invoice namun tetap diterima agar provider tidak mengirim ulang webhook
+			response.Message = "SUCCESS"
+			c.JSON(http.StatusOK, response)
+			return
+		}
+
+		// Koneksi basis data baru dibuka setelah tanda tangan dan payload webhook valid
+		db := databaseService.DB.GetConnection()
+		paidAt := time.Time{}
+		if payload.PaidAt != nil {
+			paidAt = *payload.PaidAt
+		}
+
+		invoice := models.Invoice{}
+		if err := db.Transaction(func(tx *gorm.DB) error {
+			var err error
+			invoice, err = models.SettleInvoice(tx, payload.ExternalID, status, payload.Amount, paidAt)
+			return err
+		}); err != nil {
+			response.Message = err.Error()
+			c.AbortWithStatusJSON(http.StatusBadRequest, response)
+			return
+		}
+
+		response.Message = "SUCCESS"
+		response.Data = invoice
+		c.JSON(http.StatusOK, response)
+	}
+}
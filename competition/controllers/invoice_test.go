@@ -0,0 +1,171 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"arkavidia-backend-8.0/competition/models"
+	databaseService "arkavidia-backend-8.0/competition/services/database"
+	"arkavidia-backend-8.0/competition/types"
+	"arkavidia-backend-8.0/competition/utils/payment"
+)
+
+const testWebhookSecret = "test-webhook-secret"
+
+var (
+	testConnectionOnce sync.Once
+	testConnectionErr  error
+)
+
+func TestMain(m *testing.M) {
+	os.Setenv("PAYMENT_WEBHOOK_SECRET", testWebhookSecret)
+	os.Setenv("PAYMENT_INVOICE_DURATION", "3600")
+	gin.SetMode(gin.TestMode)
+
+	os.Exit(m.Run())
+}
+
+// NOTE: Test yang membutuhkan basis data PostgreSQL (POSTGRES_HOST, POSTGRES_PORT, dst.) dijalankan dari root repository
+// agar migration dapat dibaca
+func getTestConnection(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	if os.Getenv("POSTGRES_HOST") == "" {
+		t.Skip("POSTGRES_HOST is not set")
+	}
+	testConnectionOnce.Do(func() {
+		testConnectionErr = os.Chdir("../..")
+	})
+	if testConnectionErr != nil {
+		t.Fatal(testConnectionErr)
+	}
+
+	return databaseService.DB.GetConnection()
+}
+
+func newWebhookServer() *httptest.Server {
+	router := gin.New()
+	router.POST("/invoice/webhook", PaymentWebhookHandler())
+	return httptest.NewServer(router)
+}
+
+func postWebhook(t *testing.T, server *httptest.Server, body []byte, signature string) int {
+	t.Helper()
+
+	request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/invoice/webhook", server.URL), bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if signature != "" {
+		request.Header.Set(payment.SignatureHeader, signature)
+	}
+
+	response, err := server.Client().Do(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+
+	return response.StatusCode
+}
+
+func TestPaymentWebhookHandlerRejectsInvalidRequest(t *testing.T) {
+	server := newWebhookServer()
+	defer server.Close()
+
+	body := []byte(`{"external_id":"ARKAVIDIA-1","amount":150000,"status":"PAID"}`)
+	malformed := []byte(`{"external_id":`)
+
+	testCases := []struct {
+		name      string
+		body      []byte
+		signature string
+		expected  int
+	}{
+		{name: "missing signature", body: body, signature: "", expected: http.StatusUnauthorized},
+		{name: "wrong secret", body: body, signature: payment.Sign("another-secret", body), expected: http.StatusUnauthorized},
+		{name: "signature of another body", body: body, signature: payment.Sign(testWebhookSecret, malformed), expected: http.StatusUnauthorized},
+		{name: "malformed payload", body: malformed, signature: payment.Sign(testWebhookSecret, malformed), expected: http.StatusBadRequest},
+		{name: "ignored status", body: []byte(`{"status":"PENDING"}`), signature: payment.Sign(testWebhookSecret, []byte(`{"status":"PENDING"}`)), expected: http.StatusOK},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if actual := postWebhook(t, server, testCase.body, testCase.signature); actual != testCase.expected {
+				t.Errorf("status = %d, expected %d", actual, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestPaymentWebhookHandlerSettlesInvoice(t *testing.T) {
+	db := getTestConnection(t)
+	suffix := uuid.NewString()[:8]
+	team := models.Team{Username: fmt.Sprintf("webhook-%s", suffix), HashedPassword: []byte("password"), TeamName: fmt.Sprintf("Webhook %s", suffix)}
+	if err := db.Create(&team).Error; err != nil {
+		t.Fatal(err)
+	}
+	enrolment := models.Enrolment{TeamID: team.ID, TeamCategory: types.Datavidia, Status: types.WaitingForEvaluation}
+	if err := db.Create(&enrolment).Error; err != nil {
+		t.Fatal(err)
+	}
+	invoice := models.Invoice{TeamID: team.ID, EnrolmentID: enrolment.ID, TeamCategory: enrolment.TeamCategory, Amount: 150000, Status: types.InvoicePending}
+	if err := db.Create(&invoice).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	server := newWebhookServer()
+	defer server.Close()
+
+	testCases := []struct {
+		name     string
+		payload  payment.WebhookPayload
+		expected int
+		status   types.InvoiceStatus
+	}{
+		{name: "amount mismatch", payload: payment.WebhookPayload{ExternalID: invoice.ExternalID, Amount: 1, Status: payment.StatusPaid}, expected: http.StatusBadRequest, status: types.InvoicePending},
+		{name: "unknown invoice", payload: payment.WebhookPayload{ExternalID: "ARKAVIDIA-UNKNOWN", Amount: 150000, Status: payment.StatusPaid}, expected: http.StatusBadRequest, status: types.InvoicePending},
+		{name: "paid", payload: payment.WebhookPayload{ExternalID: invoice.ExternalID, Amount: 150000, Status: payment.StatusPaid}, expected: http.StatusOK, status: types.InvoicePaid},
+		{name: "redelivered", payload: payment.WebhookPayload{ExternalID: invoice.ExternalID, Amount: 150000, Status: payment.StatusPaid}, expected: http.StatusOK, status: types.InvoicePaid},
+		{name: "expired after paid", payload: payment.WebhookPayload{ExternalID: invoice.ExternalID, Status: payment.StatusExpired}, expected: http.StatusOK, status: types.InvoicePaid},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			body, err := json.Marshal(testCase.payload)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actual := postWebhook(t, server, body, payment.Sign(testWebhookSecret, body)); actual != testCase.expected {
+				t.Fatalf("status = %d, expected %d", actual, testCase.expected)
+			}
+
+			settled := models.Invoice{}
+			if err := db.Where(&models.Invoice{Model: gorm.Model{ID: invoice.ID}}).First(&settled).Error; err != nil {
+				t.Fatal(err)
+			}
+			if settled.Status != testCase.status {
+				t.Errorf("invoice status = %s, expected %s", settled.Status, testCase.status)
+			}
+		})
+	}
+
+	approved := models.Enrolment{}
+	if err := db.Where(&models.Enrolment{Model: gorm.Model{ID: enrolment.ID}}).First(&approved).Error; err != nil {
+		t.Fatal(err)
+	}
+	if approved.PaymentApprovedAt.IsZero() {
+		t.Error("payment requirement is not approved after the invoice is paid")
+	}
+}
@@ -0,0 +1,321 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+
+	authConfig "arkavidia-backend-8.0/competition/config/authentication"
+	"arkavidia-backend-8.0/competition/middlewares"
+	"arkavidia-backend-8.0/competition/models"
+	"arkavidia-backend-8.0/competition/repository"
+	databaseService "arkavidia-backend-8.0/competition/services/database"
+)
+
+func SignInJudgeHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		config := authConfig.Config.GetMetadata()
+		response := repository.Response[string]{}
+
+		request := repository.SignInJudgeRequest{}
+		if err := c.ShouldBindJSON(&request); err != nil {
+			response.Message = "ERROR: BAD REQUEST"
+			c.AbortWithStatusJSON(http.StatusBadRequest, response)
+			return
+		}
+
+		condition := models.Judge{Username: request.Username}
+		judge := models.Judge{}
+		if err := db.Where(&condition).Find(&judge).Error; err != nil {
+			response.Message = "ERROR: INVALID USERNAME"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		if err := bcrypt.CompareHashAndPassword(judge.HashedPassword, []byte(request.Password)); err != nil {
+			response.Message = "ERROR: INVALID PASSWORD"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		judgeClaims := middlewares.AuthClaims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:    config.ApplicationName,
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(config.LoginExpirationDuration)),
+			},
+			ID:   judge.ID,
+			Role: middlewares.Judge,
+		}
+
+		unsignedAuthToken := jwt.NewWithClaims(config.JWTSigningMethod, judgeClaims)
+		signedAuthToken, err := unsignedAuthToken.SignedString(config.JWTSignatureKey)
+		if err != nil {
+			response.Message = "ERROR: JWT SIGNING ERROR"
+			c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+			return
+		}
+
+		response.Message = "SUCCESS"
+		response.Data = signedAuthToken
+		c.JSON(http.StatusCreated, response)
+	}
+}
+
+func GetAllJudgesHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[[]models.Judge]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				query := repository.GetAllJudgesQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				offset := (query.Page - 1) * query.Size
+				limit := query.Size
+				judges := []models.Judge{}
+
+				if err := db.Offset(offset).Limit(limit).Find(&judges).Error; err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = judges
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+func AddJudgeHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[models.Judge]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				request := repository.AddJudgeRequest{}
+				if err := c.ShouldBindJSON(&request); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				judge := models.Judge{Username: request.Username, HashedPassword: []byte(request.Password), Name: request.Name}
+				if err := db.Create(&judge).Error; err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = judge
+				c.JSON(http.StatusCreated, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+func GetJudgeConflictsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[[]models.JudgeConflict]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				query := repository.GetJudgeConflictsQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				condition := models.JudgeConflict{JudgeID: query.JudgeID}
+				judgeConflicts := []models.JudgeConflict{}
+				if err := db.Where(&condition).Order("judge_id, institution").Find(&judgeConflicts).Error; err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = judgeConflicts
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		case middlewares.Judge:
+			{
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				judgeID := value.(uint)
+				condition := models.JudgeConflict{JudgeID: judgeID}
+				judgeConflicts := []models.JudgeConflict{}
+				if err := db.Where(&condition).Order("institution").Find(&judgeConflicts).Error; err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = judgeConflicts
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+func SetJudgeConflictsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[[]models.JudgeConflict]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Judge:
+			{
+				request := repository.SetJudgeConflictsRequest{}
+				if err := c.ShouldBindJSON(&request); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				// Deklarasi konflik kepentingan selalu menggantikan seluruh deklarasi sebelumnya
+				judgeID := value.(uint)
+				judgeConflicts := []models.JudgeConflict{}
+				if err := db.Transaction(func(tx *gorm.DB) error {
+					condition := models.JudgeConflict{JudgeID: judgeID}
+					if err := tx.Unscoped().Where(&condition).Delete(&models.JudgeConflict{}).Error; err != nil {
+						return err
+					}
+
+					seen := map[string]bool{}
+					for _, institution := range request.Institutions {
+						normalized := models.NormalizeInstitution(institution)
+						if normalized == "" || seen[normalized] {
+							continue
+						}
+						seen[normalized] = true
+
+						judgeConflict := models.JudgeConflict{JudgeID: judgeID, Institution: normalized}
+						if err := tx.Create(&judgeConflict).Error; err != nil {
+							return err
+						}
+						judgeConflicts = append(judgeConflicts, judgeConflict)
+					}
+
+					revoked, flagged, err := models.RevokeConflictingAssignments(tx, judgeID)
+					if err != nil {
+						return err
+					}
+					for _, assignment := range flagged {
+						log.Printf("WARNING: ASSIGNMENT %d OF JUDGE %d NEEDS REVIEW DUE TO CONFLICT OF INTEREST", assignment.ID, judgeID)
+					}
+					if len(revoked) > 0 {
+						log.Printf("INFO: %d ASSIGNMENTS OF JUDGE %d REVOKED DUE TO CONFLICT OF INTEREST", len(revoked), judgeID)
+					}
+
+					return nil
+				}); err != nil {
+					response.Message = err.Error()
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = judgeConflicts
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
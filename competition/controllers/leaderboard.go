@@ -0,0 +1,222 @@
+package controllers
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"path/filepath"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	storageConfig "arkavidia-backend-8.0/competition/config/storage"
+	"arkavidia-backend-8.0/competition/middlewares"
+	"arkavidia-backend-8.0/competition/models"
+	"arkavidia-backend-8.0/competition/repository"
+	databaseService "arkavidia-backend-8.0/competition/services/database"
+	storageService "arkavidia-backend-8.0/competition/services/storage"
+	"arkavidia-backend-8.0/competition/utils/scoring"
+)
+
+// NOTE: Leaderboard dapat diakses secara publik, leaderboard private hanya tersedia setelah dibuka oleh admin
+func GetLeaderboardHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[[]models.LeaderboardEntry]{}
+
+		query := repository.GetLeaderboardQuery{}
+		if err := c.ShouldBindQuery(&query); err != nil {
+			response.Message = "ERROR: BAD REQUEST"
+			c.AbortWithStatusJSON(http.StatusBadRequest, response)
+			return
+		}
+
+		condition := models.GroundTruth{Stage: query.Stage}
+		groundTruth := models.GroundTruth{}
+		if err := db.Where(&condition).First(&groundTruth).Error; err != nil {
+			response.Message = "ERROR: LEADERBOARD NOT FOUND"
+			c.AbortWithStatusJSON(http.StatusNotFound, response)
+			return
+		}
+
+		if query.IsPrivate && !groundTruth.IsRevealed {
+			response.Message = "ERROR: PRIVATE LEADERBOARD NOT REVEALED"
+			c.AbortWithStatusJSON(http.StatusForbidden, response)
+			return
+		}
+
+		leaderboard, err := models.GetLeaderboard(db, groundTruth, query.IsPrivate)
+		if err != nil {
+			response.Message = "ERROR: BAD REQUEST"
+			c.AbortWithStatusJSON(http.StatusBadRequest, response)
+			return
+		}
+
+		response.Message = "SUCCESS"
+		response.Data = leaderboard
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+func SetGroundTruthHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		config := storageConfig.Config.GetMetadata()
+		response := repository.Response[models.GroundTruth]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				request := repository.SetGroundTruthRequest{}
+				if err := c.ShouldBindWith(&request, binding.FormMultipart); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				openedFile, err := request.File.Open()
+				if err != nil {
+					response.Message = "ERROR: FILE CANNOT BE ACCESSED"
+					c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+					return
+				}
+				defer openedFile.Close()
+
+				content, err := ioutil.ReadAll(openedFile)
+				if err != nil {
+					response.Message = "ERROR: FILE CANNOT BE ACCESSED"
+					c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+					return
+				}
+
+				// Ground truth divalidasi terlebih dahulu agar submission tidak gagal dinilai
+				if _, err := scoring.ParseGroundTruth(content, request.IDColumn, request.TargetColumn); err != nil {
+					response.Message = err.Error()
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				adminID := value.(uint)
+				fileUUID := uuid.New()
+				fileExt := filepath.Ext(request.File.Filename)
+				if err := storageService.Client.UploadFile(fmt.Sprintf("%s%s", fileUUID, fileExt), config.GroundTruthDir, bytes.NewReader(content)); err != nil {
+					response.Message = "ERROR: GOOGLE CLOUD STORAGE CANNOT BE ACCESSED"
+					c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+					return
+				}
+
+				condition := models.GroundTruth{Stage: request.Stage}
+				newGroundTruth := models.GroundTruth{FileName: fileUUID, FileExtension: fileExt, Metric: request.Metric, IDColumn: request.IDColumn, TargetColumn: request.TargetColumn, DailyLimit: request.DailyLimit, AdminID: adminID}
+				groundTruth := models.GroundTruth{}
+				if err := db.Transaction(func(tx *gorm.DB) error {
+					if err := tx.Where(&condition).Assign(&newGroundTruth).FirstOrCreate(&groundTruth).Error; err != nil {
+						return err
+					}
+
+					// Ground truth pengganti menghasilkan nilai private baru sehingga leaderboard private ditutup kembali
+					if groundTruth.IsRevealed {
+						if err := tx.Model(&groundTruth).Update("is_revealed", false).Error; err != nil {
+							return err
+						}
+						groundTruth.IsRevealed = false
+					}
+
+					// Nilai submission lama dihitung ulang agar leaderboard tidak mencampur ground truth lama dan baru
+					return scoring.Rescore(tx, groundTruth)
+				}); err != nil {
+					// File ground truth yang telah terunggah dihapus apabila transaksi gagal
+					if err := storageService.Client.DeleteFile(fmt.Sprintf("%s%s", fileUUID, fileExt), config.GroundTruthDir); err != nil {
+						log.Printf("WARNING: GROUND TRUTH %s%s CANNOT BE DELETED FROM STORAGE: %s", fileUUID, fileExt, err.Error())
+					}
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = groundTruth
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+func RevealLeaderboardHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[models.GroundTruth]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				query := repository.RevealLeaderboardQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				condition := models.GroundTruth{Stage: query.Stage}
+				groundTruth := models.GroundTruth{}
+				if err := db.Where(&condition).First(&groundTruth).Error; err != nil {
+					response.Message = "ERROR: LEADERBOARD NOT FOUND"
+					c.AbortWithStatusJSON(http.StatusNotFound, response)
+					return
+				}
+
+				if err := db.Model(&groundTruth).Update("is_revealed", true).Error; err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = groundTruth
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,441 @@
+package controllers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"gorm.io/gorm"
+
+	authConfig "arkavidia-backend-8.0/competition/config/authentication"
+	storageConfig "arkavidia-backend-8.0/competition/config/storage"
+	"arkavidia-backend-8.0/competition/middlewares"
+	"arkavidia-backend-8.0/competition/models"
+	"arkavidia-backend-8.0/competition/repository"
+	databaseService "arkavidia-backend-8.0/competition/services/database"
+	storageService "arkavidia-backend-8.0/competition/services/storage"
+	"arkavidia-backend-8.0/competition/types"
+	"arkavidia-backend-8.0/competition/utils/mail"
+)
+
+// Participant mengakses datanya sendiri, team (leader) mengakses data anggotanya, sedangkan admin mengakses seluruh participant
+func getAccessibleParticipantID(c *gin.Context, db *gorm.DB, participantID uint) (uint, bool) {
+	response := repository.Response[string]{}
+
+	value, exists := c.Get("role")
+	if !exists {
+		response.Message = "UNAUTHORIZED"
+		c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+		return 0, false
+	}
+
+	role := value.(middlewares.AuthRole)
+
+	value, exists = c.Get("id")
+	if !exists {
+		response.Message = "UNAUTHORIZED"
+		c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+		return 0, false
+	}
+
+	switch role {
+	case middlewares.Participant:
+		{
+			return value.(uint), true
+		}
+	case middlewares.Team:
+		{
+			teamID := value.(uint)
+			// Participant yang baru diundang belum menjadi anggota sehingga datanya tidak dapat diakses team
+			condition := models.Membership{TeamID: teamID, ParticipantID: participantID, Status: types.ActiveMembership}
+			membership := models.Membership{}
+			if participantID == 0 || db.Where(&condition).First(&membership).Error != nil {
+				response.Message = "ERROR: PARTICIPANT IS NOT A MEMBER OF THE TEAM"
+				c.AbortWithStatusJSON(http.StatusForbidden, response)
+				return 0, false
+			}
+			return participantID, true
+		}
+	case middlewares.Admin:
+		{
+			if participantID == 0 {
+				response.Message = "ERROR: BAD REQUEST"
+				c.AbortWithStatusJSON(http.StatusBadRequest, response)
+				return 0, false
+			}
+			return participantID, true
+		}
+	default:
+		{
+			response.Message = "ERROR: INVALID ROLE"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return 0, false
+		}
+	}
+}
+
+// NOTE: Tautan akses dikirim tanpa memberitahukan apakah email terdaftar agar email participant tidak dapat ditebak
+func RequestParticipantAccessHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		config := authConfig.Config.GetMetadata()
+		response := repository.Response[string]{}
+
+		request := repository.RequestParticipantAccessRequest{}
+		if err := c.ShouldBindJSON(&request); err != nil {
+			response.Message = "ERROR: BAD REQUEST"
+			c.AbortWithStatusJSON(http.StatusBadRequest, response)
+			return
+		}
+
+		participant := models.Participant{}
+		if err := db.Where("LOWER(TRIM(email)) = ?", models.NormalizeEmail(request.Email)).Find(&participant).Error; err != nil {
+			response.Message = "ERROR: BAD REQUEST"
+			c.AbortWithStatusJSON(http.StatusBadRequest, response)
+			return
+		}
+
+		if participant.ID != 0 && participant.AnonymisedAt.IsZero() {
+			expiresAt := time.Now().Add(config.ParticipantAccessExpirationDuration)
+			authClaims := middlewares.AuthClaims{
+				RegisteredClaims: jwt.RegisteredClaims{
+					Issuer:    config.ApplicationName,
+					ExpiresAt: jwt.NewNumericDate(expiresAt),
+				},
+				ID:   participant.ID,
+				Role: middlewares.Participant,
+			}
+
+			unsignedAuthToken := jwt.NewWithClaims(config.JWTSigningMethod, authClaims)
+			signedAuthToken, err := unsignedAuthToken.SignedString(config.JWTSignatureKey)
+			if err != nil {
+				response.Message = "ERROR: JWT SIGNING ERROR"
+				c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+				return
+			}
+
+			// Asynchronously mail the access link to the participant
+			mail.Broker.AddMailToBroker(mail.MailParameters{Email: participant.Email, Subject: "Personal Data Access", Template: "participant-access", Data: map[string]interface{}{
+				"Name":      participant.Name,
+				"AccessURL": fmt.Sprintf("%s?token=%s", config.ParticipantAccessURL, signedAuthToken),
+				"ExpiresAt": expiresAt.Format(time.RFC1123),
+			}})
+		}
+
+		response.Message = "SUCCESS"
+		c.JSON(http.StatusCreated, response)
+	}
+}
+
+func GetProfileHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[models.Participant]{}
+
+		query := repository.GetProfileQuery{}
+		if err := c.ShouldBindQuery(&query); err != nil {
+			response.Message = "ERROR: BAD REQUEST"
+			c.AbortWithStatusJSON(http.StatusBadRequest, response)
+			return
+		}
+
+		participantID, ok := getAccessibleParticipantID(c, db, query.ParticipantID)
+		if !ok {
+			return
+		}
+
+		condition := models.Participant{Model: gorm.Model{ID: participantID}}
+		participant := models.Participant{}
+		if err := db.Preload("Memberships").Preload("Photos").Where(&condition).First(&participant).Error; err != nil {
+			response.Message = "ERROR: PARTICIPANT NOT FOUND"
+			c.AbortWithStatusJSON(http.StatusBadRequest, response)
+			return
+		}
+
+		response.Message = "SUCCESS"
+		response.Data = participant
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+func ExportParticipantHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[models.ParticipantExport]{}
+
+		query := repository.ExportParticipantQuery{}
+		if err := c.ShouldBindQuery(&query); err != nil {
+			response.Message = "ERROR: BAD REQUEST"
+			c.AbortWithStatusJSON(http.StatusBadRequest, response)
+			return
+		}
+
+		participantID, ok := getAccessibleParticipantID(c, db, query.ParticipantID)
+		if !ok {
+			return
+		}
+
+		participantExport, err := models.ExportParticipant(db, participantID)
+		if err != nil {
+			response.Message = "ERROR: PARTICIPANT NOT FOUND"
+			c.AbortWithStatusJSON(http.StatusBadRequest, response)
+			return
+		}
+
+		response.Message = "SUCCESS"
+		response.Data = participantExport
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=participant-%d.json", participantID))
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+func GetDeletionRequestsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[[]models.DeletionRequest]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				query := repository.GetDeletionRequestsQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				condition := models.DeletionRequest{Status: query.Status}
+				deletionRequests := []models.DeletionRequest{}
+				if err := db.Where(&condition).Order("created_at").Find(&deletionRequests).Error; err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = deletionRequests
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		case middlewares.Participant:
+			{
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				participantID := value.(uint)
+				condition := models.DeletionRequest{ParticipantID: participantID}
+				deletionRequests := []models.DeletionRequest{}
+				if err := db.Where(&condition).Order("created_at DESC").Find(&deletionRequests).Error; err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = deletionRequests
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+func RequestDeletionHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[models.DeletionRequest]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+		if role != middlewares.Participant && role != middlewares.Team {
+			response.Message = "ERROR: INVALID ROLE"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		request := repository.RequestDeletionRequest{}
+		if err := c.ShouldBindJSON(&request); err != nil {
+			response.Message = "ERROR: BAD REQUEST"
+			c.AbortWithStatusJSON(http.StatusBadRequest, response)
+			return
+		}
+
+		query := repository.RequestDeletionQuery{}
+		if err := c.ShouldBindQuery(&query); err != nil {
+			response.Message = "ERROR: BAD REQUEST"
+			c.AbortWithStatusJSON(http.StatusBadRequest, response)
+			return
+		}
+
+		participantID, ok := getAccessibleParticipantID(c, db, query.ParticipantID)
+		if !ok {
+			return
+		}
+
+		// Permintaan yang diajukan leader atas nama anggotanya mencatat team yang mengajukan
+		deletionRequest := models.DeletionRequest{ParticipantID: participantID, Reason: request.Reason, Status: types.DeletionPending}
+		if role == middlewares.Team {
+			value, _ := c.Get("id")
+			deletionRequest.TeamID = value.(uint)
+		}
+
+		if err := db.Create(&deletionRequest).Error; err != nil {
+			response.Message = err.Error()
+			c.AbortWithStatusJSON(http.StatusBadRequest, response)
+			return
+		}
+
+		response.Message = "SUCCESS"
+		response.Data = deletionRequest
+		c.JSON(http.StatusCreated, response)
+	}
+}
+
+func processDeletionRequestHandler(status types.DeletionRequestStatus) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		config := storageConfig.Config.GetMetadata()
+		response := repository.Response[models.DeletionRequest]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				request := repository.ProcessDeletionRequestRequest{}
+				if err := c.ShouldBindJSON(&request); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				adminID := value.(uint)
+				deletionRequest := models.DeletionRequest{}
+				photos := []models.Photo{}
+				if err := db.Transaction(func(tx *gorm.DB) error {
+					var err error
+					deletionRequest, photos, err = models.ProcessDeletionRequest(tx, request.DeletionRequestID, status, adminID)
+					return err
+				}); err != nil {
+					response.Message = err.Error()
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				// Berkas foto dihapus setelah transaksi berhasil, kegagalan penghapusan dicatat agar dapat dibersihkan manual
+				for _, photo := range photos {
+					if err := storageService.Client.DeleteFile(fmt.Sprintf("%s%s", photo.FileName, photo.FileExtension), config.PhotoDir); err != nil {
+						log.Printf("WARNING: PHOTO %d OF PARTICIPANT %d CANNOT BE DELETED FROM STORAGE: %s", photo.ID, photo.ParticipantID, err.Error())
+					}
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = deletionRequest
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+func ConfirmDeletionRequestHandler() gin.HandlerFunc {
+	return processDeletionRequestHandler(types.DeletionConfirmed)
+}
+
+func RejectDeletionRequestHandler() gin.HandlerFunc {
+	return processDeletionRequestHandler(types.DeletionRejected)
+}
+
+// Participant yang membuka tautan akses telah membuktikan kepemilikan email-nya
+func ConfirmEmailHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[string]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Participant:
+			{
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				participantID := value.(uint)
+				if err := models.ConfirmEmail(db, participantID); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package controllers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"arkavidia-backend-8.0/competition/middlewares"
+	"arkavidia-backend-8.0/competition/models"
+	"arkavidia-backend-8.0/competition/types"
+)
+
+// Server tiruan yang menganggap request berasal dari team yang sudah terautentikasi
+func newParticipantDataServer(teamID uint) *httptest.Server {
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("role", middlewares.Team)
+		c.Set("id", teamID)
+		c.Next()
+	})
+	router.GET("/participant/profile", GetProfileHandler())
+	router.GET("/participant/export", ExportParticipantHandler())
+	router.POST("/participant/deletion", RequestDeletionHandler())
+	return httptest.NewServer(router)
+}
+
+func TestParticipantDataHandlersRequireActiveMembership(t *testing.T) {
+	db := getTestConnection(t)
+
+	suffix := uuid.NewString()[:8]
+	participant := models.Participant{Name: fmt.Sprintf("Participant %s", suffix), Email: fmt.Sprintf("participant-%s@example.com", suffix), CareerInterest: types.ParticipantCareerInterests{types.SoftwareEngineering}, Status: types.WaitingForVerification}
+	if err := db.Create(&participant).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	teams := map[string]*models.Team{}
+	for _, name := range []string{"active", "pending", "unrelated"} {
+		team := models.Team{Username: fmt.Sprintf("%s-%s", name, suffix), HashedPassword: []byte("password"), TeamName: fmt.Sprintf("%s %s", name, suffix)}
+		if err := db.Create(&team).Error; err != nil {
+			t.Fatal(err)
+		}
+		teams[name] = &team
+	}
+
+	membership := models.Membership{TeamID: teams["active"].ID, ParticipantID: participant.ID, Role: types.Leader, Status: types.ActiveMembership}
+	if err := db.Create(&membership).Error; err != nil {
+		t.Fatal(err)
+	}
+	if _, err := models.CreateInvitation(db, teams["pending"].ID, participant, types.Member, time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name     string
+		team     string
+		method   string
+		path     string
+		expected int
+	}{
+		{name: "active member profile", team: "active", method: http.MethodGet, path: "/participant/profile", expected: http.StatusOK},
+		{name: "pending invite profile", team: "pending", method: http.MethodGet, path: "/participant/profile", expected: http.StatusForbidden},
+		{name: "pending invite export", team: "pending", method: http.MethodGet, path: "/participant/export", expected: http.StatusForbidden},
+		{name: "pending invite deletion", team: "pending", method: http.MethodPost, path: "/participant/deletion", expected: http.StatusForbidden},
+		{name: "unrelated team export", team: "unrelated", method: http.MethodGet, path: "/participant/export", expected: http.StatusForbidden},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			server := newParticipantDataServer(teams[testCase.team].ID)
+			defer server.Close()
+
+			request, err := http.NewRequest(testCase.method, fmt.Sprintf("%s%s?participant_id=%d", server.URL, testCase.path, participant.ID), bytes.NewReader([]byte(`{"reason":"tidak lagi mengikuti lomba"}`)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			request.Header.Set("Content-Type", "application/json")
+
+			response, err := server.Client().Do(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+
+			if response.StatusCode != testCase.expected {
+				t.Errorf("status = %d, expected %d", response.StatusCode, testCase.expected)
+			}
+		})
+	}
+
+	conditionDeletionRequest := models.DeletionRequest{ParticipantID: participant.ID}
+	deletionRequests := []models.DeletionRequest{}
+	if err := db.Where(&conditionDeletionRequest).Find(&deletionRequests).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(deletionRequests) != 0 {
+		t.Errorf("deletion requests = %d, expected 0", len(deletionRequests))
+	}
+}
@@ -10,7 +10,6 @@ import (
 	"arkavidia-backend-8.0/competition/models"
 	"arkavidia-backend-8.0/competition/repository"
 	databaseService "arkavidia-backend-8.0/competition/services/database"
-	"arkavidia-backend-8.0/competition/types"
 )
 
 func GetMemberHandler() gin.HandlerFunc {
@@ -168,7 +167,7 @@ func GetAllMembersHandler() gin.HandlerFunc {
 	}
 }
 
-func AddMemberHandler() gin.HandlerFunc {
+func ChangeCareerInterestHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		db := databaseService.DB.GetConnection()
 		response := repository.Response[models.Participant]{}
@@ -185,13 +184,20 @@ func AddMemberHandler() gin.HandlerFunc {
 		switch role {
 		case middlewares.Team:
 			{
-				request := repository.AddMemberRequest{}
+				request := repository.ChangeCareerInterestRequest{}
 				if err := c.ShouldBindJSON(&request); err != nil {
 					response.Message = "ERROR: BAD REQUEST"
 					c.AbortWithStatusJSON(http.StatusBadRequest, response)
 					return
 				}
 
+				query := repository.ChangeCareerInterestQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
 				value, exists := c.Get("id")
 				if !exists {
 					response.Message = "UNAUTHORIZED"
@@ -200,32 +206,24 @@ func AddMemberHandler() gin.HandlerFunc {
 				}
 
 				teamID := value.(uint)
-				participant := models.Participant{}
+				condition := models.Membership{TeamID: teamID, ParticipantID: query.ParticipantID}
 				membership := models.Membership{}
-				if err := db.Transaction(func(tx *gorm.DB) error {
-					condition := models.Participant{Name: request.Name, Email: request.Email, CareerInterest: request.CareerInterests, Status: types.WaitingForVerification}
-					if err := tx.FirstOrCreate(&participant, &condition).Error; err != nil {
-						return err
-					}
-
-					membership = models.Membership{TeamID: teamID, ParticipantID: participant.ID, Role: request.Role}
-					if err := tx.Create(&membership).Error; err != nil {
-						return err
-					}
-
-					participant.Memberships = append(participant.Memberships, membership)
-
-					return nil
+				if err := db.Where(&condition).Find(&membership).Error; err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
 
-				}); err != nil {
+				oldParticipant := models.Participant{Model: gorm.Model{ID: query.ParticipantID}}
+				newParticipant := models.Participant{CareerInterest: request.CareerInterests}
+				if err := db.Where(&oldParticipant).Updates(&newParticipant).Error; err != nil {
 					response.Message = "ERROR: BAD REQUEST"
 					c.AbortWithStatusJSON(http.StatusBadRequest, response)
 					return
 				}
 
 				response.Message = "SUCCESS"
-				response.Data = participant
-				c.JSON(http.StatusCreated, response)
+				c.JSON(http.StatusOK, response)
 				return
 			}
 		default:
@@ -238,7 +236,7 @@ func AddMemberHandler() gin.HandlerFunc {
 	}
 }
 
-func ChangeCareerInterestHandler() gin.HandlerFunc {
+func ChangeProfileHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		db := databaseService.DB.GetConnection()
 		response := repository.Response[models.Participant]{}
@@ -253,16 +251,45 @@ func ChangeCareerInterestHandler() gin.HandlerFunc {
 		role := value.(middlewares.AuthRole)
 
 		switch role {
+		case middlewares.Participant:
+			{
+				request := repository.ChangeProfileRequest{}
+				if err := c.ShouldBindJSON(&request); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				participantID := value.(uint)
+				oldParticipant := models.Participant{Model: gorm.Model{ID: participantID}}
+				newParticipant := models.Participant{Institution: request.Institution, EducationLevel: request.EducationLevel, StudentIDNumber: request.StudentIDNumber, GraduationYear: request.GraduationYear, Phone: request.Phone}
+				if err := db.Where(&oldParticipant).Updates(&newParticipant).Error; err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				c.JSON(http.StatusOK, response)
+				return
+			}
 		case middlewares.Team:
 			{
-				request := repository.ChangeCareerInterestRequest{}
+				request := repository.ChangeProfileRequest{}
 				if err := c.ShouldBindJSON(&request); err != nil {
 					response.Message = "ERROR: BAD REQUEST"
 					c.AbortWithStatusJSON(http.StatusBadRequest, response)
 					return
 				}
 
-				query := repository.ChangeCareerInterestQuery{}
+				query := repository.ChangeProfileQuery{}
 				if err := c.ShouldBindQuery(&query); err != nil {
 					response.Message = "ERROR: BAD REQUEST"
 					c.AbortWithStatusJSON(http.StatusBadRequest, response)
@@ -276,17 +303,18 @@ func ChangeCareerInterestHandler() gin.HandlerFunc {
 					return
 				}
 
+				// Team hanya dapat mengubah profil participant yang menjadi anggotanya
 				teamID := value.(uint)
 				condition := models.Membership{TeamID: teamID, ParticipantID: query.ParticipantID}
 				membership := models.Membership{}
-				if err := db.Where(&condition).Find(&membership).Error; err != nil {
-					response.Message = "ERROR: BAD REQUEST"
+				if err := db.Where(&condition).First(&membership).Error; err != nil {
+					response.Message = "ERROR: PARTICIPANT IS NOT A MEMBER OF THE TEAM"
 					c.AbortWithStatusJSON(http.StatusBadRequest, response)
 					return
 				}
 
 				oldParticipant := models.Participant{Model: gorm.Model{ID: query.ParticipantID}}
-				newParticipant := models.Participant{CareerInterest: request.CareerInterests}
+				newParticipant := models.Participant{Institution: request.Institution, EducationLevel: request.EducationLevel, StudentIDNumber: request.StudentIDNumber, GraduationYear: request.GraduationYear, Phone: request.Phone}
 				if err := db.Where(&oldParticipant).Updates(&newParticipant).Error; err != nil {
 					response.Message = "ERROR: BAD REQUEST"
 					c.AbortWithStatusJSON(http.StatusBadRequest, response)
@@ -346,10 +374,17 @@ func ChangeRoleHandler() gin.HandlerFunc {
 				}
 
 				teamID := value.(uint)
-				oldMembership := models.Membership{TeamID: teamID, ParticipantID: query.ParticipantID}
-				newMembership := models.Membership{Role: request.Role}
-				if err := db.Where(&oldMembership).Updates(&newMembership).Error; err != nil {
-					response.Message = "ERROR: BAD REQUEST"
+				if err := db.Transaction(func(tx *gorm.DB) error {
+					if err := models.ChangeRole(tx, teamID, query.ParticipantID, request.Role, models.RoleActor{ID: teamID, Role: string(role)}); err != nil {
+						return err
+					}
+
+					return models.ValidateComposition(tx, teamID)
+				}); err != nil {
+					if abortWithCompositionError(c, err) {
+						return
+					}
+					response.Message = err.Error()
 					c.AbortWithStatusJSON(http.StatusBadRequest, response)
 					return
 				}
@@ -453,21 +488,296 @@ func DeleteParticipantHandler() gin.HandlerFunc {
 				}
 
 				teamID := value.(uint)
-				condition := models.Membership{TeamID: teamID, ParticipantID: request.ParticipantID}
-				membership := models.Membership{}
-				if err := db.Where(&condition).Find(&membership).Error; err != nil {
+				if err := db.Transaction(func(tx *gorm.DB) error {
+					if err := models.RemoveMember(tx, teamID, request.ParticipantID, request.SuccessorID, models.RoleActor{ID: teamID, Role: string(role)}); err != nil {
+						return err
+					}
+
+					return models.ValidateComposition(tx, teamID)
+				}); err != nil {
+					if abortWithCompositionError(c, err) {
+						return
+					}
+					response.Message = err.Error()
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+func TransferLeadershipHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[models.Participant]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Team:
+			{
+				request := repository.TransferLeadershipRequest{}
+				if err := c.ShouldBindJSON(&request); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				teamID := value.(uint)
+				if err := db.Transaction(func(tx *gorm.DB) error {
+					if err := models.TransferLeadership(tx, teamID, request.SuccessorID, models.RoleActor{ID: teamID, Role: string(role)}); err != nil {
+						return err
+					}
+
+					return models.ValidateComposition(tx, teamID)
+				}); err != nil {
+					if abortWithCompositionError(c, err) {
+						return
+					}
+					response.Message = err.Error()
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+func GetRoleChangesHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[[]models.RoleChange]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				query := repository.GetRoleChangesQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				condition := models.RoleChange{TeamID: query.TeamID}
+				roleChanges := []models.RoleChange{}
+				if err := db.Where(&condition).Order("created_at DESC").Find(&roleChanges).Error; err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = roleChanges
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		case middlewares.Team:
+			{
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				teamID := value.(uint)
+				condition := models.RoleChange{TeamID: teamID}
+				roleChanges := []models.RoleChange{}
+				if err := db.Where(&condition).Order("created_at DESC").Find(&roleChanges).Error; err != nil {
 					response.Message = "ERROR: BAD REQUEST"
 					c.AbortWithStatusJSON(http.StatusBadRequest, response)
 					return
 				}
 
-				if err := db.Delete(&membership); err != nil {
+				response.Message = "SUCCESS"
+				response.Data = roleChanges
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+func GetDuplicateParticipantsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[[]models.DuplicateGroup]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				groups, err := models.FindDuplicateParticipants(db)
+				if err != nil {
 					response.Message = "ERROR: BAD REQUEST"
 					c.AbortWithStatusJSON(http.StatusBadRequest, response)
 					return
 				}
 
 				response.Message = "SUCCESS"
+				response.Data = groups
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+func GetParticipantMergesHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[[]models.ParticipantMerge]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				participantMerges := []models.ParticipantMerge{}
+				if err := db.Order("created_at DESC").Find(&participantMerges).Error; err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = participantMerges
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+func MergeParticipantsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[models.ParticipantMerge]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				request := repository.MergeParticipantsRequest{}
+				if err := c.ShouldBindJSON(&request); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				adminID := value.(uint)
+				participantMerge := models.ParticipantMerge{}
+				if err := db.Transaction(func(tx *gorm.DB) error {
+					result, err := models.MergeParticipants(tx, request.SurvivorID, request.DuplicateID, adminID)
+					if err != nil {
+						return err
+					}
+
+					participantMerge = result
+					return nil
+				}); err != nil {
+					if abortWithCompositionError(c, err) {
+						return
+					}
+					response.Message = err.Error()
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = participantMerge
 				c.JSON(http.StatusOK, response)
 				return
 			}
@@ -20,6 +20,7 @@ import (
 	databaseService "arkavidia-backend-8.0/competition/services/database"
 	storageService "arkavidia-backend-8.0/competition/services/storage"
 	"arkavidia-backend-8.0/competition/types"
+	"arkavidia-backend-8.0/competition/utils/encryption"
 )
 
 func GetPhotoHandler() gin.HandlerFunc {
@@ -192,27 +193,28 @@ func DownloadPhotoHandler() gin.HandlerFunc {
 					return
 				}
 
-				filename := fmt.Sprintf("%s.%s", photo.FileName, photo.FileExtension)
-				IOWriter, err := storageService.Client.DownloadFile(filename, config.PhotoDir)
+				filename := fmt.Sprintf("%s%s", photo.FileName, photo.FileExtension)
+				content, err := storageService.Client.DownloadFile(filename, config.PhotoDir)
 				if err != nil {
 					response.Message = "ERROR: BAD REQUEST"
 					c.AbortWithStatusJSON(http.StatusBadRequest, response)
 					return
 				}
 
-				var content []byte
-				length, err := IOWriter.Write(content)
-				if err != nil {
-					response.Message = "ERROR: CONTENT CANNOT BE WRITTEN"
-					c.AbortWithStatusJSON(http.StatusInternalServerError, response)
-					return
+				if photo.WrappedKey != nil {
+					content, err = encryption.Envelope.Decrypt(content, photo.WrappedKey)
+					if err != nil {
+						response.Message = "ERROR: CONTENT CANNOT BE DECRYPTED"
+						c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+						return
+					}
 				}
 
 				c.Header("Content-Description", "File Transfer")
 				c.Header("Content-Transfer-Encoding", "binary")
 				c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
 				c.Header("Content-Type", "application/octet-stream")
-				c.Header("Accept-Length", fmt.Sprintf("%d", length))
+				c.Header("Accept-Length", fmt.Sprintf("%d", len(content)))
 				c.Writer.Write(content)
 
 				response.Message = "SUCCESS"
@@ -262,20 +264,39 @@ func RenderPhotoHandler() gin.HandlerFunc {
 					return
 				}
 
-				url := fmt.Sprintf("%s/%s/%s/%s%s", config.StorageHost, config.BucketName, config.PhotoDir, photo.FileName, photo.FileExtension)
-				res, err := http.Get(url)
-				if err != nil {
-					response.Message = err.Error()
-					c.AbortWithStatusJSON(http.StatusBadRequest, response)
-					return
-				}
-				defer res.Body.Close()
-
-				content, err := ioutil.ReadAll(res.Body)
-				if err != nil {
-					response.Message = "ERROR: CONTENT CANNOT BE WRITTEN"
-					c.AbortWithStatusJSON(http.StatusInternalServerError, response)
-					return
+				// Dokumen terenkripsi tidak dapat diakses melalui URL publik sehingga harus diunduh dan didekripsi terlebih dahulu
+				var content []byte
+				if photo.WrappedKey != nil {
+					filename := fmt.Sprintf("%s%s", photo.FileName, photo.FileExtension)
+					encryptedContent, err := storageService.Client.DownloadFile(filename, config.PhotoDir)
+					if err != nil {
+						response.Message = "ERROR: CONTENT NOT FOUND IN STORAGE"
+						c.AbortWithStatusJSON(http.StatusBadRequest, response)
+						return
+					}
+
+					content, err = encryption.Envelope.Decrypt(encryptedContent, photo.WrappedKey)
+					if err != nil {
+						response.Message = "ERROR: CONTENT CANNOT BE DECRYPTED"
+						c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+						return
+					}
+				} else {
+					url := fmt.Sprintf("%s/%s/%s/%s%s", config.StorageHost, config.BucketName, config.PhotoDir, photo.FileName, photo.FileExtension)
+					res, err := http.Get(url)
+					if err != nil {
+						response.Message = err.Error()
+						c.AbortWithStatusJSON(http.StatusBadRequest, response)
+						return
+					}
+					defer res.Body.Close()
+
+					content, err = ioutil.ReadAll(res.Body)
+					if err != nil {
+						response.Message = "ERROR: CONTENT CANNOT BE WRITTEN"
+						c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+						return
+					}
 				}
 
 				mtype, err := mimetype.DetectReader(bytes.NewReader(content))
@@ -287,7 +308,7 @@ func RenderPhotoHandler() gin.HandlerFunc {
 				c.Header("Content-Transfer-Encoding", "binary")
 				c.Header("Content-Disposition", "inline")
 				c.Header("Content-Type", mtype.String())
-				c.Header("Accept-Length", fmt.Sprintf("%d", res.ContentLength))
+				c.Header("Accept-Length", fmt.Sprintf("%d", len(content)))
 				c.Writer.Write(content)
 
 				response.Message = "SUCCESS"
@@ -327,20 +348,39 @@ func RenderPhotoHandler() gin.HandlerFunc {
 					return
 				}
 
-				url := fmt.Sprintf("%s/%s/%s/%s%s", config.StorageHost, config.BucketName, config.PhotoDir, photo.FileName, photo.FileExtension)
-				res, err := http.Get(url)
-				if err != nil {
-					response.Message = err.Error()
-					c.AbortWithStatusJSON(http.StatusBadRequest, response)
-					return
-				}
-				defer res.Body.Close()
-
-				content, err := ioutil.ReadAll(res.Body)
-				if err != nil {
-					response.Message = "ERROR: CONTENT CANNOT BE WRITTEN"
-					c.AbortWithStatusJSON(http.StatusInternalServerError, response)
-					return
+				// Dokumen terenkripsi tidak dapat diakses melalui URL publik sehingga harus diunduh dan didekripsi terlebih dahulu
+				var content []byte
+				if photo.WrappedKey != nil {
+					filename := fmt.Sprintf("%s%s", photo.FileName, photo.FileExtension)
+					encryptedContent, err := storageService.Client.DownloadFile(filename, config.PhotoDir)
+					if err != nil {
+						response.Message = "ERROR: CONTENT NOT FOUND IN STORAGE"
+						c.AbortWithStatusJSON(http.StatusBadRequest, response)
+						return
+					}
+
+					content, err = encryption.Envelope.Decrypt(encryptedContent, photo.WrappedKey)
+					if err != nil {
+						response.Message = "ERROR: CONTENT CANNOT BE DECRYPTED"
+						c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+						return
+					}
+				} else {
+					url := fmt.Sprintf("%s/%s/%s/%s%s", config.StorageHost, config.BucketName, config.PhotoDir, photo.FileName, photo.FileExtension)
+					res, err := http.Get(url)
+					if err != nil {
+						response.Message = err.Error()
+						c.AbortWithStatusJSON(http.StatusBadRequest, response)
+						return
+					}
+					defer res.Body.Close()
+
+					content, err = ioutil.ReadAll(res.Body)
+					if err != nil {
+						response.Message = "ERROR: CONTENT CANNOT BE WRITTEN"
+						c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+						return
+					}
 				}
 
 				mtype, err := mimetype.DetectReader(bytes.NewReader(content))
@@ -352,7 +392,7 @@ func RenderPhotoHandler() gin.HandlerFunc {
 				c.Header("Content-Transfer-Encoding", "binary")
 				c.Header("Content-Disposition", "inline")
 				c.Header("Content-Type", mtype.String())
-				c.Header("Accept-Length", fmt.Sprintf("%d", res.ContentLength))
+				c.Header("Accept-Length", fmt.Sprintf("%d", len(content)))
 				c.Writer.Write(content)
 
 				response.Message = "SUCCESS"
@@ -418,17 +458,34 @@ func AddPhotoHandler() gin.HandlerFunc {
 				}
 				defer openedFile.Close()
 
+				content, err := ioutil.ReadAll(openedFile)
+				if err != nil {
+					response.Message = "ERROR: FILE CANNOT BE ACCESSED"
+					c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+					return
+				}
+
+				var wrappedKey []byte
+				if request.Type.IsConfidential() {
+					content, wrappedKey, err = encryption.Envelope.Encrypt(content)
+					if err != nil {
+						response.Message = "ERROR: FILE CANNOT BE ENCRYPTED"
+						c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+						return
+					}
+				}
+
 				fileUUID := uuid.New()
 				fileExt := filepath.Ext(request.File.Filename)
 
-				photo := models.Photo{FileName: fileUUID, FileExtension: fileExt, ParticipantID: request.ParticipantID, Status: types.WaitingForApproval, Type: request.Type}
+				photo := models.Photo{FileName: fileUUID, FileExtension: fileExt, ParticipantID: request.ParticipantID, Status: types.WaitingForApproval, Type: request.Type, WrappedKey: wrappedKey}
 				if err := db.Create(&photo).Error; err != nil {
 					response.Message = "ERROR: BAD REQUEST"
 					c.AbortWithStatusJSON(http.StatusBadRequest, response)
 					return
 				}
 
-				if err := storageService.Client.UploadFile(fmt.Sprintf("%s%s", fileUUID, fileExt), config.PhotoDir, openedFile); err != nil {
+				if err := storageService.Client.UploadFile(fmt.Sprintf("%s%s", fileUUID, fileExt), config.PhotoDir, bytes.NewReader(content)); err != nil {
 					response.Message = "ERROR: GOOGLE CLOUD STORAGE CANNOT BE ACCESSED"
 					c.AbortWithStatusJSON(http.StatusInternalServerError, response)
 					return
@@ -0,0 +1,158 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"arkavidia-backend-8.0/competition/middlewares"
+	"arkavidia-backend-8.0/competition/models"
+	"arkavidia-backend-8.0/competition/repository"
+	databaseService "arkavidia-backend-8.0/competition/services/database"
+	"arkavidia-backend-8.0/competition/utils/receipt"
+)
+
+func DownloadReceiptHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[models.Receipt]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				query := repository.GetReceiptQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				condition := models.Receipt{SubmissionID: query.SubmissionID}
+				submissionReceipt := models.Receipt{}
+				if err := db.Where(&condition).First(&submissionReceipt).Error; err != nil {
+					response.Message = "ERROR: RECEIPT NOT FOUND"
+					c.AbortWithStatusJSON(http.StatusNotFound, response)
+					return
+				}
+
+				content, err := receipt.GeneratePDF(submissionReceipt)
+				if err != nil {
+					response.Message = "ERROR: RECEIPT CANNOT BE GENERATED"
+					c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+					return
+				}
+
+				c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=receipt-%s.pdf", submissionReceipt.Serial))
+				c.Data(http.StatusOK, "application/pdf", content)
+				return
+			}
+		case middlewares.Team:
+			{
+				query := repository.GetReceiptQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				teamID := value.(uint)
+				condition := models.Receipt{SubmissionID: query.SubmissionID, TeamID: teamID}
+				submissionReceipt := models.Receipt{}
+				if err := db.Where(&condition).First(&submissionReceipt).Error; err != nil {
+					response.Message = "ERROR: RECEIPT NOT FOUND"
+					c.AbortWithStatusJSON(http.StatusNotFound, response)
+					return
+				}
+
+				content, err := receipt.GeneratePDF(submissionReceipt)
+				if err != nil {
+					response.Message = "ERROR: RECEIPT CANNOT BE GENERATED"
+					c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+					return
+				}
+
+				c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=receipt-%s.pdf", submissionReceipt.Serial))
+				c.Data(http.StatusOK, "application/pdf", content)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+// NOTE: Verifikasi receipt dapat diakses secara publik tanpa autentikasi
+// Receipt dianggap autentik apabila tanda tangannya valid dan sesuai dengan receipt yang tercatat
+func VerifyReceiptHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[models.Receipt]{}
+
+		query := repository.VerifyReceiptQuery{}
+		if err := c.ShouldBindQuery(&query); err != nil {
+			response.Message = "ERROR: BAD REQUEST"
+			c.AbortWithStatusJSON(http.StatusBadRequest, response)
+			return
+		}
+
+		code := query.Code
+		serial, err := uuid.Parse(query.Serial)
+		if query.Code != "" {
+			payload, err := receipt.Verify(query.Code)
+			if err != nil {
+				response.Message = err.Error()
+				c.AbortWithStatusJSON(http.StatusBadRequest, response)
+				return
+			}
+			serial = payload.Serial
+		} else if err != nil {
+			response.Message = "ERROR: BAD REQUEST"
+			c.AbortWithStatusJSON(http.StatusBadRequest, response)
+			return
+		}
+
+		// Receipt tetap dapat diverifikasi walaupun submission-nya telah dihapus
+		condition := models.Receipt{Serial: serial}
+		submissionReceipt := models.Receipt{}
+		if err := db.Unscoped().Where(&condition).First(&submissionReceipt).Error; err != nil {
+			response.Message = "ERROR: RECEIPT NOT FOUND"
+			c.AbortWithStatusJSON(http.StatusNotFound, response)
+			return
+		}
+		if code == "" {
+			code = submissionReceipt.Code
+		}
+
+		if _, err := receipt.Verify(submissionReceipt.Code); err != nil || code != submissionReceipt.Code {
+			response.Message = receipt.ErrInvalidReceipt.Error()
+			c.AbortWithStatusJSON(http.StatusBadRequest, response)
+			return
+		}
+
+		response.Message = "SUCCESS"
+		response.Data = submissionReceipt
+		c.JSON(http.StatusOK, response)
+	}
+}
@@ -0,0 +1,127 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"arkavidia-backend-8.0/competition/middlewares"
+	"arkavidia-backend-8.0/competition/models"
+	"arkavidia-backend-8.0/competition/repository"
+	databaseService "arkavidia-backend-8.0/competition/services/database"
+)
+
+func GetRubricHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[models.Rubric]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin, middlewares.Judge:
+			{
+				query := repository.GetRubricQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				condition := models.Rubric{TeamCategory: query.TeamCategory, Stage: query.Stage}
+				rubric := models.Rubric{}
+				if err := db.Preload("Criteria").Where(&condition).First(&rubric).Error; err != nil {
+					response.Message = "ERROR: RUBRIC NOT FOUND"
+					c.AbortWithStatusJSON(http.StatusNotFound, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = rubric
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+func AddRubricHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[models.Rubric]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				request := repository.AddRubricRequest{}
+				if err := c.ShouldBindJSON(&request); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				adminID := value.(uint)
+				rubric := models.Rubric{TeamCategory: request.TeamCategory, Stage: request.Stage, AdminID: adminID}
+				if err := db.Transaction(func(tx *gorm.DB) error {
+					if err := tx.Create(&rubric).Error; err != nil {
+						return err
+					}
+
+					for _, criterionRequest := range request.Criteria {
+						criterion := models.Criterion{RubricID: rubric.ID, Name: criterionRequest.Name, Description: criterionRequest.Description, Weight: criterionRequest.Weight, MaxScore: criterionRequest.MaxScore}
+						if err := tx.Create(&criterion).Error; err != nil {
+							return err
+						}
+						rubric.Criteria = append(rubric.Criteria, criterion)
+					}
+					return nil
+				}); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = rubric
+				c.JSON(http.StatusCreated, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,333 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"arkavidia-backend-8.0/competition/middlewares"
+	"arkavidia-backend-8.0/competition/models"
+	"arkavidia-backend-8.0/competition/repository"
+	databaseService "arkavidia-backend-8.0/competition/services/database"
+	"arkavidia-backend-8.0/competition/types"
+	"arkavidia-backend-8.0/competition/utils/mail"
+)
+
+func GetScoreHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[[]models.Score]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				query := repository.GetScoreQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				condition := models.Score{AssignmentID: query.AssignmentID}
+				scores := []models.Score{}
+				if err := db.Where(&condition).Find(&scores).Error; err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = scores
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		case middlewares.Judge:
+			{
+				query := repository.GetScoreQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				judgeID := value.(uint)
+				conditionAssignment := models.Assignment{Model: gorm.Model{ID: query.AssignmentID}, JudgeID: judgeID}
+				assignment := models.Assignment{}
+				if err := db.Preload("Scores").Where(&conditionAssignment).First(&assignment).Error; err != nil {
+					response.Message = "ERROR: ASSIGNMENT NOT FOUND"
+					c.AbortWithStatusJSON(http.StatusNotFound, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = assignment.Scores
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+func SetScoreHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[[]models.Score]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Judge:
+			{
+				request := repository.SetScoreRequest{}
+				if err := c.ShouldBindJSON(&request); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				query := repository.SetScoreQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				judgeID := value.(uint)
+				scores := []models.Score{}
+				if err := db.Transaction(func(tx *gorm.DB) error {
+					conditionAssignment := models.Assignment{Model: gorm.Model{ID: query.AssignmentID}, JudgeID: judgeID}
+					assignment := models.Assignment{}
+					if err := tx.Preload("Submission.Enrolment").Where(&conditionAssignment).First(&assignment).Error; err != nil {
+						return fmt.Errorf("ERROR: ASSIGNMENT NOT FOUND")
+					}
+
+					conditionRubric := models.Rubric{TeamCategory: assignment.Submission.Enrolment.TeamCategory, Stage: assignment.Submission.Stage}
+					rubric := models.Rubric{}
+					if err := tx.Preload("Criteria").Where(&conditionRubric).First(&rubric).Error; err != nil {
+						return fmt.Errorf("ERROR: RUBRIC NOT FOUND")
+					}
+
+					criteria := map[uint]bool{}
+					for _, criterion := range rubric.Criteria {
+						criteria[criterion.ID] = true
+					}
+
+					for _, scoreRequest := range request.Scores {
+						if !criteria[scoreRequest.CriterionID] {
+							return fmt.Errorf("ERROR: CRITERION NOT IN RUBRIC")
+						}
+
+						condition := models.Score{AssignmentID: assignment.ID, CriterionID: scoreRequest.CriterionID}
+						newScore := models.Score{CriterionID: scoreRequest.CriterionID, Value: scoreRequest.Value, Comment: scoreRequest.Comment}
+						score := models.Score{}
+						if err := tx.Where(&condition).Assign(&newScore).FirstOrCreate(&score).Error; err != nil {
+							return err
+						}
+						scores = append(scores, score)
+					}
+					return nil
+				}); err != nil {
+					response.Message = err.Error()
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = scores
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+func GetRankingHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[[]models.Ranking]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				query := repository.GetRankingQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				rankings, err := models.GetRankings(db, query.TeamCategory, query.Stage)
+				if err != nil {
+					response.Message = err.Error()
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = rankings
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+// NOTE: Sebanyak PassedCount team teratas pada peringkat dinyatakan lolos, sisanya dinyatakan tereliminasi
+func DecideStageHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[[]models.Ranking]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				request := repository.DecideStageRequest{}
+				if err := c.ShouldBindJSON(&request); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				adminID := value.(uint)
+				rankings := []models.Ranking{}
+				stageTransitions := []models.StageTransition{}
+				emails := map[uint][]string{}
+				if err := db.Transaction(func(tx *gorm.DB) error {
+					var err error
+					rankings, err = models.GetRankings(tx, request.TeamCategory, request.Stage)
+					if err != nil {
+						return err
+					}
+
+					for _, ranking := range rankings {
+						// Enrolment yang telah berpindah dari stage ini tidak diputuskan ulang
+						conditionEnrolment := models.Enrolment{Model: gorm.Model{ID: ranking.EnrolmentID}}
+						enrolment := models.Enrolment{}
+						if err := tx.Where(&conditionEnrolment).First(&enrolment).Error; err != nil {
+							return err
+						}
+						if enrolment.GetCurrentStage() != request.Stage {
+							continue
+						}
+
+						status := types.Eliminated
+						if ranking.Rank <= request.PassedCount {
+							status = types.Passed
+						}
+
+						stageTransition, err := models.TransitionEnrolment(tx, ranking.EnrolmentID, status, adminID)
+						if err != nil {
+							return err
+						}
+						stageTransitions = append(stageTransitions, stageTransition)
+
+						emails[ranking.TeamID], err = models.GetMemberEmails(tx, ranking.TeamID)
+						if err != nil {
+							return err
+						}
+					}
+					return nil
+				}); err != nil {
+					response.Message = err.Error()
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				// Asynchronously mail the stage result to every member of each team
+				for _, stageTransition := range stageTransitions {
+					if subject, template, ok := stageTransition.GetMailTemplate(); ok {
+						for _, email := range emails[stageTransition.TeamID] {
+							mail.Broker.AddMailToBroker(mail.MailParameters{Email: email, Subject: subject, Template: template, Data: stageTransition.GetMailData()})
+						}
+					}
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = rankings
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,365 @@
+package controllers
+
+import (
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"gorm.io/gorm"
+
+	"arkavidia-backend-8.0/competition/middlewares"
+	"arkavidia-backend-8.0/competition/models"
+	"arkavidia-backend-8.0/competition/repository"
+	databaseService "arkavidia-backend-8.0/competition/services/database"
+	"arkavidia-backend-8.0/competition/utils/scoreboard"
+)
+
+// NOTE: Daftar contest dan scoreboard dapat diakses secara publik tanpa autentikasi
+func GetContestsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[[]models.Contest]{}
+
+		contests := []models.Contest{}
+		if err := db.Order("start_time").Find(&contests).Error; err != nil {
+			response.Message = "ERROR: BAD REQUEST"
+			c.AbortWithStatusJSON(http.StatusBadRequest, response)
+			return
+		}
+
+		response.Message = "SUCCESS"
+		response.Data = contests
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+func GetScoreboardHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[[]models.ScoreboardRow]{}
+
+		query := repository.GetScoreboardQuery{}
+		if err := c.ShouldBindQuery(&query); err != nil {
+			response.Message = "ERROR: BAD REQUEST"
+			c.AbortWithStatusJSON(http.StatusBadRequest, response)
+			return
+		}
+
+		condition := models.Contest{Model: gorm.Model{ID: query.ContestID}}
+		contest := models.Contest{}
+		if err := db.Where(&condition).First(&contest).Error; err != nil {
+			response.Message = "ERROR: CONTEST NOT FOUND"
+			c.AbortWithStatusJSON(http.StatusNotFound, response)
+			return
+		}
+
+		rows, err := models.GetScoreboard(db, contest, false)
+		if err != nil {
+			response.Message = "ERROR: BAD REQUEST"
+			c.AbortWithStatusJSON(http.StatusBadRequest, response)
+			return
+		}
+
+		response.Message = "SUCCESS"
+		response.Data = rows
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// Admin dapat melihat scoreboard lengkap meskipun scoreboard publik sedang dibekukan
+func GetUnfrozenScoreboardHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[[]models.ScoreboardRow]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				query := repository.GetScoreboardQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				condition := models.Contest{Model: gorm.Model{ID: query.ContestID}}
+				contest := models.Contest{}
+				if err := db.Where(&condition).First(&contest).Error; err != nil {
+					response.Message = "ERROR: CONTEST NOT FOUND"
+					c.AbortWithStatusJSON(http.StatusNotFound, response)
+					return
+				}
+
+				rows, err := models.GetScoreboard(db, contest, true)
+				if err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = rows
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+// Event feed diambil dari file yang diunggah, atau dari endpoint lokal yang dikonfigurasi apabila tidak ada file
+func ImportEventFeedHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[models.Contest]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				request := repository.ImportEventFeedRequest{}
+				if err := c.ShouldBindWith(&request, binding.FormMultipart); err != nil && err != http.ErrNotMultipart {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				var content []byte
+				if request.File != nil {
+					openedFile, err := request.File.Open()
+					if err != nil {
+						response.Message = "ERROR: FILE CANNOT BE ACCESSED"
+						c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+						return
+					}
+					defer openedFile.Close()
+
+					content, err = ioutil.ReadAll(openedFile)
+					if err != nil {
+						response.Message = "ERROR: FILE CANNOT BE ACCESSED"
+						c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+						return
+					}
+				} else {
+					fetchedContent, err := scoreboard.FetchEventFeed()
+					if err != nil {
+						response.Message = err.Error()
+						c.AbortWithStatusJSON(http.StatusBadGateway, response)
+						return
+					}
+					content = fetchedContent
+				}
+
+				events, err := scoreboard.ParseEventFeed(content)
+				if err != nil {
+					response.Message = err.Error()
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				contest := models.Contest{}
+				if err := db.Transaction(func(tx *gorm.DB) error {
+					importedContest, err := scoreboard.Import(tx, events)
+					if err != nil {
+						return err
+					}
+					contest = importedContest
+					return nil
+				}); err != nil {
+					response.Message = err.Error()
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = contest
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+func FreezeScoreboardHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[models.Contest]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				query := repository.FreezeScoreboardQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				condition := models.Contest{Model: gorm.Model{ID: query.ContestID}}
+				contest := models.Contest{}
+				if err := db.Where(&condition).First(&contest).Error; err != nil {
+					response.Message = "ERROR: CONTEST NOT FOUND"
+					c.AbortWithStatusJSON(http.StatusNotFound, response)
+					return
+				}
+
+				if err := db.Model(&contest).Update("is_frozen", *query.IsFrozen).Error; err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = contest
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+func GetContestTeamsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[[]models.ContestTeam]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				query := repository.GetContestTeamsQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				condition := models.ContestTeam{ContestID: query.ContestID}
+				contestTeams := []models.ContestTeam{}
+				if err := db.Where(&condition).Order("external_id").Find(&contestTeams).Error; err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = contestTeams
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+// Pemetaan manual digunakan untuk team pada event feed yang tidak dapat dipetakan secara otomatis
+func MapContestTeamHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[models.ContestTeam]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				query := repository.MapContestTeamQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				condition := models.ContestTeam{Model: gorm.Model{ID: query.ContestTeamID}}
+				contestTeam := models.ContestTeam{}
+				if err := db.Where(&condition).First(&contestTeam).Error; err != nil {
+					response.Message = "ERROR: CONTEST TEAM NOT FOUND"
+					c.AbortWithStatusJSON(http.StatusNotFound, response)
+					return
+				}
+
+				contestTeam.TeamID = &query.TeamID
+				if err := db.Save(&contestTeam).Error; err != nil {
+					response.Message = err.Error()
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = contestTeam
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
@@ -4,14 +4,17 @@ import (
 	"bytes"
 	"fmt"
 	"io/ioutil"
+	"log"
 	"net/http"
 	"path/filepath"
+	"strings"
 
 	"github.com/gabriel-vasile/mimetype"
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	storageConfig "arkavidia-backend-8.0/competition/config/storage"
 	"arkavidia-backend-8.0/competition/middlewares"
@@ -19,8 +22,23 @@ import (
 	"arkavidia-backend-8.0/competition/repository"
 	databaseService "arkavidia-backend-8.0/competition/services/database"
 	storageService "arkavidia-backend-8.0/competition/services/storage"
+	"arkavidia-backend-8.0/competition/types"
+	"arkavidia-backend-8.0/competition/utils/mail"
+	"arkavidia-backend-8.0/competition/utils/preview"
+	"arkavidia-backend-8.0/competition/utils/receipt"
+	"arkavidia-backend-8.0/competition/utils/scoring"
 )
 
+// Hanya pesan constraint dari model (berawalan "ERROR: ") yang dikembalikan kepada team,
+// pesan error database maupun library lain disembunyikan
+func getSubmissionErrorMessage(err error) string {
+	if strings.HasPrefix(err.Error(), "ERROR: ") {
+		return err.Error()
+	}
+
+	return "ERROR: BAD REQUEST"
+}
+
 func GetSubmissionHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		db := databaseService.DB.GetConnection()
@@ -48,7 +66,11 @@ func GetSubmissionHandler() gin.HandlerFunc {
 
 				condition := models.Submission{TeamID: query.TeamID}
 				submissions := []models.Submission{}
-				if err := db.Where(&condition).Find(&submissions).Error; err != nil {
+				tx := db.Where(&condition)
+				if query.TeamCategory != "" {
+					tx = tx.Joins("JOIN enrolments ON enrolments.id = submissions.enrolment_id").Where("enrolments.team_category = ?", query.TeamCategory)
+				}
+				if err := tx.Order("stage, version DESC").Find(&submissions).Error; err != nil {
 					response.Message = "ERROR: BAD REQUEST"
 					c.AbortWithStatusJSON(http.StatusBadRequest, response)
 					return
@@ -69,10 +91,21 @@ func GetSubmissionHandler() gin.HandlerFunc {
 					return
 				}
 
+				query := repository.GetTeamSubmissionQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
 				teamID := value.(uint)
 				condition := models.Submission{TeamID: teamID}
 				submissions := []models.Submission{}
-				if err := db.Where(&condition).Find(&submissions).Error; err != nil {
+				tx := db.Where(&condition)
+				if query.TeamCategory != "" {
+					tx = tx.Joins("JOIN enrolments ON enrolments.id = submissions.enrolment_id").Where("enrolments.team_category = ?", query.TeamCategory)
+				}
+				if err := tx.Order("stage, version DESC").Find(&submissions).Error; err != nil {
 					response.Message = "ERROR: BAD REQUEST"
 					c.AbortWithStatusJSON(http.StatusBadRequest, response)
 					return
@@ -178,27 +211,19 @@ func DownloadSubmissionHandler() gin.HandlerFunc {
 					return
 				}
 
-				filename := fmt.Sprintf("%s.%s", submission.FileName, submission.FileExtension)
-				IOWriter, err := storageService.Client.DownloadFile(filename, config.SubmissionDir)
+				filename := fmt.Sprintf("%s%s", submission.FileName, submission.FileExtension)
+				content, err := storageService.Client.DownloadFile(filename, config.SubmissionDir)
 				if err != nil {
 					response.Message = "ERROR: CONTENT NOT FOUND IN STORAGE"
 					c.AbortWithStatusJSON(http.StatusBadRequest, response)
 					return
 				}
 
-				var content []byte
-				length, err := IOWriter.Write(content)
-				if err != nil {
-					response.Message = "ERROR: CONTENT CANNOT BE WRITTEN"
-					c.AbortWithStatusJSON(http.StatusInternalServerError, response)
-					return
-				}
-
 				c.Header("Content-Description", "File Transfer")
 				c.Header("Content-Transfer-Encoding", "binary")
 				c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
 				c.Header("Content-Type", "application/octet-stream")
-				c.Header("Accept-Length", fmt.Sprintf("%d", length))
+				c.Header("Accept-Length", fmt.Sprintf("%d", len(content)))
 				c.Writer.Write(content)
 
 				response.Message = "SUCCESS"
@@ -230,19 +255,56 @@ func DownloadSubmissionHandler() gin.HandlerFunc {
 					return
 				}
 
-				filename := fmt.Sprintf("%s.%s", submission.FileName, submission.FileExtension)
-				IOWriter, err := storageService.Client.DownloadFile(filename, config.SubmissionDir)
+				filename := fmt.Sprintf("%s%s", submission.FileName, submission.FileExtension)
+				content, err := storageService.Client.DownloadFile(filename, config.SubmissionDir)
 				if err != nil {
 					response.Message = "ERROR: CONTENT NOT FOUND IN STORAGE"
 					c.AbortWithStatusJSON(http.StatusBadRequest, response)
 					return
 				}
 
-				var content []byte
-				length, err := IOWriter.Write(content)
+				c.Header("Content-Description", "File Transfer")
+				c.Header("Content-Transfer-Encoding", "binary")
+				c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+				c.Header("Content-Type", "application/octet-stream")
+				c.Header("Accept-Length", fmt.Sprintf("%d", len(content)))
+				c.Writer.Write(content)
+
+				response.Message = "SUCCESS"
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		case middlewares.Judge:
+			{
+				query := repository.DownloadSubmissionQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				judgeID := value.(uint)
+				conditionAssignment := models.Assignment{JudgeID: judgeID, SubmissionID: query.SubmissionID}
+				assignment := models.Assignment{}
+				if err := db.Preload("Submission").Where(&conditionAssignment).First(&assignment).Error; err != nil {
+					response.Message = "ERROR: CONTENT NOT FOUND IN DB"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				submission := assignment.Submission
+				filename := fmt.Sprintf("%s%s", submission.FileName, submission.FileExtension)
+				content, err := storageService.Client.DownloadFile(filename, config.SubmissionDir)
 				if err != nil {
-					response.Message = "ERROR: CONTENT CANNOT BE WRITTEN"
-					c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+					response.Message = "ERROR: CONTENT NOT FOUND IN STORAGE"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
 					return
 				}
 
@@ -250,7 +312,7 @@ func DownloadSubmissionHandler() gin.HandlerFunc {
 				c.Header("Content-Transfer-Encoding", "binary")
 				c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
 				c.Header("Content-Type", "application/octet-stream")
-				c.Header("Accept-Length", fmt.Sprintf("%d", length))
+				c.Header("Accept-Length", fmt.Sprintf("%d", len(content)))
 				c.Writer.Write(content)
 
 				response.Message = "SUCCESS"
@@ -399,6 +461,232 @@ func RenderSubmissionHandler() gin.HandlerFunc {
 	}
 }
 
+func PreviewSubmissionHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		config := storageConfig.Config.GetMetadata()
+		response := repository.Response[models.Preview]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				query := repository.DownloadSubmissionQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				condition := models.Submission{Model: gorm.Model{ID: query.SubmissionID}}
+				submission := models.Submission{}
+				if err := db.Where(&condition).Find(&submission).Error; err != nil {
+					response.Message = "ERROR: CONTENT NOT FOUND IN DB"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				conditionPreview := models.Preview{SubmissionID: submission.ID}
+				submissionPreview := models.Preview{}
+				if err := db.Where(&conditionPreview).Find(&submissionPreview).Error; err != nil || submissionPreview.ID == 0 {
+					response.Message = "ERROR: PREVIEW NOT FOUND IN DB"
+					c.AbortWithStatusJSON(http.StatusNotFound, response)
+					return
+				}
+
+				switch submissionPreview.Status {
+				case types.PreviewPending:
+					{
+						response.Message = "PREVIEW IS BEING GENERATED"
+						response.Data = submissionPreview
+						c.JSON(http.StatusAccepted, response)
+						return
+					}
+				case types.PreviewUnsupported:
+					{
+						response.Message = "ERROR: PREVIEW NOT SUPPORTED"
+						response.Data = submissionPreview
+						c.AbortWithStatusJSON(http.StatusUnsupportedMediaType, response)
+						return
+					}
+				case types.PreviewFailed:
+					{
+						response.Message = "ERROR: PREVIEW CANNOT BE GENERATED"
+						response.Data = submissionPreview
+						c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+						return
+					}
+				}
+
+				filename := fmt.Sprintf("%s%s", submissionPreview.FileName, submissionPreview.FileExtension)
+				content, err := storageService.Client.DownloadFile(filename, config.PreviewDir)
+				if err != nil {
+					response.Message = "ERROR: CONTENT NOT FOUND IN STORAGE"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				c.Header("Content-Disposition", "inline")
+				c.Data(http.StatusOK, submissionPreview.ContentType, content)
+				return
+			}
+		case middlewares.Team:
+			{
+				query := repository.DownloadSubmissionQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				teamID := value.(uint)
+				condition := models.Submission{Model: gorm.Model{ID: query.SubmissionID}, TeamID: teamID}
+				submission := models.Submission{}
+				if err := db.Where(&condition).Find(&submission).Error; err != nil {
+					response.Message = "ERROR: CONTENT NOT FOUND IN DB"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				conditionPreview := models.Preview{SubmissionID: submission.ID}
+				submissionPreview := models.Preview{}
+				if err := db.Where(&conditionPreview).Find(&submissionPreview).Error; err != nil || submissionPreview.ID == 0 {
+					response.Message = "ERROR: PREVIEW NOT FOUND IN DB"
+					c.AbortWithStatusJSON(http.StatusNotFound, response)
+					return
+				}
+
+				switch submissionPreview.Status {
+				case types.PreviewPending:
+					{
+						response.Message = "PREVIEW IS BEING GENERATED"
+						response.Data = submissionPreview
+						c.JSON(http.StatusAccepted, response)
+						return
+					}
+				case types.PreviewUnsupported:
+					{
+						response.Message = "ERROR: PREVIEW NOT SUPPORTED"
+						response.Data = submissionPreview
+						c.AbortWithStatusJSON(http.StatusUnsupportedMediaType, response)
+						return
+					}
+				case types.PreviewFailed:
+					{
+						response.Message = "ERROR: PREVIEW CANNOT BE GENERATED"
+						response.Data = submissionPreview
+						c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+						return
+					}
+				}
+
+				filename := fmt.Sprintf("%s%s", submissionPreview.FileName, submissionPreview.FileExtension)
+				content, err := storageService.Client.DownloadFile(filename, config.PreviewDir)
+				if err != nil {
+					response.Message = "ERROR: CONTENT NOT FOUND IN STORAGE"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				c.Header("Content-Disposition", "inline")
+				c.Data(http.StatusOK, submissionPreview.ContentType, content)
+				return
+			}
+		case middlewares.Judge:
+			{
+				query := repository.DownloadSubmissionQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				// Judge hanya dapat melihat preview submission yang ditugaskan kepadanya
+				judgeID := value.(uint)
+				conditionAssignment := models.Assignment{JudgeID: judgeID, SubmissionID: query.SubmissionID}
+				assignment := models.Assignment{}
+				if err := db.Where(&conditionAssignment).First(&assignment).Error; err != nil {
+					response.Message = "ERROR: CONTENT NOT FOUND IN DB"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				conditionPreview := models.Preview{SubmissionID: assignment.SubmissionID}
+				submissionPreview := models.Preview{}
+				if err := db.Where(&conditionPreview).Find(&submissionPreview).Error; err != nil || submissionPreview.ID == 0 {
+					response.Message = "ERROR: PREVIEW NOT FOUND IN DB"
+					c.AbortWithStatusJSON(http.StatusNotFound, response)
+					return
+				}
+
+				switch submissionPreview.Status {
+				case types.PreviewPending:
+					{
+						response.Message = "PREVIEW IS BEING GENERATED"
+						response.Data = submissionPreview
+						c.JSON(http.StatusAccepted, response)
+						return
+					}
+				case types.PreviewUnsupported:
+					{
+						response.Message = "ERROR: PREVIEW NOT SUPPORTED"
+						response.Data = submissionPreview
+						c.AbortWithStatusJSON(http.StatusUnsupportedMediaType, response)
+						return
+					}
+				case types.PreviewFailed:
+					{
+						response.Message = "ERROR: PREVIEW CANNOT BE GENERATED"
+						response.Data = submissionPreview
+						c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+						return
+					}
+				}
+
+				filename := fmt.Sprintf("%s%s", submissionPreview.FileName, submissionPreview.FileExtension)
+				content, err := storageService.Client.DownloadFile(filename, config.PreviewDir)
+				if err != nil {
+					response.Message = "ERROR: CONTENT NOT FOUND IN STORAGE"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				c.Header("Content-Disposition", "inline")
+				c.Data(http.StatusOK, submissionPreview.ContentType, content)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
 func AddSubmissionHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		db := databaseService.DB.GetConnection()
@@ -442,20 +730,87 @@ func AddSubmissionHandler() gin.HandlerFunc {
 					return
 				}
 
-				teamID := value.(uint)
-				submission := models.Submission{FileName: fileUUID, FileExtension: fileExt, TeamID: teamID, Stage: request.Stage}
-				if err := db.Create(&submission).Error; err != nil {
-					response.Message = "ERROR: BAD REQUEST"
-					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+				content, err := ioutil.ReadAll(openedFile)
+				if err != nil {
+					response.Message = "ERROR: FILE CANNOT BE ACCESSED"
+					c.AbortWithStatusJSON(http.StatusInternalServerError, response)
 					return
 				}
 
-				if err := storageService.Client.UploadFile(fmt.Sprintf("%s%s", fileUUID, fileExt), config.SubmissionDir, openedFile); err != nil {
+				// File diunggah sebelum transaksi dibuka agar row lock tidak ditahan selama proses unggah
+				if err := storageService.Client.UploadFile(fmt.Sprintf("%s%s", fileUUID, fileExt), config.SubmissionDir, bytes.NewReader(content)); err != nil {
 					response.Message = "ERROR: GOOGLE CLOUD STORAGE CANNOT BE ACCESSED"
 					c.AbortWithStatusJSON(http.StatusInternalServerError, response)
 					return
 				}
 
+				teamID := value.(uint)
+				submission := models.Submission{FileName: fileUUID, FileExtension: fileExt, TeamID: teamID, Stage: request.Stage}
+				emails := []string{}
+				if err := db.Transaction(func(tx *gorm.DB) error {
+					enrolment, err := models.FindEnrolment(tx, teamID, request.TeamCategory)
+					if err != nil {
+						return err
+					}
+					submission.EnrolmentID = enrolment.ID
+
+					// Submission Datavidia dinilai otomatis apabila ground truth untuk stage tersebut tersedia
+					groundTruth, exists, err := models.FindGroundTruth(tx, enrolment.TeamCategory, request.Stage)
+					if err != nil {
+						return err
+					}
+					if exists {
+						if err := groundTruth.CheckDailyLimit(tx, enrolment.ID); err != nil {
+							return err
+						}
+					}
+
+					if err := tx.Create(&submission).Error; err != nil {
+						return err
+					}
+
+					if exists {
+						datavidiaScore, err := scoring.Grade(groundTruth, content)
+						if err != nil {
+							return err
+						}
+						datavidiaScore.SubmissionID = submission.ID
+						if err := tx.Create(&datavidiaScore).Error; err != nil {
+							return err
+						}
+						submission.Score = &datavidiaScore
+					}
+
+					submissionReceipt, err := receipt.Issue(tx, submission, content)
+					if err != nil {
+						return err
+					}
+					submission.Receipt = &submissionReceipt
+
+					// Preview dibuat secara asynchronous setelah file berhasil diunggah
+					submissionPreview := models.Preview{FileName: uuid.New(), SubmissionID: submission.ID, Status: types.PreviewPending}
+					if err := tx.Create(&submissionPreview).Error; err != nil {
+						return err
+					}
+
+					emails, err = models.GetMemberEmails(tx, teamID)
+					return err
+				}); err != nil {
+					// File yang telah terunggah dihapus apabila transaksi gagal
+					if err := storageService.Client.DeleteFile(fmt.Sprintf("%s%s", fileUUID, fileExt), config.SubmissionDir); err != nil {
+						log.Printf("WARNING: SUBMISSION %s%s CANNOT BE DELETED FROM STORAGE: %s", fileUUID, fileExt, err.Error())
+					}
+					response.Message = getSubmissionErrorMessage(err)
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+				preview.Broker.AddPreviewToBroker(preview.PreviewParameters{SubmissionID: submission.ID})
+
+				// Tanda terima yang telah ditandatangani dikirim secara asynchronous kepada seluruh anggota team
+				for _, email := range emails {
+					mail.Broker.AddMailToBroker(mail.MailParameters{Email: email, Subject: "Submission Receipt", Template: "submission-receipt", Data: receipt.GetMailData(*submission.Receipt)})
+				}
+
 				response.Message = "SUCCESS"
 				response.Data = submission
 				response.URL = fmt.Sprintf("%s/%s/%s/", config.StorageHost, config.BucketName, config.SubmissionDir)
@@ -472,6 +827,77 @@ func AddSubmissionHandler() gin.HandlerFunc {
 	}
 }
 
+func SetFinalSubmissionHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[models.Submission]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Team:
+			{
+				query := repository.SetFinalSubmissionQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				teamID := value.(uint)
+				submission := models.Submission{}
+				if err := db.Transaction(func(tx *gorm.DB) error {
+					condition := models.Submission{Model: gorm.Model{ID: query.SubmissionID}, TeamID: teamID}
+					if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(&condition).First(&submission).Error; err != nil {
+						return err
+					}
+
+					if err := submission.CheckMutable(tx); err != nil {
+						return err
+					}
+
+					conditionStage := models.Submission{EnrolmentID: submission.EnrolmentID, Stage: submission.Stage}
+					if err := tx.Model(&models.Submission{}).Where(&conditionStage).Update("is_final", false).Error; err != nil {
+						return err
+					}
+
+					submission.IsFinal = true
+					return tx.Model(&submission).Update("is_final", true).Error
+				}); err != nil {
+					response.Message = err.Error()
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = submission
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
 func DeleteSubmissionHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		db := databaseService.DB.GetConnection()
@@ -513,12 +939,18 @@ func DeleteSubmissionHandler() gin.HandlerFunc {
 				teamID := value.(uint)
 				condition := models.Submission{FileName: fileUUID, TeamID: teamID}
 				submission := models.Submission{}
-				if err := db.Where(&condition).Delete(&submission).Error; err != nil {
+				if err := db.Where(&condition).First(&submission).Error; err != nil {
 					response.Message = "ERROR: BAD REQUEST"
 					c.AbortWithStatusJSON(http.StatusBadRequest, response)
 					return
 				}
 
+				if err := db.Delete(&submission).Error; err != nil {
+					response.Message = err.Error()
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
 				response.Message = "SUCCESS"
 				c.JSON(http.StatusOK, response)
 				return
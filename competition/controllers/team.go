@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"fmt"
 	"net/http"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 	"gorm.io/gorm"
 
 	authConfig "arkavidia-backend-8.0/competition/config/authentication"
+	invitationConfig "arkavidia-backend-8.0/competition/config/invitation"
 	"arkavidia-backend-8.0/competition/middlewares"
 	"arkavidia-backend-8.0/competition/models"
 	"arkavidia-backend-8.0/competition/repository"
@@ -110,26 +112,45 @@ func SignUpTeamHandler() gin.HandlerFunc {
 		}
 
 		encryptedString := []byte(request.Password)
-		team = models.Team{Username: request.Username, HashedPassword: encryptedString, TeamName: request.TeamName, Status: types.WaitingForEvaluation}
+		team = models.Team{Username: request.Username, HashedPassword: encryptedString, TeamName: request.TeamName, Institution: request.Institution, EducationLevel: request.EducationLevel}
+		invitationMetadata := invitationConfig.Config.GetMetadata()
+		invitations := []models.Invitation{}
+		names := map[uint]string{}
 		if err := db.Transaction(func(tx *gorm.DB) error {
 			if err := tx.Create(&team).Error; err != nil {
 				return err
 			}
 
 			for _, member := range request.Members {
-				conditionParticipant := models.Participant{Name: member.Name, Email: member.Email, CareerInterest: member.CareerInterests, Status: types.WaitingForVerification}
+				// Participant dicocokkan berdasarkan email yang telah dinormalisasi saja
+				email := models.NormalizeEmail(member.Email)
+				newParticipant := models.Participant{Name: member.Name, Email: email, CareerInterest: member.CareerInterests, Status: types.WaitingForVerification, Institution: member.Institution, EducationLevel: member.EducationLevel, StudentIDNumber: member.StudentIDNumber, GraduationYear: member.GraduationYear, Phone: member.Phone}
 				participant := models.Participant{}
-				if err := tx.FirstOrCreate(&participant, &conditionParticipant).Error; err != nil {
+				if err := tx.Where("LOWER(TRIM(email)) = ?", email).Attrs(&newParticipant).FirstOrCreate(&participant).Error; err != nil {
 					return err
 				}
 
-				membership := models.Membership{TeamID: team.ID, ParticipantID: participant.ID, Role: member.Role}
-				if err := tx.Create(&membership).Error; err != nil {
+				if member.Role == types.Leader {
+					membership := models.Membership{TeamID: team.ID, ParticipantID: participant.ID, Role: member.Role, Status: types.ActiveMembership}
+					if err := tx.Create(&membership).Error; err != nil {
+						return err
+					}
+					continue
+				}
+
+				// Anggota selain leader harus menyetujui undangan sebelum tergabung ke dalam team
+				invitation, err := models.CreateInvitation(tx, team.ID, participant, member.Role, time.Now().Add(invitationMetadata.ExpirationDuration))
+				if err != nil {
 					return err
 				}
+				invitations = append(invitations, invitation)
+				names[invitation.ID] = participant.Name
 			}
-			return nil
+			return models.ValidateComposition(tx, team.ID)
 		}); err != nil {
+			if abortWithCompositionError(c, err) {
+				return
+			}
 			response.Message = "BAD REQUEST"
 			c.AbortWithStatusJSON(http.StatusBadRequest, response)
 			return
@@ -152,9 +173,14 @@ func SignUpTeamHandler() gin.HandlerFunc {
 			return
 		}
 
-		// Asynchronously mail to every member registered on the team
+		// Asynchronously mail the leader and invite the other listed members
 		for _, member := range request.Members {
-			mail.Broker.AddMailToBroker(mail.MailParameters{Email: member.Email})
+			if member.Role == types.Leader {
+				mail.Broker.AddMailToBroker(mail.MailParameters{Email: member.Email, Subject: "Registration", Template: "registration", Data: map[string]interface{}{"Name": member.Name, "TeamName": team.TeamName}})
+			}
+		}
+		for _, invitation := range invitations {
+			mailInvitation(invitation, names[invitation.ID])
 		}
 
 		response.Message = "SUCCESS"
@@ -189,7 +215,7 @@ func GetTeamHandler() gin.HandlerFunc {
 
 				condition := models.Team{Model: gorm.Model{ID: query.TeamID}}
 				team := models.Team{}
-				if err := db.Preload("Memberships").Where(&condition).Find(&team).Error; err != nil {
+				if err := db.Preload("Enrolments").Preload("Memberships").Where(&condition).Find(&team).Error; err != nil {
 					response.Message = "ERROR: BAD REQUEST"
 					c.AbortWithStatusJSON(http.StatusBadRequest, response)
 					return
@@ -212,7 +238,7 @@ func GetTeamHandler() gin.HandlerFunc {
 				teamID := value.(uint)
 				condition := models.Team{Model: gorm.Model{ID: teamID}}
 				team := models.Team{}
-				if err := db.Preload("Memberships").Where(&condition).Find(&team).Error; err != nil {
+				if err := db.Preload("Enrolments").Preload("Memberships").Where(&condition).Find(&team).Error; err != nil {
 					response.Message = "ERROR: BAD REQUEST"
 					c.AbortWithStatusJSON(http.StatusBadRequest, response)
 					return
@@ -259,9 +285,8 @@ func GetAllTeamsHandler() gin.HandlerFunc {
 
 				offset := (query.Page - 1) * query.Size
 				limit := query.Size
-				condition := models.Team{TeamCategory: query.TeamCategory}
 				teams := []models.Team{}
-				if err := db.Where(&condition).Offset(offset).Limit(limit).Find(&teams).Error; err != nil {
+				if err := db.Preload("Enrolments").Joins("JOIN enrolments ON enrolments.team_id = teams.id AND enrolments.deleted_at IS NULL").Where("enrolments.team_category = ?", query.TeamCategory).Offset(offset).Limit(limit).Find(&teams).Error; err != nil {
 					response.Message = "ERROR: BAD REQUEST"
 					c.AbortWithStatusJSON(http.StatusBadRequest, response)
 					return
@@ -336,7 +361,7 @@ func ChangePasswordHandler() gin.HandlerFunc {
 	}
 }
 
-func CompetitionRegistration() gin.HandlerFunc {
+func ChangeTeamProfileHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		db := databaseService.DB.GetConnection()
 		response := repository.Response[models.Team]{}
@@ -353,8 +378,8 @@ func CompetitionRegistration() gin.HandlerFunc {
 		switch role {
 		case middlewares.Team:
 			{
-				query := repository.CompetitionRegistrationQuery{}
-				if err := c.ShouldBindQuery(&query); err != nil {
+				request := repository.ChangeTeamProfileRequest{}
+				if err := c.ShouldBindJSON(&request); err != nil {
 					response.Message = "ERROR: BAD REQUEST"
 					c.AbortWithStatusJSON(http.StatusBadRequest, response)
 					return
@@ -369,7 +394,7 @@ func CompetitionRegistration() gin.HandlerFunc {
 
 				teamID := value.(uint)
 				oldTeam := models.Team{Model: gorm.Model{ID: teamID}}
-				newTeam := models.Team{TeamCategory: query.TeamCategory}
+				newTeam := models.Team{Institution: request.Institution, EducationLevel: request.EducationLevel}
 				if err := db.Where(&oldTeam).Updates(&newTeam).Error; err != nil {
 					response.Message = "ERROR: BAD REQUEST"
 					c.AbortWithStatusJSON(http.StatusBadRequest, response)
@@ -390,6 +415,108 @@ func CompetitionRegistration() gin.HandlerFunc {
 	}
 }
 
+func CompetitionRegistration() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[models.Enrolment]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Team:
+			{
+				query := repository.CompetitionRegistrationQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				teamID := value.(uint)
+				enrolment := models.Enrolment{TeamID: teamID, TeamCategory: query.TeamCategory, Stage: query.TeamCategory.GetStagePipeline()[0], Status: types.WaitingForEvaluation}
+				waitlistEntry := models.WaitlistEntry{}
+				if err := db.Transaction(func(tx *gorm.DB) error {
+					condition := models.Team{Model: gorm.Model{ID: teamID}}
+					team := models.Team{}
+					if err := tx.Where(&condition).First(&team).Error; err != nil {
+						return err
+					}
+					if team.IsDisbanded {
+						return fmt.Errorf("ERROR: TEAM DISBANDED")
+					}
+
+					// Team ditempatkan pada waitlist apabila kuota pendaftaran jenis lomba telah penuh
+					categoryQuota, err := models.LockCategoryQuota(tx, query.TeamCategory)
+					if err != nil {
+						return err
+					}
+					available, limited, err := categoryQuota.GetAvailableSlots(tx)
+					if err != nil {
+						return err
+					}
+					if limited && available == 0 {
+						if err := models.CheckEligibility(tx, teamID, query.TeamCategory); err != nil {
+							return err
+						}
+
+						waitlistEntry = models.WaitlistEntry{TeamID: teamID, TeamCategory: query.TeamCategory}
+						return tx.Create(&waitlistEntry).Error
+					}
+
+					// Satu akun team dapat mendaftar beberapa jenis lomba dengan enrolment yang terpisah
+					if err := tx.Create(&enrolment).Error; err != nil {
+						return err
+					}
+
+					if err := models.ValidateComposition(tx, teamID); err != nil {
+						return err
+					}
+
+					return models.CheckEligibility(tx, teamID, query.TeamCategory)
+				}); err != nil {
+					if abortWithCompositionError(c, err) || abortWithEligibilityError(c, err) {
+						return
+					}
+					response.Message = err.Error()
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				if waitlistEntry.ID != 0 {
+					waitlistResponse := repository.Response[models.WaitlistEntry]{Message: "SUCCESS", Data: waitlistEntry}
+					c.JSON(http.StatusAccepted, waitlistResponse)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = enrolment
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
 func ChangeStatusTeamHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		db := databaseService.DB.GetConnection()
@@ -429,14 +556,30 @@ func ChangeStatusTeamHandler() gin.HandlerFunc {
 				}
 
 				adminID := value.(uint)
-				oldTeam := models.Team{Model: gorm.Model{ID: query.TeamID}}
-				newTeam := models.Team{Status: request.Status, AdminID: adminID}
-				if err := db.Where(&oldTeam).Updates(&newTeam).Error; err != nil {
-					response.Message = "ERROR: BAD REQUEST"
+				stageTransition := models.StageTransition{}
+				emails := []string{}
+				if err := db.Transaction(func(tx *gorm.DB) error {
+					var err error
+					stageTransition, err = models.TransitionEnrolment(tx, query.EnrolmentID, request.Status, adminID)
+					if err != nil {
+						return err
+					}
+
+					emails, err = models.GetMemberEmails(tx, stageTransition.TeamID)
+					return err
+				}); err != nil {
+					response.Message = err.Error()
 					c.AbortWithStatusJSON(http.StatusBadRequest, response)
 					return
 				}
 
+				// Asynchronously mail the stage result to every member of the team
+				if subject, template, ok := stageTransition.GetMailTemplate(); ok {
+					for _, email := range emails {
+						mail.Broker.AddMailToBroker(mail.MailParameters{Email: email, Subject: subject, Template: template, Data: stageTransition.GetMailData()})
+					}
+				}
+
 				response.Message = "SUCCESS"
 				c.JSON(http.StatusOK, response)
 				return
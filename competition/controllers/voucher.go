@@ -0,0 +1,194 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"arkavidia-backend-8.0/competition/middlewares"
+	"arkavidia-backend-8.0/competition/models"
+	"arkavidia-backend-8.0/competition/repository"
+	databaseService "arkavidia-backend-8.0/competition/services/database"
+)
+
+func GetVouchersHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[[]models.Voucher]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				query := repository.GetVouchersQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				// Voucher tanpa daftar jenis lomba berlaku untuk seluruh jenis lomba
+				statement := db.Order("code")
+				if query.TeamCategory != "" {
+					statement = statement.Where("team_categories IS NULL OR ? = ANY(team_categories)", query.TeamCategory)
+				}
+
+				vouchers := []models.Voucher{}
+				if err := statement.Find(&vouchers).Error; err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = vouchers
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+// NOTE: Voucher diidentifikasi berdasarkan kode sehingga kode yang telah ada akan diperbarui
+func SetVoucherHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[models.Voucher]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				request := repository.SetVoucherRequest{}
+				if err := c.ShouldBindJSON(&request); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				adminID := value.(uint)
+				newVoucher := models.Voucher{Code: models.NormalizeVoucherCode(request.Code), DiscountType: request.DiscountType, DiscountValue: request.DiscountValue, TeamCategories: request.TeamCategories, UsageLimit: request.UsageLimit, IsDisabled: request.IsDisabled, AdminID: adminID}
+				if request.ValidFrom != nil {
+					newVoucher.ValidFrom = *request.ValidFrom
+				}
+				if request.ValidUntil != nil {
+					newVoucher.ValidUntil = *request.ValidUntil
+				}
+				if err := newVoucher.Validate(); err != nil {
+					response.Message = err.Error()
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				// Nilai kosong disimpan sebagai NULL sehingga perlu diperbarui menggunakan map
+				updates := map[string]interface{}{"discount_type": newVoucher.DiscountType, "discount_value": newVoucher.DiscountValue, "team_categories": nil, "valid_from": nil, "valid_until": nil, "usage_limit": newVoucher.UsageLimit, "is_disabled": newVoucher.IsDisabled, "admin_id": adminID}
+				if len(newVoucher.TeamCategories) > 0 {
+					updates["team_categories"] = newVoucher.TeamCategories
+				}
+				if request.ValidFrom != nil {
+					updates["valid_from"] = newVoucher.ValidFrom
+				}
+				if request.ValidUntil != nil {
+					updates["valid_until"] = newVoucher.ValidUntil
+				}
+
+				voucher := models.Voucher{}
+				if err := db.Transaction(func(tx *gorm.DB) error {
+					condition := models.Voucher{Code: newVoucher.Code}
+					return tx.Where(&condition).Assign(updates).FirstOrCreate(&voucher).Error
+				}); err != nil {
+					response.Message = err.Error()
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = voucher
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+func GetVoucherRedemptionsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[[]models.VoucherRedemptionReport]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				query := repository.GetVoucherRedemptionsQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				reports, err := models.GetVoucherRedemptionReport(db, query.VoucherID, query.TeamCategory)
+				if err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = reports
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,314 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"arkavidia-backend-8.0/competition/middlewares"
+	"arkavidia-backend-8.0/competition/models"
+	"arkavidia-backend-8.0/competition/repository"
+	databaseService "arkavidia-backend-8.0/competition/services/database"
+	"arkavidia-backend-8.0/competition/types"
+	"arkavidia-backend-8.0/competition/utils/mail"
+)
+
+// Mempromosikan waitlist seluruh jenis lomba yang slotnya mungkin terbuka di dalam transaksi yang sama,
+// alamat email anggota dikumpulkan agar pemberitahuan dapat dikirim setelah transaksi berhasil
+func promoteWaitlist(tx *gorm.DB, teamCategories ...types.TeamCategory) ([]models.WaitlistEntry, map[uint][]string, error) {
+	promoted := []models.WaitlistEntry{}
+	emails := map[uint][]string{}
+	for _, teamCategory := range teamCategories {
+		waitlistEntries, err := models.PromoteWaitlist(tx, teamCategory)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, waitlistEntry := range waitlistEntries {
+			emails[waitlistEntry.ID], err = models.GetMemberEmails(tx, waitlistEntry.TeamID)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		promoted = append(promoted, waitlistEntries...)
+	}
+
+	return promoted, emails, nil
+}
+
+func mailWaitlistPromotions(promoted []models.WaitlistEntry, emails map[uint][]string) {
+	// Asynchronously mail the promotion notice to every member of each team
+	for _, waitlistEntry := range promoted {
+		for _, email := range emails[waitlistEntry.ID] {
+			mail.Broker.AddMailToBroker(mail.MailParameters{Email: email, Subject: "Waitlist Promotion", Template: "waitlist-promotion", Data: map[string]interface{}{"TeamName": waitlistEntry.Team.TeamName, "TeamCategory": waitlistEntry.TeamCategory}})
+		}
+	}
+}
+
+// NOTE: Kuota jenis lomba dapat diakses secara publik tanpa autentikasi
+func GetCategoryQuotasHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[[]models.CategoryQuota]{}
+
+		query := repository.GetCategoryQuotasQuery{}
+		if err := c.ShouldBindQuery(&query); err != nil {
+			response.Message = "ERROR: BAD REQUEST"
+			c.AbortWithStatusJSON(http.StatusBadRequest, response)
+			return
+		}
+
+		condition := models.CategoryQuota{TeamCategory: query.TeamCategory}
+		categoryQuotas := []models.CategoryQuota{}
+		if err := db.Where(&condition).Order("team_category").Find(&categoryQuotas).Error; err != nil {
+			response.Message = "ERROR: BAD REQUEST"
+			c.AbortWithStatusJSON(http.StatusBadRequest, response)
+			return
+		}
+
+		response.Message = "SUCCESS"
+		response.Data = categoryQuotas
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+func SetCategoryQuotaHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[models.CategoryQuota]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				request := repository.SetCategoryQuotaRequest{}
+				if err := c.ShouldBindJSON(&request); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				adminID := value.(uint)
+				categoryQuota := models.CategoryQuota{}
+				promoted := []models.WaitlistEntry{}
+				emails := map[uint][]string{}
+				if err := db.Transaction(func(tx *gorm.DB) error {
+					condition := models.CategoryQuota{TeamCategory: request.TeamCategory}
+					newCategoryQuota := map[string]interface{}{"registration_capacity": request.RegistrationCapacity, "final_capacity": request.FinalCapacity, "registration_fee": request.RegistrationFee, "admin_id": adminID}
+					if err := tx.Where(&condition).Assign(newCategoryQuota).FirstOrCreate(&categoryQuota).Error; err != nil {
+						return err
+					}
+
+					// Penambahan kapasitas dapat membuka slot bagi team pada waitlist
+					var err error
+					promoted, emails, err = promoteWaitlist(tx, request.TeamCategory)
+					return err
+				}); err != nil {
+					response.Message = err.Error()
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				mailWaitlistPromotions(promoted, emails)
+
+				response.Message = "SUCCESS"
+				response.Data = categoryQuota
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+func GetWaitlistHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[[]models.WaitlistEntry]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				query := repository.GetWaitlistQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				waitlistEntries, err := models.GetWaitlist(db, query.TeamCategory)
+				if err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = waitlistEntries
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		case middlewares.Team:
+			{
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				teamID := value.(uint)
+				condition := models.WaitlistEntry{TeamID: teamID}
+				waitlistEntries := []models.WaitlistEntry{}
+				if err := db.Where(&condition).Order("created_at DESC").Find(&waitlistEntries).Error; err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = waitlistEntries
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+func ReorderWaitlistHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[[]models.WaitlistEntry]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				request := repository.ReorderWaitlistRequest{}
+				if err := c.ShouldBindJSON(&request); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				waitlistEntries := []models.WaitlistEntry{}
+				if err := db.Transaction(func(tx *gorm.DB) error {
+					var err error
+					waitlistEntries, err = models.ReorderWaitlist(tx, request.WaitlistEntryID, request.Position)
+					return err
+				}); err != nil {
+					response.Message = err.Error()
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = waitlistEntries
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+func LeaveWaitlistHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[models.WaitlistEntry]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Team:
+			{
+				query := repository.LeaveWaitlistQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				teamID := value.(uint)
+				if err := models.LeaveWaitlist(db, teamID, query.TeamCategory); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
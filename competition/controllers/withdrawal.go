@@ -0,0 +1,245 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	mailConfig "arkavidia-backend-8.0/competition/config/mail"
+	"arkavidia-backend-8.0/competition/middlewares"
+	"arkavidia-backend-8.0/competition/models"
+	"arkavidia-backend-8.0/competition/repository"
+	databaseService "arkavidia-backend-8.0/competition/services/database"
+	"arkavidia-backend-8.0/competition/types"
+	"arkavidia-backend-8.0/competition/utils/mail"
+)
+
+func withdrawTeamHandler(withdrawalType types.WithdrawalType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[models.TeamWithdrawal]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Team:
+			{
+				request := repository.WithdrawTeamRequest{}
+				if err := c.ShouldBindJSON(&request); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				teamID := value.(uint)
+				teamWithdrawal := models.TeamWithdrawal{}
+				promoted := []models.WaitlistEntry{}
+				emails := map[uint][]string{}
+				if err := db.Transaction(func(tx *gorm.DB) error {
+					conditionEnrolment := models.Enrolment{TeamID: teamID}
+					enrolments := []models.Enrolment{}
+					if err := tx.Where(&conditionEnrolment).Find(&enrolments).Error; err != nil {
+						return err
+					}
+
+					result, err := models.WithdrawTeam(tx, teamID, withdrawalType, request.TeamCategory, request.Reason)
+					if err != nil {
+						return err
+					}
+					teamWithdrawal = result
+
+					// Slot pendaftaran yang dilepaskan diberikan kepada team berikutnya pada waitlist
+					teamCategories := []types.TeamCategory{}
+					for _, enrolment := range enrolments {
+						if withdrawalType == types.Disband || enrolment.ID == teamWithdrawal.EnrolmentID {
+							teamCategories = append(teamCategories, enrolment.TeamCategory)
+						}
+					}
+
+					promoted, emails, err = promoteWaitlist(tx, teamCategories...)
+					return err
+				}); err != nil {
+					response.Message = err.Error()
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				// Asynchronously notify admin about the withdrawal
+				if adminEmail := mailConfig.Config.GetMetadata().AdminEmail; adminEmail != "" {
+					mail.Broker.AddMailToBroker(mail.MailParameters{Email: adminEmail, Subject: "Team Withdrawal", Template: "team-withdrawal", Data: map[string]interface{}{"TeamName": teamWithdrawal.Team.TeamName, "Type": teamWithdrawal.Type, "TeamCategory": teamWithdrawal.PreviousCategory, "Reason": teamWithdrawal.Reason}})
+				}
+
+				mailWaitlistPromotions(promoted, emails)
+
+				response.Message = "SUCCESS"
+				response.Data = teamWithdrawal
+				c.JSON(http.StatusCreated, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+func WithdrawTeamHandler() gin.HandlerFunc {
+	return withdrawTeamHandler(types.Withdrawal)
+}
+
+func DisbandTeamHandler() gin.HandlerFunc {
+	return withdrawTeamHandler(types.Disband)
+}
+
+func GetTeamWithdrawalsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[[]models.TeamWithdrawal]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				query := repository.GetTeamWithdrawalsQuery{}
+				if err := c.ShouldBindQuery(&query); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				condition := models.TeamWithdrawal{TeamID: query.TeamID}
+				teamWithdrawals := []models.TeamWithdrawal{}
+				if err := db.Where(&condition).Order("created_at DESC").Find(&teamWithdrawals).Error; err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = teamWithdrawals
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		case middlewares.Team:
+			{
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				teamID := value.(uint)
+				condition := models.TeamWithdrawal{TeamID: teamID}
+				teamWithdrawals := []models.TeamWithdrawal{}
+				if err := db.Where(&condition).Order("created_at DESC").Find(&teamWithdrawals).Error; err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = teamWithdrawals
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
+
+func RevertWithdrawalHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		db := databaseService.DB.GetConnection()
+		response := repository.Response[models.TeamWithdrawal]{}
+
+		value, exists := c.Get("role")
+		if !exists {
+			response.Message = "UNAUTHORIZED"
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		role := value.(middlewares.AuthRole)
+
+		switch role {
+		case middlewares.Admin:
+			{
+				request := repository.RevertWithdrawalRequest{}
+				if err := c.ShouldBindJSON(&request); err != nil {
+					response.Message = "ERROR: BAD REQUEST"
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				value, exists := c.Get("id")
+				if !exists {
+					response.Message = "UNAUTHORIZED"
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+					return
+				}
+
+				adminID := value.(uint)
+				teamWithdrawal := models.TeamWithdrawal{}
+				if err := db.Transaction(func(tx *gorm.DB) error {
+					result, err := models.RevertWithdrawal(tx, request.WithdrawalID, adminID)
+					if err != nil {
+						return err
+					}
+
+					teamWithdrawal = result
+					return nil
+				}); err != nil {
+					if abortWithCompositionError(c, err) {
+						return
+					}
+					response.Message = err.Error()
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+
+				response.Message = "SUCCESS"
+				response.Data = teamWithdrawal
+				c.JSON(http.StatusOK, response)
+				return
+			}
+		default:
+			{
+				response.Message = "ERROR: INVALID ROLE"
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+		}
+	}
+}
@@ -15,8 +15,10 @@ import (
 type AuthRole string
 
 const (
-	Admin AuthRole = "Admin"
-	Team  AuthRole = "Team"
+	Admin       AuthRole = "Admin"
+	Team        AuthRole = "Team"
+	Judge       AuthRole = "Judge"
+	Participant AuthRole = "Participant"
 )
 
 type AuthClaims struct {
@@ -11,29 +11,29 @@ import (
 
 type Admin struct {
 	gorm.Model
-	Username       string                `gorm:"not null;unique"`
-	HashedPassword types.EncryptedString `gorm:"not null"`
-	ApprovesPhoto  []Photo
-	ApprovesTeam   []Team
+	Username          string                `gorm:"not null;unique"`
+	HashedPassword    types.EncryptedString `gorm:"not null"`
+	ApprovesPhoto     []Photo
+	ApprovesEnrolment []Enrolment
 }
 
 type DisplayAdmin struct {
-	ID             uint                  `json:"id,omitempty"`
-	CreatedAt      time.Time             `json:"created_at,omitempty"`
-	UpdatedAt      time.Time             `json:"updated_at,omitempty"`
-	Username       string                `json:"username,omitempty"`
-	HashedPassword types.EncryptedString `json:"-"`
-	ApprovesPhoto  []Photo               `json:"photos,omitempty"`
-	ApprovesTeam   []Team                `json:"teams,omitempty"`
+	ID                uint                  `json:"id,omitempty"`
+	CreatedAt         time.Time             `json:"created_at,omitempty"`
+	UpdatedAt         time.Time             `json:"updated_at,omitempty"`
+	Username          string                `json:"username,omitempty"`
+	HashedPassword    types.EncryptedString `json:"-"`
+	ApprovesPhoto     []Photo               `json:"photos,omitempty"`
+	ApprovesEnrolment []Enrolment           `json:"enrolments,omitempty"`
 }
 
 func (admin Admin) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&DisplayAdmin{
-		ID:            admin.ID,
-		CreatedAt:     admin.CreatedAt,
-		UpdatedAt:     admin.UpdatedAt,
-		Username:      admin.Username,
-		ApprovesPhoto: admin.ApprovesPhoto,
-		ApprovesTeam:  admin.ApprovesTeam,
+		ID:                admin.ID,
+		CreatedAt:         admin.CreatedAt,
+		UpdatedAt:         admin.UpdatedAt,
+		Username:          admin.Username,
+		ApprovesPhoto:     admin.ApprovesPhoto,
+		ApprovesEnrolment: admin.ApprovesEnrolment,
 	})
 }
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Answer struct {
+	gorm.Model
+	AttemptID  uint     `gorm:"not null;uniqueIndex:answer_index"`
+	QuestionID uint     `gorm:"not null;uniqueIndex:answer_index"`
+	OptionID   *uint    `gorm:"default:null"`
+	Text       string   `gorm:"default:null"`
+	IsCorrect  *bool    `gorm:"default:null"`
+	Point      *float64 `gorm:"default:null"`
+	Attempt    Attempt  `gorm:"foreignKey:AttemptID;references:ID"`
+	Question   Question `gorm:"foreignKey:QuestionID;references:ID"`
+}
+
+// NOTE: Hasil penilaian per jawaban tidak ditampilkan agar kunci jawaban tidak bocor selama ujian berlangsung
+type DisplayAnswer struct {
+	ID         uint      `json:"id,omitempty"`
+	CreatedAt  time.Time `json:"created_at,omitempty"`
+	UpdatedAt  time.Time `json:"updated_at,omitempty"`
+	AttemptID  uint      `json:"attempt_id,omitempty"`
+	QuestionID uint      `json:"question_id,omitempty"`
+	OptionID   *uint     `json:"option_id,omitempty"`
+	Text       string    `json:"text,omitempty"`
+}
+
+func (answer Answer) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&DisplayAnswer{
+		ID:         answer.ID,
+		CreatedAt:  answer.CreatedAt,
+		UpdatedAt:  answer.UpdatedAt,
+		AttemptID:  answer.AttemptID,
+		QuestionID: answer.QuestionID,
+		OptionID:   answer.OptionID,
+		Text:       answer.Text,
+	})
+}
+
+// Menambahkan constraint untuk mengecek apakah attempt masih berlangsung dan jawaban sesuai dengan soal pada ujian tersebut
+func (answer *Answer) BeforeSave(tx *gorm.DB) error {
+	conditionAttempt := Attempt{Model: gorm.Model{ID: answer.AttemptID}}
+	attempt := Attempt{}
+	if err := tx.Where(&conditionAttempt).First(&attempt).Error; err != nil {
+		return err
+	}
+	if !attempt.IsOpen(tx.NowFunc()) {
+		return fmt.Errorf("ERROR: ATTEMPT IS CLOSED")
+	}
+
+	conditionQuestion := Question{Model: gorm.Model{ID: answer.QuestionID}, ExamID: attempt.ExamID}
+	question := Question{}
+	if err := tx.Preload("Options").Where(&conditionQuestion).First(&question).Error; err != nil {
+		return fmt.Errorf("ERROR: QUESTION NOT IN EXAM")
+	}
+
+	if answer.OptionID != nil {
+		isValid := false
+		for _, option := range question.Options {
+			if option.ID == *answer.OptionID {
+				isValid = true
+			}
+		}
+		if !isValid {
+			return fmt.Errorf("ERROR: OPTION NOT IN QUESTION")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type Assignment struct {
+	gorm.Model
+	JudgeID      uint       `gorm:"not null;uniqueIndex:assignment_index"`
+	SubmissionID uint       `gorm:"not null;uniqueIndex:assignment_index"`
+	AdminID      uint       `gorm:"not null"`
+	NeedsReview  bool       `gorm:"not null;default:false"`
+	Judge        Judge      `gorm:"foreignKey:JudgeID;references:ID"`
+	Submission   Submission `gorm:"foreignKey:SubmissionID;references:ID"`
+	AssignedBy   Admin      `gorm:"foreignKey:AdminID;references:ID"`
+	Scores       []Score
+}
+
+type DisplayAssignment struct {
+	ID           uint      `json:"id,omitempty"`
+	CreatedAt    time.Time `json:"created_at,omitempty"`
+	UpdatedAt    time.Time `json:"updated_at,omitempty"`
+	JudgeID      uint      `json:"judge_id,omitempty"`
+	SubmissionID uint      `json:"submission_id,omitempty"`
+	AdminID      uint      `json:"admin_id,omitempty"`
+	NeedsReview  bool      `json:"needs_review"`
+	Scores       []Score   `json:"scores,omitempty"`
+}
+
+func (assignment Assignment) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&DisplayAssignment{
+		ID:           assignment.ID,
+		CreatedAt:    assignment.CreatedAt,
+		UpdatedAt:    assignment.UpdatedAt,
+		JudgeID:      assignment.JudgeID,
+		SubmissionID: assignment.SubmissionID,
+		AdminID:      assignment.AdminID,
+		NeedsReview:  assignment.NeedsReview,
+		Scores:       assignment.Scores,
+	})
+}
+
+// Tampilan assignment untuk judge (blind review): identitas team, nama anggota, dan receipt tidak disertakan
+type BlindAssignment struct {
+	ID            uint                  `json:"id,omitempty"`
+	CreatedAt     time.Time             `json:"created_at,omitempty"`
+	UpdatedAt     time.Time             `json:"updated_at,omitempty"`
+	SubmissionID  uint                  `json:"submission_id,omitempty"`
+	Stage         types.SubmissionStage `json:"stage,omitempty"`
+	Version       uint                  `json:"version,omitempty"`
+	FileExtension string                `json:"file_extension,omitempty"`
+	IsLate        bool                  `json:"is_late,omitempty"`
+	Scores        []Score               `json:"scores,omitempty"`
+}
+
+func (assignment Assignment) Blind() BlindAssignment {
+	return BlindAssignment{
+		ID:            assignment.ID,
+		CreatedAt:     assignment.CreatedAt,
+		UpdatedAt:     assignment.UpdatedAt,
+		SubmissionID:  assignment.SubmissionID,
+		Stage:         assignment.Submission.Stage,
+		Version:       assignment.Submission.Version,
+		FileExtension: assignment.Submission.FileExtension,
+		IsLate:        assignment.Submission.IsLate,
+		Scores:        assignment.Scores,
+	}
+}
+
+// Menambahkan constraint untuk mengecek apakah judge memiliki konflik kepentingan dengan institusi team dan anggotanya,
+// assignment ditandai perlu ditinjau jika ada institusi yang tidak diketahui
+func (assignment *Assignment) BeforeCreate(tx *gorm.DB) error {
+	conditionSubmission := Submission{Model: gorm.Model{ID: assignment.SubmissionID}}
+	submission := Submission{}
+	if err := tx.Where(&conditionSubmission).First(&submission).Error; err != nil {
+		return err
+	}
+
+	hasConflict, needsReview, err := HasConflict(tx, assignment.JudgeID, submission.TeamID)
+	if err != nil {
+		return err
+	}
+	if hasConflict {
+		return fmt.Errorf("ERROR: JUDGE HAS CONFLICT OF INTEREST")
+	}
+	assignment.NeedsReview = needsReview
+
+	return nil
+}
+
+// Setiap submission final pada jenis lomba dan stage tersebut mendapatkan judgesPerSubmission judge,
+// judge dipilih berdasarkan jumlah assignment paling sedikit dan tidak memiliki konflik kepentingan
+func AutoAssign(tx *gorm.DB, teamCategory types.TeamCategory, stage types.SubmissionStage, judgesPerSubmission int, adminID uint) ([]Assignment, error) {
+	judges := []Judge{}
+	if err := tx.Find(&judges).Error; err != nil {
+		return nil, err
+	}
+
+	loads := map[uint]int64{}
+	for _, judge := range judges {
+		var count int64
+		condition := Assignment{JudgeID: judge.ID}
+		if err := tx.Model(&Assignment{}).Where(&condition).Count(&count).Error; err != nil {
+			return nil, err
+		}
+		loads[judge.ID] = count
+	}
+
+	submissions := []Submission{}
+	if err := tx.Joins("JOIN enrolments ON enrolments.id = submissions.enrolment_id AND enrolments.deleted_at IS NULL").Where("enrolments.team_category = ? AND submissions.stage = ? AND submissions.is_final", teamCategory, stage).Order("submissions.id").Find(&submissions).Error; err != nil {
+		return nil, err
+	}
+
+	assignments := []Assignment{}
+	for _, submission := range submissions {
+		conditionAssignment := Assignment{SubmissionID: submission.ID}
+		existingAssignments := []Assignment{}
+		if err := tx.Where(&conditionAssignment).Find(&existingAssignments).Error; err != nil {
+			return nil, err
+		}
+
+		assigned := map[uint]bool{}
+		for _, existingAssignment := range existingAssignments {
+			assigned[existingAssignment.JudgeID] = true
+		}
+
+		candidates := []Judge{}
+		for _, judge := range judges {
+			if assigned[judge.ID] {
+				continue
+			}
+
+			hasConflict, _, err := HasConflict(tx, judge.ID, submission.TeamID)
+			if err != nil {
+				return nil, err
+			}
+			if !hasConflict {
+				candidates = append(candidates, judge)
+			}
+		}
+
+		need := judgesPerSubmission - len(existingAssignments)
+		if need <= 0 {
+			continue
+		}
+		if len(candidates) < need {
+			return nil, fmt.Errorf("ERROR: NOT ENOUGH ELIGIBLE JUDGES FOR SUBMISSION %d", submission.ID)
+		}
+
+		sort.SliceStable(candidates, func(i, j int) bool {
+			if loads[candidates[i].ID] == loads[candidates[j].ID] {
+				return candidates[i].ID < candidates[j].ID
+			}
+			return loads[candidates[i].ID] < loads[candidates[j].ID]
+		})
+
+		for _, judge := range candidates[:need] {
+			assignment := Assignment{JudgeID: judge.ID, SubmissionID: submission.ID, AdminID: adminID}
+			if err := tx.Create(&assignment).Error; err != nil {
+				return nil, err
+			}
+			loads[judge.ID]++
+			assignments = append(assignments, assignment)
+		}
+	}
+
+	return assignments, nil
+}
@@ -0,0 +1,153 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type Attempt struct {
+	gorm.Model
+	ExamID      uint       `gorm:"not null;uniqueIndex:attempt_index"`
+	TeamID      uint       `gorm:"not null;uniqueIndex:attempt_index"`
+	StartedAt   time.Time  `gorm:"not null"`
+	EndAt       time.Time  `gorm:"not null"`
+	SubmittedAt *time.Time `gorm:"default:null"`
+	Score       float64    `gorm:"not null;default:0"`
+	IsGraded    bool       `gorm:"not null;default:false"`
+	Exam        Exam       `gorm:"foreignKey:ExamID;references:ID"`
+	Team        Team       `gorm:"foreignKey:TeamID;references:ID"`
+	Answers     []Answer
+	isHidden    bool
+}
+
+type DisplayAttempt struct {
+	ID          uint       `json:"id,omitempty"`
+	CreatedAt   time.Time  `json:"created_at,omitempty"`
+	UpdatedAt   time.Time  `json:"updated_at,omitempty"`
+	ExamID      uint       `json:"exam_id,omitempty"`
+	TeamID      uint       `json:"team_id,omitempty"`
+	StartedAt   time.Time  `json:"started_at,omitempty"`
+	EndAt       time.Time  `json:"end_at,omitempty"`
+	SubmittedAt *time.Time `json:"submitted_at,omitempty"`
+	Score       *float64   `json:"score,omitempty"`
+	IsGraded    *bool      `json:"is_graded,omitempty"`
+	Answers     []Answer   `json:"answers,omitempty"`
+}
+
+func (attempt Attempt) MarshalJSON() ([]byte, error) {
+	var score *float64
+	var isGraded *bool
+	if !attempt.isHidden {
+		score = &attempt.Score
+		isGraded = &attempt.IsGraded
+	}
+
+	return json.Marshal(&DisplayAttempt{
+		ID:          attempt.ID,
+		CreatedAt:   attempt.CreatedAt,
+		UpdatedAt:   attempt.UpdatedAt,
+		ExamID:      attempt.ExamID,
+		TeamID:      attempt.TeamID,
+		StartedAt:   attempt.StartedAt,
+		EndAt:       attempt.EndAt,
+		SubmittedAt: attempt.SubmittedAt,
+		Score:       score,
+		IsGraded:    isGraded,
+		Answers:     attempt.Answers,
+	})
+}
+
+// Menambahkan constraint untuk mengecek apakah team Arkalogica yang belum tereliminasi memulai ujian pada stage-nya
+// dalam rentang waktu ujian, kemudian menentukan waktu berakhir attempt di sisi server
+func (attempt *Attempt) BeforeCreate(tx *gorm.DB) error {
+	enrolment, err := FindEnrolment(tx, attempt.TeamID, types.Arkalogica)
+	if err != nil {
+		return err
+	}
+	if enrolment.Status == types.Eliminated {
+		return fmt.Errorf("ERROR: TEAM ELIMINATED")
+	}
+
+	conditionExam := Exam{Model: gorm.Model{ID: attempt.ExamID}}
+	exam := Exam{}
+	if err := tx.Where(&conditionExam).First(&exam).Error; err != nil {
+		return err
+	}
+	if enrolment.GetCurrentStage() != exam.Stage {
+		return fmt.Errorf("ERROR: STAGE LOCKED")
+	}
+
+	now := tx.NowFunc()
+	if now.Before(exam.StartAt) || !now.Before(exam.EndAt) {
+		return fmt.Errorf("ERROR: EXAM IS NOT OPEN")
+	}
+
+	attempt.StartedAt = now
+	attempt.EndAt = now.Add(time.Duration(exam.Duration) * time.Second)
+	if attempt.EndAt.After(exam.EndAt) {
+		attempt.EndAt = exam.EndAt
+	}
+
+	return nil
+}
+
+// Nilai attempt tidak ditampilkan kepada team sebelum hasil ujian dipublikasikan
+func (attempt Attempt) HideResult(exam Exam) Attempt {
+	attempt.isHidden = !exam.IsResultPublished()
+	return attempt
+}
+
+func (attempt Attempt) IsOpen(now time.Time) bool {
+	return attempt.SubmittedAt == nil && now.Before(attempt.EndAt)
+}
+
+// Menilai seluruh jawaban yang dapat dinilai otomatis, nilai manual untuk isian singkat tanpa kunci tetap dipertahankan
+// Attempt yang belum dikumpulkan namun telah melewati waktu berakhir dianggap dikumpulkan pada waktu berakhir
+func (attempt *Attempt) Grade(tx *gorm.DB) error {
+	conditionAnswer := Answer{AttemptID: attempt.ID}
+	answers := []Answer{}
+	if err := tx.Preload("Question.Options").Where(&conditionAnswer).Find(&answers).Error; err != nil {
+		return err
+	}
+
+	score := 0.0
+	isGraded := true
+	for _, answer := range answers {
+		if !answer.Question.IsAutoGraded() {
+			if answer.Point == nil {
+				isGraded = false
+			} else {
+				score += *answer.Point
+			}
+			continue
+		}
+
+		isCorrect := answer.Question.IsCorrect(answer)
+		point := 0.0
+		if isCorrect {
+			point = answer.Question.Point
+		}
+		score += point
+
+		if err := tx.Model(&answer).UpdateColumns(map[string]interface{}{"is_correct": isCorrect, "point": point}).Error; err != nil {
+			return err
+		}
+	}
+
+	if attempt.SubmittedAt == nil {
+		submittedAt := attempt.EndAt
+		if now := tx.NowFunc(); now.Before(submittedAt) {
+			submittedAt = now
+		}
+		attempt.SubmittedAt = &submittedAt
+	}
+	attempt.Score = score
+	attempt.IsGraded = isGraded
+
+	return tx.Model(attempt).UpdateColumns(map[string]interface{}{"submitted_at": attempt.SubmittedAt, "score": score, "is_graded": isGraded}).Error
+}
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type CategoryQuota struct {
+	gorm.Model
+	TeamCategory         types.TeamCategory `gorm:"not null;unique"`
+	RegistrationCapacity uint               `gorm:"not null;default:0"`
+	FinalCapacity        uint               `gorm:"not null;default:0"`
+	RegistrationFee      uint               `gorm:"not null;default:0"`
+	AdminID              uint               `gorm:"not null"`
+	SetBy                Admin              `gorm:"foreignKey:AdminID;references:ID"`
+}
+
+type DisplayCategoryQuota struct {
+	ID                   uint               `json:"id,omitempty"`
+	CreatedAt            time.Time          `json:"created_at,omitempty"`
+	UpdatedAt            time.Time          `json:"updated_at,omitempty"`
+	TeamCategory         types.TeamCategory `json:"team_category,omitempty"`
+	RegistrationCapacity uint               `json:"registration_capacity"`
+	FinalCapacity        uint               `json:"final_capacity"`
+	RegistrationFee      uint               `json:"registration_fee"`
+	AdminID              uint               `json:"admin_id,omitempty"`
+}
+
+func (categoryQuota CategoryQuota) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&DisplayCategoryQuota{
+		ID:                   categoryQuota.ID,
+		CreatedAt:            categoryQuota.CreatedAt,
+		UpdatedAt:            categoryQuota.UpdatedAt,
+		TeamCategory:         categoryQuota.TeamCategory,
+		RegistrationCapacity: categoryQuota.RegistrationCapacity,
+		FinalCapacity:        categoryQuota.FinalCapacity,
+		RegistrationFee:      categoryQuota.RegistrationFee,
+		AdminID:              categoryQuota.AdminID,
+	})
+}
+
+// Kuota jenis lomba dikunci agar pendaftaran dan promosi waitlist yang bersamaan tidak melebihi kapasitas,
+// jenis lomba tanpa kuota atau dengan kapasitas 0 dianggap tidak terbatas
+func LockCategoryQuota(tx *gorm.DB, teamCategory types.TeamCategory) (CategoryQuota, error) {
+	condition := CategoryQuota{TeamCategory: teamCategory}
+	categoryQuota := CategoryQuota{}
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(&condition).Find(&categoryQuota).Error; err != nil {
+		return CategoryQuota{}, err
+	}
+
+	categoryQuota.TeamCategory = teamCategory
+	return categoryQuota, nil
+}
+
+// Mengembalikan jumlah slot pendaftaran yang masih tersedia, enrolment yang telah ditarik (soft delete) tidak menempati slot
+func (categoryQuota CategoryQuota) GetAvailableSlots(tx *gorm.DB) (int, bool, error) {
+	if categoryQuota.RegistrationCapacity == 0 {
+		return 0, false, nil
+	}
+
+	var count int64
+	condition := Enrolment{TeamCategory: categoryQuota.TeamCategory}
+	if err := tx.Model(&Enrolment{}).Where(&condition).Count(&count).Error; err != nil {
+		return 0, true, err
+	}
+
+	available := int(categoryQuota.RegistrationCapacity) - int(count)
+	if available < 0 {
+		available = 0
+	}
+
+	return available, true, nil
+}
+
+// Menambahkan constraint untuk mengecek apakah masih terdapat kursi pada babak final sebelum enrolment dipindahkan ke sana
+func (categoryQuota CategoryQuota) CheckFinalCapacity(tx *gorm.DB) error {
+	if categoryQuota.FinalCapacity == 0 {
+		return nil
+	}
+
+	var count int64
+	condition := Enrolment{TeamCategory: categoryQuota.TeamCategory, Stage: types.FinalStage}
+	if err := tx.Model(&Enrolment{}).Where(&condition).Count(&count).Error; err != nil {
+		return err
+	}
+	if count >= int64(categoryQuota.FinalCapacity) {
+		return fmt.Errorf("ERROR: FINAL STAGE QUOTA REACHED")
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type CertificateBatch struct {
+	gorm.Model
+	TeamCategory types.TeamCategory `gorm:"not null"`
+	Status       types.BatchStatus  `gorm:"not null;default:'pending'"`
+	IssuedCount  uint               `gorm:"not null;default:0"`
+	AdminID      uint               `gorm:"not null"`
+	RequestedBy  Admin              `gorm:"foreignKey:AdminID;references:ID"`
+	Certificates []Certificate      `gorm:"foreignKey:BatchID"`
+}
+
+type DisplayCertificateBatch struct {
+	ID           uint               `json:"id,omitempty"`
+	CreatedAt    time.Time          `json:"created_at,omitempty"`
+	UpdatedAt    time.Time          `json:"updated_at,omitempty"`
+	TeamCategory types.TeamCategory `json:"team_category,omitempty"`
+	Status       types.BatchStatus  `json:"status,omitempty"`
+	IssuedCount  uint               `json:"issued_count"`
+	AdminID      uint               `json:"admin_id,omitempty"`
+	Certificates []Certificate      `json:"certificates,omitempty"`
+}
+
+func (certificateBatch CertificateBatch) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&DisplayCertificateBatch{
+		ID:           certificateBatch.ID,
+		CreatedAt:    certificateBatch.CreatedAt,
+		UpdatedAt:    certificateBatch.UpdatedAt,
+		TeamCategory: certificateBatch.TeamCategory,
+		Status:       certificateBatch.Status,
+		IssuedCount:  certificateBatch.IssuedCount,
+		AdminID:      certificateBatch.AdminID,
+		Certificates: certificateBatch.Certificates,
+	})
+}
@@ -0,0 +1,166 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+// Certificate menyimpan salinan nama participant dan team pada saat diterbitkan agar isi sertifikat tidak berubah
+type Certificate struct {
+	gorm.Model
+	Serial          uuid.UUID             `gorm:"type:uuid;not null;unique"`
+	BatchID         uint                  `gorm:"not null"`
+	ParticipantID   uint                  `gorm:"not null;uniqueIndex:certificate_index"`
+	EnrolmentID     uint                  `gorm:"not null;uniqueIndex:certificate_index"`
+	TeamID          uint                  `gorm:"not null"`
+	TeamCategory    types.TeamCategory    `gorm:"not null"`
+	Kind            types.CertificateKind `gorm:"not null"`
+	Stage           types.SubmissionStage `gorm:"not null"`
+	TeamStatus      types.TeamStatus      `gorm:"not null"`
+	ParticipantName string                `gorm:"not null"`
+	TeamName        string                `gorm:"not null"`
+	IssuedAt        time.Time             `gorm:"not null"`
+	SentAt          time.Time             `gorm:"default:null"`
+	Batch           CertificateBatch      `gorm:"foreignKey:BatchID;references:ID"`
+	Participant     Participant           `gorm:"foreignKey:ParticipantID;references:ID"`
+	Enrolment       Enrolment             `gorm:"foreignKey:EnrolmentID;references:ID"`
+	Team            Team                  `gorm:"foreignKey:TeamID;references:ID"`
+}
+
+type DisplayCertificate struct {
+	ID              uint                  `json:"id,omitempty"`
+	CreatedAt       time.Time             `json:"created_at,omitempty"`
+	UpdatedAt       time.Time             `json:"updated_at,omitempty"`
+	Serial          uuid.UUID             `json:"serial,omitempty"`
+	BatchID         uint                  `json:"batch_id,omitempty"`
+	ParticipantID   uint                  `json:"participant_id,omitempty"`
+	EnrolmentID     uint                  `json:"enrolment_id,omitempty"`
+	TeamID          uint                  `json:"team_id,omitempty"`
+	TeamCategory    types.TeamCategory    `json:"team_category,omitempty"`
+	Kind            types.CertificateKind `json:"kind,omitempty"`
+	Stage           types.SubmissionStage `json:"stage,omitempty"`
+	TeamStatus      types.TeamStatus      `json:"team_status,omitempty"`
+	ParticipantName string                `json:"participant_name,omitempty"`
+	TeamName        string                `json:"team_name,omitempty"`
+	IssuedAt        time.Time             `json:"issued_at,omitempty"`
+	SentAt          *time.Time            `json:"sent_at,omitempty"`
+}
+
+func (certificate Certificate) MarshalJSON() ([]byte, error) {
+	var sentAt *time.Time
+	if !certificate.SentAt.IsZero() {
+		sentAt = &certificate.SentAt
+	}
+
+	return json.Marshal(&DisplayCertificate{
+		ID:              certificate.ID,
+		CreatedAt:       certificate.CreatedAt,
+		UpdatedAt:       certificate.UpdatedAt,
+		Serial:          certificate.Serial,
+		BatchID:         certificate.BatchID,
+		ParticipantID:   certificate.ParticipantID,
+		EnrolmentID:     certificate.EnrolmentID,
+		TeamID:          certificate.TeamID,
+		TeamCategory:    certificate.TeamCategory,
+		Kind:            certificate.Kind,
+		Stage:           certificate.Stage,
+		TeamStatus:      certificate.TeamStatus,
+		ParticipantName: certificate.ParticipantName,
+		TeamName:        certificate.TeamName,
+		IssuedAt:        certificate.IssuedAt,
+		SentAt:          sentAt,
+	})
+}
+
+// Tampilan publik untuk verifikasi sertifikat melalui QR code, data lain seperti id participant dan team tidak disertakan
+type VerifiedCertificate struct {
+	ParticipantName string                `json:"participant_name,omitempty"`
+	TeamName        string                `json:"team_name,omitempty"`
+	TeamCategory    types.TeamCategory    `json:"team_category,omitempty"`
+	Kind            types.CertificateKind `json:"kind,omitempty"`
+	IssuedAt        time.Time             `json:"issued_at,omitempty"`
+}
+
+func (certificate Certificate) Verify() VerifiedCertificate {
+	return VerifiedCertificate{
+		ParticipantName: certificate.ParticipantName,
+		TeamName:        certificate.TeamName,
+		TeamCategory:    certificate.TeamCategory,
+		Kind:            certificate.Kind,
+		IssuedAt:        certificate.IssuedAt,
+	}
+}
+
+// Menerbitkan sertifikat untuk setiap anggota aktif dari enrolment pada jenis lomba batch,
+// anggota yang telah memiliki sertifikat untuk enrolment yang sama tidak diterbitkan ulang
+func IssueCertificates(tx *gorm.DB, batch CertificateBatch) ([]Certificate, error) {
+	conditionEnrolment := Enrolment{TeamCategory: batch.TeamCategory}
+	enrolments := []Enrolment{}
+	if err := tx.Preload("Team").Where(&conditionEnrolment).Order("id").Find(&enrolments).Error; err != nil {
+		return nil, err
+	}
+
+	certificates := []Certificate{}
+	for _, enrolment := range enrolments {
+		conditionMembership := Membership{TeamID: enrolment.TeamID, Status: types.ActiveMembership}
+		memberships := []Membership{}
+		if err := tx.Preload("Participant").Where(&conditionMembership).Order("id").Find(&memberships).Error; err != nil {
+			return nil, err
+		}
+
+		stage := enrolment.GetCurrentStage()
+		for _, membership := range memberships {
+			if !membership.Participant.AnonymisedAt.IsZero() {
+				continue
+			}
+
+			var count int64
+			conditionCertificate := Certificate{ParticipantID: membership.ParticipantID, EnrolmentID: enrolment.ID}
+			if err := tx.Model(&Certificate{}).Where(&conditionCertificate).Count(&count).Error; err != nil {
+				return nil, err
+			}
+			if count > 0 {
+				continue
+			}
+
+			certificate := Certificate{
+				Serial:          uuid.New(),
+				BatchID:         batch.ID,
+				ParticipantID:   membership.ParticipantID,
+				EnrolmentID:     enrolment.ID,
+				TeamID:          enrolment.TeamID,
+				TeamCategory:    enrolment.TeamCategory,
+				Kind:            types.GetCertificateKind(stage, enrolment.Status),
+				Stage:           stage,
+				TeamStatus:      enrolment.Status,
+				ParticipantName: membership.Participant.Name,
+				TeamName:        enrolment.Team.TeamName,
+				IssuedAt:        tx.NowFunc(),
+			}
+			if err := tx.Create(&certificate).Error; err != nil {
+				return nil, err
+			}
+
+			certificate.Participant = membership.Participant
+			certificates = append(certificates, certificate)
+		}
+	}
+
+	return certificates, nil
+}
+
+// Sertifikat yang belum terkirim pada jenis lomba tersebut, termasuk dari batch sebelumnya yang gagal dikirim
+func GetUnsentCertificates(tx *gorm.DB, teamCategory types.TeamCategory) ([]Certificate, error) {
+	condition := Certificate{TeamCategory: teamCategory}
+	certificates := []Certificate{}
+	if err := tx.Preload("Participant").Joins("JOIN participants ON participants.id = certificates.participant_id AND participants.anonymised_at IS NULL").Where(&condition).Where("certificates.sent_at IS NULL").Order("certificates.id").Find(&certificates).Error; err != nil {
+		return nil, err
+	}
+
+	return certificates, nil
+}
@@ -0,0 +1,196 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+const (
+	ChecklistPhoto             = "photo"
+	ChecklistEmailConfirmation = "email-confirmation"
+	ChecklistPayment           = "payment"
+	ChecklistTeamSize          = "team-size"
+	ChecklistSubmission        = "submission"
+)
+
+type ChecklistItem struct {
+	Key     string                `json:"key"`
+	Detail  string                `json:"detail,omitempty"`
+	Status  types.ChecklistStatus `json:"status"`
+	Message string                `json:"message,omitempty"`
+}
+
+type MemberChecklist struct {
+	ParticipantID uint                 `json:"participant_id"`
+	Name          string               `json:"name"`
+	Role          types.MembershipRole `json:"role"`
+	Items         []ChecklistItem      `json:"items"`
+}
+
+type TeamChecklist struct {
+	TeamID     uint              `json:"team_id"`
+	TeamName   string            `json:"team_name"`
+	IsComplete bool              `json:"is_complete"`
+	Incomplete []string          `json:"incomplete,omitempty"`
+	Items      []ChecklistItem   `json:"items"`
+	Members    []MemberChecklist `json:"members"`
+}
+
+// Mengecek apakah item dengan key tertentu belum lengkap, key kosong berarti item apa pun
+func (teamChecklist TeamChecklist) HasIncomplete(key string) bool {
+	for _, incomplete := range teamChecklist.Incomplete {
+		if key == "" || incomplete == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+func getPhotoChecklistStatus(photos []Photo) types.ChecklistStatus {
+	status := types.ChecklistMissing
+	for _, photo := range photos {
+		switch photo.Status {
+		case types.Approved:
+			return types.ChecklistApproved
+		case types.WaitingForApproval:
+			status = types.ChecklistPending
+		case types.Denied:
+			if status == types.ChecklistMissing {
+				status = types.ChecklistDenied
+			}
+		}
+	}
+
+	return status
+}
+
+// Checklist kelengkapan pendaftaran dihitung dari data terkini setiap kali diminta,
+// dokumen yang wajib diunggah anggota ditentukan oleh jenjang pendidikan anggota atau team
+func BuildTeamChecklist(tx *gorm.DB, teamID uint) (TeamChecklist, error) {
+	conditionTeam := Team{Model: gorm.Model{ID: teamID}}
+	team := Team{}
+	if err := tx.Preload("Enrolments").Where(&conditionTeam).First(&team).Error; err != nil {
+		return TeamChecklist{}, err
+	}
+
+	conditionMembership := Membership{TeamID: teamID, Status: types.ActiveMembership}
+	memberships := []Membership{}
+	if err := tx.Preload("Participant.Photos").Where(&conditionMembership).Order("id").Find(&memberships).Error; err != nil {
+		return TeamChecklist{}, err
+	}
+
+	teamChecklist := TeamChecklist{TeamID: team.ID, TeamName: team.TeamName, Items: []ChecklistItem{}, Members: []MemberChecklist{}}
+	paymentPhotos := []Photo{}
+	for _, membership := range memberships {
+		participant := membership.Participant
+		memberChecklist := MemberChecklist{ParticipantID: participant.ID, Name: participant.Name, Role: membership.Role, Items: []ChecklistItem{}}
+
+		photosByType := map[types.PhotoType][]Photo{}
+		for _, photo := range participant.Photos {
+			photosByType[photo.Type] = append(photosByType[photo.Type], photo)
+		}
+		paymentPhotos = append(paymentPhotos, photosByType[types.BuktiPembayaran]...)
+
+		educationLevel := participant.EducationLevel
+		if educationLevel == "" {
+			educationLevel = team.EducationLevel
+		}
+		for _, photoType := range educationLevel.GetRequiredPhotoTypes() {
+			memberChecklist.Items = append(memberChecklist.Items, ChecklistItem{Key: ChecklistPhoto, Detail: string(photoType), Status: getPhotoChecklistStatus(photosByType[photoType])})
+		}
+
+		emailStatus := types.ChecklistIncomplete
+		if !participant.EmailConfirmedAt.IsZero() {
+			emailStatus = types.ChecklistComplete
+		}
+		memberChecklist.Items = append(memberChecklist.Items, ChecklistItem{Key: ChecklistEmailConfirmation, Status: emailStatus})
+
+		teamChecklist.Members = append(teamChecklist.Members, memberChecklist)
+	}
+
+	// Pembayaran dihitung per enrolment, enrolment yang invoice-nya telah dilunasi melalui payment gateway tercatat disetujui
+	// sedangkan jenis lomba tanpa invoice tetap menggunakan bukti pembayaran manual
+	enrolmentIDs := []uint{}
+	for _, enrolment := range team.Enrolments {
+		enrolmentIDs = append(enrolmentIDs, enrolment.ID)
+	}
+	invoices, err := GetLatestInvoices(tx, enrolmentIDs)
+	if err != nil {
+		return TeamChecklist{}, err
+	}
+	for _, enrolment := range team.Enrolments {
+		payment := ChecklistItem{Key: ChecklistPayment, Detail: string(enrolment.TeamCategory), Status: getPhotoChecklistStatus(paymentPhotos)}
+		if !enrolment.PaymentApprovedAt.IsZero() {
+			payment.Status = types.ChecklistApproved
+		} else if invoice, ok := invoices[enrolment.ID]; ok && invoice.Status == types.InvoicePending && payment.Status != types.ChecklistApproved {
+			payment.Status = types.ChecklistPending
+		}
+		teamChecklist.Items = append(teamChecklist.Items, payment)
+	}
+
+	teamSize := ChecklistItem{Key: ChecklistTeamSize, Status: types.ChecklistComplete}
+	if err := ValidateComposition(tx, teamID); err != nil {
+		compositionError := &CompositionError{}
+		if !errors.As(err, &compositionError) {
+			return TeamChecklist{}, err
+		}
+		teamSize.Status = types.ChecklistIncomplete
+		teamSize.Message = compositionError.Error()
+	}
+	teamChecklist.Items = append(teamChecklist.Items, teamSize)
+
+	// Submission hanya diwajibkan apabila stage saat ini memiliki jendela waktu pengumpulan
+	for _, enrolment := range team.Enrolments {
+		stage := enrolment.GetCurrentStage()
+		submission := ChecklistItem{Key: ChecklistSubmission, Detail: string(enrolment.TeamCategory), Status: types.ChecklistNotRequired}
+
+		conditionWindow := SubmissionWindow{TeamCategory: enrolment.TeamCategory, Stage: stage}
+		submissionWindow := SubmissionWindow{}
+		if err := tx.Where(&conditionWindow).Find(&submissionWindow).Error; err != nil {
+			return TeamChecklist{}, err
+		}
+
+		if submissionWindow.ID != 0 && enrolment.Status != types.Eliminated {
+			var count int64
+			conditionSubmission := Submission{EnrolmentID: enrolment.ID, Stage: stage, IsFinal: true}
+			if err := tx.Model(&Submission{}).Where(&conditionSubmission).Count(&count).Error; err != nil {
+				return TeamChecklist{}, err
+			}
+
+			submission.Status = types.ChecklistIncomplete
+			submission.Message = fmt.Sprintf("NO FINAL SUBMISSION FOR %s", string(stage))
+			if count > 0 {
+				submission.Status = types.ChecklistComplete
+				submission.Message = ""
+			}
+		}
+		teamChecklist.Items = append(teamChecklist.Items, submission)
+	}
+
+	incomplete := map[string]bool{}
+	for _, item := range teamChecklist.Items {
+		if !item.Status.IsDone() {
+			incomplete[item.Key] = true
+		}
+	}
+	for _, memberChecklist := range teamChecklist.Members {
+		for _, item := range memberChecklist.Items {
+			if !item.Status.IsDone() {
+				incomplete[item.Key] = true
+			}
+		}
+	}
+	for _, key := range []string{ChecklistPhoto, ChecklistEmailConfirmation, ChecklistPayment, ChecklistTeamSize, ChecklistSubmission} {
+		if incomplete[key] {
+			teamChecklist.Incomplete = append(teamChecklist.Incomplete, key)
+		}
+	}
+	teamChecklist.IsComplete = len(teamChecklist.Incomplete) == 0
+
+	return teamChecklist, nil
+}
@@ -0,0 +1,126 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type CompositionViolation struct {
+	Rule          string `json:"rule,omitempty"`
+	Message       string `json:"message,omitempty"`
+	ParticipantID uint   `json:"participant_id,omitempty"`
+}
+
+// Seluruh pelanggaran aturan komposisi dikumpulkan agar dapat dikembalikan sekaligus kepada team
+type CompositionError struct {
+	Violations []CompositionViolation
+}
+
+func (compositionError *CompositionError) Error() string {
+	messages := []string{}
+	for _, violation := range compositionError.Violations {
+		messages = append(messages, violation.Message)
+	}
+
+	return fmt.Sprintf("ERROR: TEAM COMPOSITION VIOLATED (%s)", strings.Join(messages, "; "))
+}
+
+// Komposisi team dicek setelah perubahan membership di dalam transaksi yang sama,
+// team yang belum mendaftar jenis lomba hanya dibatasi jumlah leader
+func ValidateComposition(tx *gorm.DB, teamID uint) error {
+	conditionEnrolment := Enrolment{TeamID: teamID}
+	enrolments := []Enrolment{}
+	if err := tx.Where(&conditionEnrolment).Find(&enrolments).Error; err != nil {
+		return err
+	}
+
+	conditionMembership := Membership{TeamID: teamID}
+	memberships := []Membership{}
+	if err := tx.Where(&conditionMembership).Find(&memberships).Error; err != nil {
+		return err
+	}
+
+	activeMembers := 0
+	activeLeaders := 0
+	leaders := 0
+	for _, membership := range memberships {
+		if membership.Role == types.Leader {
+			leaders++
+		}
+		if membership.Status == types.ActiveMembership {
+			activeMembers++
+			if membership.Role == types.Leader {
+				activeLeaders++
+			}
+		}
+	}
+
+	violations := []CompositionViolation{}
+	if leaders > 1 {
+		violations = append(violations, CompositionViolation{Rule: "leader", Message: "TEAM MUST HAVE EXACTLY ONE LEADER"})
+	}
+
+	// Team yang mendaftar beberapa jenis lomba harus memenuhi aturan komposisi seluruh jenis lomba tersebut
+	hasLeaderViolation := leaders > 1
+	maxCategories := 0
+	for _, enrolment := range enrolments {
+		rule, exists := enrolment.TeamCategory.GetCompositionRule()
+		if !exists {
+			continue
+		}
+		if maxCategories == 0 || rule.MaxCategories < maxCategories {
+			maxCategories = rule.MaxCategories
+		}
+
+		if activeLeaders != 1 && !hasLeaderViolation {
+			hasLeaderViolation = true
+			violations = append(violations, CompositionViolation{Rule: "leader", Message: "TEAM MUST HAVE EXACTLY ONE LEADER"})
+		}
+		if activeMembers < rule.MinMembers {
+			violations = append(violations, CompositionViolation{Rule: "min-members", Message: fmt.Sprintf("%s TEAM MUST HAVE AT LEAST %d MEMBERS", string(enrolment.TeamCategory), rule.MinMembers)})
+		}
+		// Undangan yang belum dijawab tetap menempati slot anggota
+		if len(memberships) > rule.MaxMembers {
+			violations = append(violations, CompositionViolation{Rule: "max-members", Message: fmt.Sprintf("%s TEAM MUST HAVE AT MOST %d MEMBERS", string(enrolment.TeamCategory), rule.MaxMembers)})
+		}
+	}
+
+	if maxCategories > 0 {
+		for _, membership := range memberships {
+			categories, err := getParticipantCategories(tx, membership.ParticipantID)
+			if err != nil {
+				return err
+			}
+			if len(categories) > maxCategories {
+				violations = append(violations, CompositionViolation{Rule: "max-categories", Message: fmt.Sprintf("PARTICIPANT MUST JOIN AT MOST %d CATEGORIES", maxCategories), ParticipantID: membership.ParticipantID})
+			}
+		}
+	}
+
+	if len(violations) > 0 {
+		return &CompositionError{Violations: violations}
+	}
+
+	return nil
+}
+
+func getParticipantCategories(tx *gorm.DB, participantID uint) (map[types.TeamCategory]bool, error) {
+	condition := Membership{ParticipantID: participantID}
+	memberships := []Membership{}
+	if err := tx.Preload("Team.Enrolments").Where(&condition).Find(&memberships).Error; err != nil {
+		return nil, err
+	}
+
+	categories := map[types.TeamCategory]bool{}
+	for _, membership := range memberships {
+		for _, enrolment := range membership.Team.Enrolments {
+			categories[enrolment.TeamCategory] = true
+		}
+	}
+
+	return categories, nil
+}
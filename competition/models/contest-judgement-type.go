@@ -0,0 +1,55 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ContestJudgementType struct {
+	gorm.Model
+	ContestID  uint    `gorm:"not null;uniqueIndex:contest_judgement_type_index"`
+	ExternalID string  `gorm:"not null;uniqueIndex:contest_judgement_type_index"`
+	Name       string  `gorm:"not null"`
+	IsPenalty  bool    `gorm:"not null;default:false"`
+	IsSolved   bool    `gorm:"not null;default:false"`
+	Contest    Contest `gorm:"foreignKey:ContestID;references:ID"`
+}
+
+type DisplayContestJudgementType struct {
+	ID         uint      `json:"id,omitempty"`
+	CreatedAt  time.Time `json:"created_at,omitempty"`
+	UpdatedAt  time.Time `json:"updated_at,omitempty"`
+	ContestID  uint      `json:"contest_id,omitempty"`
+	ExternalID string    `json:"external_id,omitempty"`
+	Name       string    `json:"name,omitempty"`
+	IsPenalty  bool      `json:"is_penalty"`
+	IsSolved   bool      `json:"is_solved"`
+}
+
+func (contestJudgementType ContestJudgementType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&DisplayContestJudgementType{
+		ID:         contestJudgementType.ID,
+		CreatedAt:  contestJudgementType.CreatedAt,
+		UpdatedAt:  contestJudgementType.UpdatedAt,
+		ContestID:  contestJudgementType.ContestID,
+		ExternalID: contestJudgementType.ExternalID,
+		Name:       contestJudgementType.Name,
+		IsPenalty:  contestJudgementType.IsPenalty,
+		IsSolved:   contestJudgementType.IsSolved,
+	})
+}
+
+// Digunakan apabila event feed tidak menyertakan judgement types
+// REFERENCE: https://ccs-specs.icpc.io/2021-11/contest_api#known-judgement-types
+func GetDefaultJudgementType(externalID string) ContestJudgementType {
+	switch externalID {
+	case "AC":
+		return ContestJudgementType{ExternalID: externalID, IsSolved: true}
+	case "CE", "JE":
+		return ContestJudgementType{ExternalID: externalID}
+	default:
+		return ContestJudgementType{ExternalID: externalID, IsPenalty: true}
+	}
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ContestProblem struct {
+	gorm.Model
+	ContestID  uint    `gorm:"not null;uniqueIndex:contest_problem_index"`
+	ExternalID string  `gorm:"not null;uniqueIndex:contest_problem_index"`
+	Label      string  `gorm:"not null"`
+	Name       string  `gorm:"not null"`
+	Ordinal    int     `gorm:"not null;default:0"`
+	Contest    Contest `gorm:"foreignKey:ContestID;references:ID"`
+}
+
+type DisplayContestProblem struct {
+	ID         uint      `json:"id,omitempty"`
+	CreatedAt  time.Time `json:"created_at,omitempty"`
+	UpdatedAt  time.Time `json:"updated_at,omitempty"`
+	ContestID  uint      `json:"contest_id,omitempty"`
+	ExternalID string    `json:"external_id,omitempty"`
+	Label      string    `json:"label,omitempty"`
+	Name       string    `json:"name,omitempty"`
+	Ordinal    int       `json:"ordinal"`
+}
+
+func (contestProblem ContestProblem) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&DisplayContestProblem{
+		ID:         contestProblem.ID,
+		CreatedAt:  contestProblem.CreatedAt,
+		UpdatedAt:  contestProblem.UpdatedAt,
+		ContestID:  contestProblem.ContestID,
+		ExternalID: contestProblem.ExternalID,
+		Label:      contestProblem.Label,
+		Name:       contestProblem.Name,
+		Ordinal:    contestProblem.Ordinal,
+	})
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ContestSubmission struct {
+	gorm.Model
+	ContestID         uint          `gorm:"not null;uniqueIndex:contest_submission_index"`
+	ExternalID        string        `gorm:"not null;uniqueIndex:contest_submission_index"`
+	TeamExternalID    string        `gorm:"not null"`
+	ProblemExternalID string        `gorm:"not null"`
+	ContestTime       time.Duration `gorm:"not null"`
+	JudgementTypeID   *string       `gorm:"default:null"`
+	Contest           Contest       `gorm:"foreignKey:ContestID;references:ID"`
+}
+
+type DisplayContestSubmission struct {
+	ID                uint      `json:"id,omitempty"`
+	CreatedAt         time.Time `json:"created_at,omitempty"`
+	UpdatedAt         time.Time `json:"updated_at,omitempty"`
+	ContestID         uint      `json:"contest_id,omitempty"`
+	ExternalID        string    `json:"external_id,omitempty"`
+	TeamExternalID    string    `json:"team_external_id,omitempty"`
+	ProblemExternalID string    `json:"problem_external_id,omitempty"`
+	ContestTime       string    `json:"contest_time,omitempty"`
+	JudgementTypeID   *string   `json:"judgement_type_id,omitempty"`
+}
+
+func (contestSubmission ContestSubmission) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&DisplayContestSubmission{
+		ID:                contestSubmission.ID,
+		CreatedAt:         contestSubmission.CreatedAt,
+		UpdatedAt:         contestSubmission.UpdatedAt,
+		ContestID:         contestSubmission.ContestID,
+		ExternalID:        contestSubmission.ExternalID,
+		TeamExternalID:    contestSubmission.TeamExternalID,
+		ProblemExternalID: contestSubmission.ProblemExternalID,
+		ContestTime:       contestSubmission.ContestTime.String(),
+		JudgementTypeID:   contestSubmission.JudgementTypeID,
+	})
+}
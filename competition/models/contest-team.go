@@ -0,0 +1,86 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type ContestTeam struct {
+	gorm.Model
+	ContestID  uint    `gorm:"not null;uniqueIndex:contest_team_index"`
+	ExternalID string  `gorm:"not null;uniqueIndex:contest_team_index"`
+	ICPCID     *string `gorm:"default:null"`
+	Name       string  `gorm:"not null"`
+	IsHidden   bool    `gorm:"not null;default:false"`
+	TeamID     *uint   `gorm:"default:null"`
+	Contest    Contest `gorm:"foreignKey:ContestID;references:ID"`
+	Team       *Team   `gorm:"foreignKey:TeamID;references:ID"`
+}
+
+type DisplayContestTeam struct {
+	ID         uint      `json:"id,omitempty"`
+	CreatedAt  time.Time `json:"created_at,omitempty"`
+	UpdatedAt  time.Time `json:"updated_at,omitempty"`
+	ContestID  uint      `json:"contest_id,omitempty"`
+	ExternalID string    `json:"external_id,omitempty"`
+	ICPCID     *string   `json:"icpc_id,omitempty"`
+	Name       string    `json:"name,omitempty"`
+	IsHidden   bool      `json:"is_hidden,omitempty"`
+	TeamID     *uint     `json:"team_id,omitempty"`
+}
+
+func (contestTeam ContestTeam) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&DisplayContestTeam{
+		ID:         contestTeam.ID,
+		CreatedAt:  contestTeam.CreatedAt,
+		UpdatedAt:  contestTeam.UpdatedAt,
+		ContestID:  contestTeam.ContestID,
+		ExternalID: contestTeam.ExternalID,
+		ICPCID:     contestTeam.ICPCID,
+		Name:       contestTeam.Name,
+		IsHidden:   contestTeam.IsHidden,
+		TeamID:     contestTeam.TeamID,
+	})
+}
+
+// Menambahkan constraint untuk mengecek apakah team yang dipetakan merupakan team competitive programming
+func (contestTeam *ContestTeam) BeforeSave(tx *gorm.DB) error {
+	if contestTeam.TeamID == nil {
+		return nil
+	}
+
+	if _, err := FindEnrolment(tx, *contestTeam.TeamID, types.CP); err != nil {
+		return fmt.Errorf("ERROR: TEAM IS NOT REGISTERED TO COMPETITIVE PROGRAMMING")
+	}
+
+	return nil
+}
+
+// Team pada event feed dipetakan secara otomatis berdasarkan username (icpc_id) atau nama team
+func (contestTeam *ContestTeam) AutoMap(tx *gorm.DB) error {
+	if contestTeam.TeamID != nil {
+		return nil
+	}
+
+	team := Team{}
+	query := tx.Joins("JOIN enrolments ON enrolments.team_id = teams.id AND enrolments.deleted_at IS NULL").Where("enrolments.team_category = ?", types.CP)
+	if contestTeam.ICPCID != nil {
+		query = query.Where("teams.username = ? OR LOWER(teams.team_name) = LOWER(?)", *contestTeam.ICPCID, contestTeam.Name)
+	} else {
+		query = query.Where("LOWER(teams.team_name) = LOWER(?)", contestTeam.Name)
+	}
+	if err := query.Limit(1).Find(&team).Error; err != nil {
+		return err
+	}
+
+	if team.ID != 0 {
+		contestTeam.TeamID = &team.ID
+	}
+
+	return nil
+}
@@ -0,0 +1,209 @@
+package models
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Contest struct {
+	gorm.Model
+	ExternalID     string        `gorm:"not null;unique"`
+	Name           string        `gorm:"not null"`
+	StartTime      *time.Time    `gorm:"default:null"`
+	Duration       time.Duration `gorm:"not null"`
+	FreezeDuration time.Duration `gorm:"not null;default:0"`
+	PenaltyTime    time.Duration `gorm:"not null"`
+	IsFrozen       bool          `gorm:"not null;default:false"`
+	Teams          []ContestTeam
+	Problems       []ContestProblem
+}
+
+type DisplayContest struct {
+	ID             uint       `json:"id,omitempty"`
+	CreatedAt      time.Time  `json:"created_at,omitempty"`
+	UpdatedAt      time.Time  `json:"updated_at,omitempty"`
+	ExternalID     string     `json:"external_id,omitempty"`
+	Name           string     `json:"name,omitempty"`
+	StartTime      *time.Time `json:"start_time,omitempty"`
+	Duration       string     `json:"duration,omitempty"`
+	FreezeDuration string     `json:"freeze_duration,omitempty"`
+	PenaltyTime    string     `json:"penalty_time,omitempty"`
+	IsFrozen       bool       `json:"is_frozen"`
+}
+
+func (contest Contest) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&DisplayContest{
+		ID:             contest.ID,
+		CreatedAt:      contest.CreatedAt,
+		UpdatedAt:      contest.UpdatedAt,
+		ExternalID:     contest.ExternalID,
+		Name:           contest.Name,
+		StartTime:      contest.StartTime,
+		Duration:       contest.Duration.String(),
+		FreezeDuration: contest.FreezeDuration.String(),
+		PenaltyTime:    contest.PenaltyTime.String(),
+		IsFrozen:       contest.IsFrozen,
+	})
+}
+
+// Submission yang masuk setelah waktu freeze tidak ditampilkan hasilnya pada scoreboard publik
+func (contest Contest) IsFrozenAt(contestTime time.Duration) bool {
+	return contest.IsFrozen && contest.FreezeDuration > 0 && contestTime >= contest.Duration-contest.FreezeDuration
+}
+
+type ScoreboardProblem struct {
+	Label          string `json:"label"`
+	NumJudged      int    `json:"num_judged"`
+	NumPending     int    `json:"num_pending"`
+	IsSolved       bool   `json:"is_solved"`
+	IsFirstToSolve bool   `json:"is_first_to_solve,omitempty"`
+	Time           int    `json:"time,omitempty"`
+}
+
+type ScoreboardRow struct {
+	Rank     int                 `json:"rank"`
+	TeamID   uint                `json:"team_id"`
+	TeamName string              `json:"team_name"`
+	Solved   int                 `json:"solved"`
+	Penalty  int                 `json:"penalty"`
+	Problems []ScoreboardProblem `json:"problems"`
+	lastTime int
+}
+
+// Scoreboard ICPC: diurutkan berdasarkan jumlah soal yang diselesaikan, total penalty (menit),
+// kemudian waktu penyelesaian soal terakhir. Submission setelah freeze dianggap pending apabila
+// scoreboard masih dibekukan dan isUnfrozen bernilai false
+func GetScoreboard(tx *gorm.DB, contest Contest, isUnfrozen bool) ([]ScoreboardRow, error) {
+	conditionProblem := ContestProblem{ContestID: contest.ID}
+	contestProblems := []ContestProblem{}
+	if err := tx.Where(&conditionProblem).Order("ordinal, label").Find(&contestProblems).Error; err != nil {
+		return nil, err
+	}
+
+	conditionJudgementType := ContestJudgementType{ContestID: contest.ID}
+	contestJudgementTypes := []ContestJudgementType{}
+	if err := tx.Where(&conditionJudgementType).Find(&contestJudgementTypes).Error; err != nil {
+		return nil, err
+	}
+
+	conditionTeam := ContestTeam{ContestID: contest.ID}
+	contestTeams := []ContestTeam{}
+	if err := tx.Preload("Team").Where(&conditionTeam).Where("team_id IS NOT NULL AND is_hidden = ?", false).Find(&contestTeams).Error; err != nil {
+		return nil, err
+	}
+
+	conditionSubmission := ContestSubmission{ContestID: contest.ID}
+	contestSubmissions := []ContestSubmission{}
+	if err := tx.Where(&conditionSubmission).Order("contest_time, id").Find(&contestSubmissions).Error; err != nil {
+		return nil, err
+	}
+
+	return RankScoreboard(contest, contestProblems, contestJudgementTypes, contestTeams, contestSubmissions, isUnfrozen), nil
+}
+
+// Menghitung scoreboard dari data contest yang telah dimuat, contestTeams hanya berisi team yang terhubung dan tidak disembunyikan
+func RankScoreboard(contest Contest, contestProblems []ContestProblem, contestJudgementTypes []ContestJudgementType, contestTeams []ContestTeam, contestSubmissions []ContestSubmission, isUnfrozen bool) []ScoreboardRow {
+	judgementTypes := map[string]ContestJudgementType{}
+	for _, contestJudgementType := range contestJudgementTypes {
+		judgementTypes[contestJudgementType.ExternalID] = contestJudgementType
+	}
+
+	problemIndexes := map[string]int{}
+	for i, contestProblem := range contestProblems {
+		problemIndexes[contestProblem.ExternalID] = i
+	}
+
+	rows := map[string]*ScoreboardRow{}
+	for _, contestTeam := range contestTeams {
+		row := &ScoreboardRow{TeamID: *contestTeam.TeamID, TeamName: contestTeam.Team.TeamName, Problems: []ScoreboardProblem{}}
+		for _, contestProblem := range contestProblems {
+			row.Problems = append(row.Problems, ScoreboardProblem{Label: contestProblem.Label})
+		}
+		rows[contestTeam.ExternalID] = row
+	}
+
+	penaltyAttempts := map[*ScoreboardProblem]int{}
+	for _, contestSubmission := range contestSubmissions {
+		row, exists := rows[contestSubmission.TeamExternalID]
+		if !exists {
+			continue
+		}
+		index, exists := problemIndexes[contestSubmission.ProblemExternalID]
+		if !exists {
+			continue
+		}
+		if contestSubmission.ContestTime < 0 || contestSubmission.ContestTime >= contest.Duration {
+			continue
+		}
+
+		problem := &row.Problems[index]
+		if problem.IsSolved {
+			continue
+		}
+		if (!isUnfrozen && contest.IsFrozenAt(contestSubmission.ContestTime)) || contestSubmission.JudgementTypeID == nil {
+			problem.NumPending++
+			continue
+		}
+
+		judgementType, exists := judgementTypes[*contestSubmission.JudgementTypeID]
+		if !exists {
+			judgementType = GetDefaultJudgementType(*contestSubmission.JudgementTypeID)
+		}
+
+		problem.NumJudged++
+		if judgementType.IsSolved {
+			problem.IsSolved = true
+			problem.Time = int(contestSubmission.ContestTime / time.Minute)
+			row.Solved++
+			row.Penalty += problem.Time + penaltyAttempts[problem]*int(contest.PenaltyTime/time.Minute)
+			row.lastTime = problem.Time
+		} else if judgementType.IsPenalty {
+			penaltyAttempts[problem]++
+		}
+	}
+
+	scoreboard := []ScoreboardRow{}
+	for _, row := range rows {
+		scoreboard = append(scoreboard, *row)
+	}
+
+	sort.SliceStable(scoreboard, func(i, j int) bool {
+		if scoreboard[i].Solved != scoreboard[j].Solved {
+			return scoreboard[i].Solved > scoreboard[j].Solved
+		}
+		if scoreboard[i].Penalty != scoreboard[j].Penalty {
+			return scoreboard[i].Penalty < scoreboard[j].Penalty
+		}
+		if scoreboard[i].lastTime != scoreboard[j].lastTime {
+			return scoreboard[i].lastTime < scoreboard[j].lastTime
+		}
+		return scoreboard[i].TeamName < scoreboard[j].TeamName
+	})
+
+	// Team dengan solved, penalty, dan waktu terakhir yang sama mendapatkan peringkat yang sama
+	for i := range scoreboard {
+		scoreboard[i].Rank = i + 1
+		if i > 0 && scoreboard[i].Solved == scoreboard[i-1].Solved && scoreboard[i].Penalty == scoreboard[i-1].Penalty && scoreboard[i].lastTime == scoreboard[i-1].lastTime {
+			scoreboard[i].Rank = scoreboard[i-1].Rank
+		}
+	}
+
+	for index := range contestProblems {
+		firstTime := -1
+		for _, row := range scoreboard {
+			if row.Problems[index].IsSolved && (firstTime == -1 || row.Problems[index].Time < firstTime) {
+				firstTime = row.Problems[index].Time
+			}
+		}
+		for _, row := range scoreboard {
+			if row.Problems[index].IsSolved && row.Problems[index].Time == firstTime {
+				row.Problems[index].IsFirstToSolve = true
+			}
+		}
+	}
+
+	return scoreboard
+}
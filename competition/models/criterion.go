@@ -0,0 +1,52 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Criterion struct {
+	gorm.Model
+	RubricID    uint    `gorm:"not null"`
+	Name        string  `gorm:"not null"`
+	Description string  `gorm:"default:null"`
+	Weight      float64 `gorm:"not null"`
+	MaxScore    float64 `gorm:"not null"`
+	Rubric      Rubric  `gorm:"foreignKey:RubricID;references:ID"`
+}
+
+type DisplayCriterion struct {
+	ID          uint      `json:"id,omitempty"`
+	CreatedAt   time.Time `json:"created_at,omitempty"`
+	UpdatedAt   time.Time `json:"updated_at,omitempty"`
+	RubricID    uint      `json:"rubric_id,omitempty"`
+	Name        string    `json:"name,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Weight      float64   `json:"weight,omitempty"`
+	MaxScore    float64   `json:"max_score,omitempty"`
+}
+
+func (criterion Criterion) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&DisplayCriterion{
+		ID:          criterion.ID,
+		CreatedAt:   criterion.CreatedAt,
+		UpdatedAt:   criterion.UpdatedAt,
+		RubricID:    criterion.RubricID,
+		Name:        criterion.Name,
+		Description: criterion.Description,
+		Weight:      criterion.Weight,
+		MaxScore:    criterion.MaxScore,
+	})
+}
+
+// Menambahkan constraint untuk mengecek apakah bobot dan nilai maksimum kriteria bernilai positif
+func (criterion *Criterion) BeforeSave(tx *gorm.DB) error {
+	if criterion.Weight <= 0 || criterion.MaxScore <= 0 {
+		return fmt.Errorf("ERROR: INVALID CRITERION")
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package models
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type DatavidiaScore struct {
+	gorm.Model
+	SubmissionID  uint                `gorm:"not null;unique"`
+	GroundTruthID uint                `gorm:"not null"`
+	Metric        types.ScoringMetric `gorm:"not null"`
+	PublicScore   float64             `gorm:"not null"`
+	PrivateScore  float64             `gorm:"not null"`
+	Submission    Submission          `gorm:"foreignKey:SubmissionID;references:ID"`
+	GroundTruth   GroundTruth         `gorm:"foreignKey:GroundTruthID;references:ID"`
+}
+
+// NOTE: Nilai private tidak pernah ditampilkan melalui model ini, hanya melalui leaderboard private yang telah dibuka
+type DisplayDatavidiaScore struct {
+	ID            uint                `json:"id,omitempty"`
+	CreatedAt     time.Time           `json:"created_at,omitempty"`
+	UpdatedAt     time.Time           `json:"updated_at,omitempty"`
+	SubmissionID  uint                `json:"submission_id,omitempty"`
+	GroundTruthID uint                `json:"ground_truth_id,omitempty"`
+	Metric        types.ScoringMetric `json:"metric,omitempty"`
+	PublicScore   float64             `json:"public_score"`
+}
+
+func (datavidiaScore DatavidiaScore) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&DisplayDatavidiaScore{
+		ID:            datavidiaScore.ID,
+		CreatedAt:     datavidiaScore.CreatedAt,
+		UpdatedAt:     datavidiaScore.UpdatedAt,
+		SubmissionID:  datavidiaScore.SubmissionID,
+		GroundTruthID: datavidiaScore.GroundTruthID,
+		Metric:        datavidiaScore.Metric,
+		PublicScore:   datavidiaScore.PublicScore,
+	})
+}
+
+type LeaderboardEntry struct {
+	Rank               int       `json:"rank"`
+	TeamID             uint      `json:"team_id"`
+	TeamName           string    `json:"team_name"`
+	SubmissionID       uint      `json:"submission_id"`
+	SubmittedAt        time.Time `json:"submitted_at"`
+	Score              float64   `json:"score"`
+	NumberOfSubmission int       `json:"number_of_submission"`
+}
+
+// Leaderboard public menggunakan nilai public terbaik setiap team, sedangkan leaderboard private
+// menggunakan nilai private dari submission final setiap team
+func GetLeaderboard(tx *gorm.DB, groundTruth GroundTruth, isPrivate bool) ([]LeaderboardEntry, error) {
+	datavidiaScores := []DatavidiaScore{}
+	if err := tx.Preload("Submission.Team").Joins("JOIN submissions ON submissions.id = datavidia_scores.submission_id AND submissions.deleted_at IS NULL").Where("datavidia_scores.ground_truth_id = ?", groundTruth.ID).Find(&datavidiaScores).Error; err != nil {
+		return nil, err
+	}
+
+	isBetter := func(a float64, b float64) bool {
+		if groundTruth.Metric.IsHigherBetter() {
+			return a > b
+		}
+		return a < b
+	}
+
+	entries := map[uint]*LeaderboardEntry{}
+	for _, datavidiaScore := range datavidiaScores {
+		submission := datavidiaScore.Submission
+		entry, exists := entries[submission.TeamID]
+		if !exists {
+			entry = &LeaderboardEntry{TeamID: submission.TeamID, TeamName: submission.Team.TeamName}
+			entries[submission.TeamID] = entry
+		}
+
+		entry.NumberOfSubmission++
+		if isPrivate {
+			if submission.IsFinal {
+				entry.SubmissionID = submission.ID
+				entry.SubmittedAt = submission.CreatedAt
+				entry.Score = datavidiaScore.PrivateScore
+			}
+		} else if entry.SubmissionID == 0 || isBetter(datavidiaScore.PublicScore, entry.Score) {
+			entry.SubmissionID = submission.ID
+			entry.SubmittedAt = submission.CreatedAt
+			entry.Score = datavidiaScore.PublicScore
+		}
+	}
+
+	leaderboard := []LeaderboardEntry{}
+	for _, entry := range entries {
+		if entry.SubmissionID != 0 {
+			leaderboard = append(leaderboard, *entry)
+		}
+	}
+
+	sort.SliceStable(leaderboard, func(i, j int) bool {
+		if leaderboard[i].Score == leaderboard[j].Score {
+			return leaderboard[i].SubmittedAt.Before(leaderboard[j].SubmittedAt)
+		}
+		return isBetter(leaderboard[i].Score, leaderboard[j].Score)
+	})
+	for i := range leaderboard {
+		leaderboard[i].Rank = i + 1
+	}
+
+	return leaderboard, nil
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type DeadlineExtension struct {
+	gorm.Model
+	TeamID       uint                  `gorm:"not null;uniqueIndex:deadline_extension_index"`
+	TeamCategory types.TeamCategory    `gorm:"not null;uniqueIndex:deadline_extension_index"`
+	Stage        types.SubmissionStage `gorm:"not null;uniqueIndex:deadline_extension_index"`
+	CloseAt      time.Time             `gorm:"not null"`
+	AdminID      uint                  `gorm:"not null"`
+	Team         Team                  `gorm:"foreignKey:TeamID;references:ID"`
+	GrantedBy    Admin                 `gorm:"foreignKey:AdminID;references:ID"`
+}
+
+type DisplayDeadlineExtension struct {
+	ID           uint                  `json:"id,omitempty"`
+	CreatedAt    time.Time             `json:"created_at,omitempty"`
+	UpdatedAt    time.Time             `json:"updated_at,omitempty"`
+	TeamID       uint                  `json:"team_id,omitempty"`
+	TeamCategory types.TeamCategory    `json:"team_category,omitempty"`
+	Stage        types.SubmissionStage `json:"stage,omitempty"`
+	CloseAt      time.Time             `json:"close_at,omitempty"`
+	AdminID      uint                  `json:"admin_id,omitempty"`
+}
+
+func (deadlineExtension DeadlineExtension) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&DisplayDeadlineExtension{
+		ID:           deadlineExtension.ID,
+		CreatedAt:    deadlineExtension.CreatedAt,
+		UpdatedAt:    deadlineExtension.UpdatedAt,
+		TeamID:       deadlineExtension.TeamID,
+		TeamCategory: deadlineExtension.TeamCategory,
+		Stage:        deadlineExtension.Stage,
+		CloseAt:      deadlineExtension.CloseAt,
+		AdminID:      deadlineExtension.AdminID,
+	})
+}
@@ -0,0 +1,165 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type DeletionRequest struct {
+	gorm.Model
+	ParticipantID uint                        `gorm:"not null"`
+	TeamID        uint                        `gorm:"default:null"`
+	Reason        string                      `gorm:"default:null"`
+	Status        types.DeletionRequestStatus `gorm:"not null;default:'pending'"`
+	AdminID       uint                        `gorm:"default:null"`
+	ProcessedAt   time.Time                   `gorm:"default:null"`
+	Participant   Participant                 `gorm:"foreignKey:ParticipantID;references:ID"`
+	RequestedBy   Team                        `gorm:"foreignKey:TeamID;references:ID"`
+	ProcessedBy   Admin                       `gorm:"foreignKey:AdminID;references:ID"`
+}
+
+type DisplayDeletionRequest struct {
+	ID            uint                        `json:"id,omitempty"`
+	CreatedAt     time.Time                   `json:"created_at,omitempty"`
+	UpdatedAt     time.Time                   `json:"updated_at,omitempty"`
+	ParticipantID uint                        `json:"participant_id,omitempty"`
+	TeamID        uint                        `json:"team_id,omitempty"`
+	Reason        string                      `json:"reason,omitempty"`
+	Status        types.DeletionRequestStatus `json:"status,omitempty"`
+	AdminID       uint                        `json:"admin_id,omitempty"`
+	ProcessedAt   *time.Time                  `json:"processed_at,omitempty"`
+}
+
+func (deletionRequest DeletionRequest) MarshalJSON() ([]byte, error) {
+	var processedAt *time.Time
+	if !deletionRequest.ProcessedAt.IsZero() {
+		processedAt = &deletionRequest.ProcessedAt
+	}
+
+	return json.Marshal(&DisplayDeletionRequest{
+		ID:            deletionRequest.ID,
+		CreatedAt:     deletionRequest.CreatedAt,
+		UpdatedAt:     deletionRequest.UpdatedAt,
+		ParticipantID: deletionRequest.ParticipantID,
+		TeamID:        deletionRequest.TeamID,
+		Reason:        deletionRequest.Reason,
+		Status:        deletionRequest.Status,
+		AdminID:       deletionRequest.AdminID,
+		ProcessedAt:   processedAt,
+	})
+}
+
+// Menambahkan constraint untuk mengecek apakah participant telah dianonimkan
+// atau masih memiliki permintaan penghapusan yang belum diproses
+func (deletionRequest *DeletionRequest) BeforeCreate(tx *gorm.DB) error {
+	conditionParticipant := Participant{Model: gorm.Model{ID: deletionRequest.ParticipantID}}
+	participant := Participant{}
+	if err := tx.Where(&conditionParticipant).First(&participant).Error; err != nil {
+		return err
+	}
+	if !participant.AnonymisedAt.IsZero() {
+		return fmt.Errorf("ERROR: PARTICIPANT ALREADY ANONYMISED")
+	}
+
+	var count int64
+	condition := DeletionRequest{ParticipantID: deletionRequest.ParticipantID, Status: types.DeletionPending}
+	if err := tx.Model(&DeletionRequest{}).Where(&condition).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("ERROR: DELETION REQUEST ALREADY SUBMITTED")
+	}
+
+	return nil
+}
+
+// Memproses permintaan penghapusan, permintaan yang dikonfirmasi admin akan menganonimkan participant
+// dan mengembalikan foto yang berkasnya perlu dihapus dari storage setelah transaksi berhasil
+func ProcessDeletionRequest(tx *gorm.DB, deletionRequestID uint, status types.DeletionRequestStatus, adminID uint) (DeletionRequest, []Photo, error) {
+	condition := DeletionRequest{Model: gorm.Model{ID: deletionRequestID}}
+	deletionRequest := DeletionRequest{}
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(&condition).First(&deletionRequest).Error; err != nil {
+		return DeletionRequest{}, nil, err
+	}
+	if deletionRequest.Status != types.DeletionPending {
+		return DeletionRequest{}, nil, fmt.Errorf("ERROR: DELETION REQUEST ALREADY PROCESSED")
+	}
+
+	photos := []Photo{}
+	if status == types.DeletionConfirmed {
+		var err error
+		photos, err = AnonymiseParticipant(tx, deletionRequest.ParticipantID)
+		if err != nil {
+			return DeletionRequest{}, nil, err
+		}
+	}
+
+	deletionRequest.Status = status
+	deletionRequest.AdminID = adminID
+	deletionRequest.ProcessedAt = tx.NowFunc()
+	if err := tx.Model(&DeletionRequest{}).Where(&condition).Updates(map[string]interface{}{"status": status, "admin_id": adminID, "processed_at": deletionRequest.ProcessedAt}).Error; err != nil {
+		return DeletionRequest{}, nil, err
+	}
+
+	return deletionRequest, photos, nil
+}
+
+// Data pribadi participant dihapus namun record tetap dipertahankan agar riwayat team dan submission tetap utuh,
+// kunci enkripsi foto dibuang dan seluruh foto participant dikembalikan agar berkasnya dihapus dari storage
+func AnonymiseParticipant(tx *gorm.DB, participantID uint) ([]Photo, error) {
+	condition := Participant{Model: gorm.Model{ID: participantID}}
+	participant := Participant{}
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(&condition).First(&participant).Error; err != nil {
+		return nil, err
+	}
+	if !participant.AnonymisedAt.IsZero() {
+		return nil, fmt.Errorf("ERROR: PARTICIPANT ALREADY ANONYMISED")
+	}
+
+	anonymisedEmail := fmt.Sprintf("deleted-%d@anonymised.invalid", participantID)
+	updates := map[string]interface{}{
+		"name":               fmt.Sprintf("Deleted Participant %d", participantID),
+		"email":              anonymisedEmail,
+		"career_interest":    gorm.Expr("'{}'"),
+		"institution":        nil,
+		"education_level":    nil,
+		"student_id_number":  nil,
+		"graduation_year":    nil,
+		"phone":              nil,
+		"email_confirmed_at": nil,
+		"anonymised_at":      tx.NowFunc(),
+	}
+	if err := tx.Model(&Participant{}).Where(&condition).Updates(updates).Error; err != nil {
+		return nil, err
+	}
+
+	conditionCertificate := Certificate{ParticipantID: participantID}
+	if err := tx.Model(&Certificate{}).Where(&conditionCertificate).Update("participant_name", updates["name"]).Error; err != nil {
+		return nil, err
+	}
+
+	// Foto yang telah dihapus sebelumnya tetap disertakan karena berkasnya masih tersimpan di storage
+	conditionPhoto := Photo{ParticipantID: participantID}
+	photos := []Photo{}
+	if err := tx.Unscoped().Where(&conditionPhoto).Find(&photos).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Unscoped().Model(&Photo{}).Where(&conditionPhoto).Update("wrapped_key", nil).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Where(&conditionPhoto).Delete(&Photo{}).Error; err != nil {
+		return nil, err
+	}
+
+	if err := tx.Model(&Invitation{}).Where("email = ?", participant.Email).Update("email", anonymisedEmail).Error; err != nil {
+		return nil, err
+	}
+
+	return photos, nil
+}
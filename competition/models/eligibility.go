@@ -0,0 +1,84 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type EligibilityReason struct {
+	Rule          string `json:"rule,omitempty"`
+	Message       string `json:"message,omitempty"`
+	ParticipantID uint   `json:"participant_id,omitempty"`
+}
+
+type EligibilityError struct {
+	Reasons []EligibilityReason
+}
+
+func (eligibilityError *EligibilityError) Error() string {
+	messages := []string{}
+	for _, reason := range eligibilityError.Reasons {
+		messages = append(messages, reason.Message)
+	}
+
+	return fmt.Sprintf("ERROR: TEAM IS NOT ELIGIBLE (%s)", strings.Join(messages, "; "))
+}
+
+// Kelengkapan profil team dan seluruh anggota aktif dicek terhadap jenjang pendidikan jenis lomba yang didaftarkan,
+// anggota yang telah lulus sebelum tahun berjalan tidak lagi dianggap sebagai pelajar atau mahasiswa
+func CheckEligibility(tx *gorm.DB, teamID uint, teamCategory types.TeamCategory) error {
+	conditionTeam := Team{Model: gorm.Model{ID: teamID}}
+	team := Team{}
+	if err := tx.Where(&conditionTeam).First(&team).Error; err != nil {
+		return err
+	}
+
+	educationLevel, exists := teamCategory.GetEligibleEducationLevel()
+	if !exists {
+		return nil
+	}
+
+	reasons := []EligibilityReason{}
+	if team.Institution == "" {
+		reasons = append(reasons, EligibilityReason{Rule: "institution", Message: "TEAM INSTITUTION IS REQUIRED"})
+	}
+	if team.EducationLevel != educationLevel {
+		reasons = append(reasons, EligibilityReason{Rule: "education-level", Message: fmt.Sprintf("TEAM EDUCATION LEVEL MUST BE %s", strings.ToUpper(string(educationLevel)))})
+	}
+
+	conditionMembership := Membership{TeamID: teamID, Status: types.ActiveMembership}
+	memberships := []Membership{}
+	if err := tx.Preload("Participant").Where(&conditionMembership).Find(&memberships).Error; err != nil {
+		return err
+	}
+
+	currentYear := time.Now().Year()
+	for _, membership := range memberships {
+		participant := membership.Participant
+		if participant.EducationLevel != educationLevel {
+			reasons = append(reasons, EligibilityReason{Rule: "education-level", Message: fmt.Sprintf("MEMBER EDUCATION LEVEL MUST BE %s", strings.ToUpper(string(educationLevel))), ParticipantID: participant.ID})
+		}
+		if participant.StudentIDNumber == "" {
+			reasons = append(reasons, EligibilityReason{Rule: "student-id-number", Message: "MEMBER STUDENT ID NUMBER IS REQUIRED", ParticipantID: participant.ID})
+		}
+		if participant.GraduationYear == 0 {
+			reasons = append(reasons, EligibilityReason{Rule: "graduation-year", Message: "MEMBER GRADUATION YEAR IS REQUIRED", ParticipantID: participant.ID})
+		} else if participant.GraduationYear < currentYear {
+			reasons = append(reasons, EligibilityReason{Rule: "graduation-year", Message: "MEMBER HAS ALREADY GRADUATED", ParticipantID: participant.ID})
+		}
+		if membership.Role == types.Leader && participant.Phone == "" {
+			reasons = append(reasons, EligibilityReason{Rule: "phone", Message: "LEADER PHONE NUMBER IS REQUIRED", ParticipantID: participant.ID})
+		}
+	}
+
+	if len(reasons) > 0 {
+		return &EligibilityError{Reasons: reasons}
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type Enrolment struct {
+	gorm.Model
+	TeamID            uint                  `gorm:"not null;uniqueIndex:enrolment_index"`
+	TeamCategory      types.TeamCategory    `gorm:"not null;uniqueIndex:enrolment_index"`
+	Stage             types.SubmissionStage `gorm:"default:null"`
+	AdminID           uint                  `gorm:"default:null"`
+	Status            types.TeamStatus      `gorm:"not null"`
+	PaymentApprovedAt time.Time             `gorm:"default:null"`
+	Team              Team                  `gorm:"foreignKey:TeamID;references:ID"`
+	ApprovedBy        Admin                 `gorm:"foreignKey:AdminID;references:ID"`
+	Submissions       []Submission
+}
+
+type DisplayEnrolment struct {
+	ID                uint                  `json:"id,omitempty"`
+	CreatedAt         time.Time             `json:"created_at,omitempty"`
+	UpdatedAt         time.Time             `json:"updated_at,omitempty"`
+	TeamID            uint                  `json:"team_id,omitempty"`
+	TeamCategory      types.TeamCategory    `json:"team_category,omitempty"`
+	Stage             types.SubmissionStage `json:"stage,omitempty"`
+	AdminID           uint                  `json:"admin_id,omitempty"`
+	Status            types.TeamStatus      `json:"status,omitempty"`
+	PaymentApprovedAt *time.Time            `json:"payment_approved_at,omitempty"`
+	Submissions       []Submission          `json:"submissions,omitempty"`
+}
+
+func (enrolment Enrolment) MarshalJSON() ([]byte, error) {
+	var paymentApprovedAt *time.Time
+	if !enrolment.PaymentApprovedAt.IsZero() {
+		paymentApprovedAt = &enrolment.PaymentApprovedAt
+	}
+
+	return json.Marshal(&DisplayEnrolment{
+		ID:                enrolment.ID,
+		CreatedAt:         enrolment.CreatedAt,
+		UpdatedAt:         enrolment.UpdatedAt,
+		TeamID:            enrolment.TeamID,
+		TeamCategory:      enrolment.TeamCategory,
+		Stage:             enrolment.GetCurrentStage(),
+		AdminID:           enrolment.AdminID,
+		Status:            enrolment.Status,
+		PaymentApprovedAt: paymentApprovedAt,
+		Submissions:       enrolment.Submissions,
+	})
+}
+
+// Menambahkan constraint untuk mengecek apakah terdapat enrolment yang telah dievaluasi namun admin tidak tercatat
+// atau enrolment yang belum dievaluasi namun admin tercatat
+func (enrolment *Enrolment) BeforeSave(tx *gorm.DB) error {
+	if enrolment.Status != "" {
+		if enrolment.Status != types.WaitingForEvaluation && enrolment.AdminID == 0 {
+			return fmt.Errorf("ERROR: ADMIN MUST BE RECORDED")
+		}
+		if enrolment.Status == types.WaitingForEvaluation && enrolment.AdminID != 0 {
+			return fmt.Errorf("ERROR: STATUS MUST BE RECORDED")
+		}
+	}
+
+	return nil
+}
+
+// Menambahkan constraint untuk mengecek apakah team telah mendaftar jenis lomba yang sama
+// atau terdapat anggota team yang telah mengikuti jenis lomba yang sama melalui team lain
+func (enrolment *Enrolment) BeforeCreate(tx *gorm.DB) error {
+	// Enrolment yang telah ditarik hanya dapat dikembalikan oleh admin melalui pembatalan pengunduran diri
+	var withdrawn int64
+	condition := Enrolment{TeamID: enrolment.TeamID, TeamCategory: enrolment.TeamCategory}
+	if err := tx.Unscoped().Model(&Enrolment{}).Where(&condition).Count(&withdrawn).Error; err != nil {
+		return err
+	}
+	if withdrawn > 0 {
+		return fmt.Errorf("ERROR: TEAM ALREADY REGISTERED TO %s", string(enrolment.TeamCategory))
+	}
+
+	return CheckDuplicateParticipation(tx, enrolment.TeamID, enrolment.TeamCategory)
+}
+
+// Mengecek apakah terdapat anggota team yang telah mengikuti jenis lomba yang sama melalui team lain
+func CheckDuplicateParticipation(tx *gorm.DB, teamID uint, teamCategory types.TeamCategory) error {
+	var count int64
+	if err := tx.Model(&Membership{}).
+		Joins("JOIN memberships AS other_memberships ON other_memberships.participant_id = memberships.participant_id AND other_memberships.team_id <> memberships.team_id AND other_memberships.deleted_at IS NULL").
+		Joins("JOIN enrolments ON enrolments.team_id = other_memberships.team_id AND enrolments.deleted_at IS NULL").
+		Where("memberships.team_id = ? AND enrolments.team_category = ?", teamID, teamCategory).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("ERROR: CANNOT PARTICIPATE MORE THAN ONCE")
+	}
+
+	return nil
+}
+
+// Team yang masih berada pada waitlist jenis lomba yang sama dianggap telah dipromosikan
+func (enrolment *Enrolment) AfterCreate(tx *gorm.DB) error {
+	condition := WaitlistEntry{TeamID: enrolment.TeamID, TeamCategory: enrolment.TeamCategory, Status: types.WaitlistWaiting}
+	return tx.Model(&WaitlistEntry{}).Where(&condition).Updates(map[string]interface{}{"status": types.WaitlistPromoted, "enrolment_id": enrolment.ID, "promoted_at": tx.NowFunc()}).Error
+}
+
+// Enrolment yang belum memiliki stage tercatat dianggap berada pada stage pertama jenis lombanya
+func (enrolment Enrolment) GetCurrentStage() types.SubmissionStage {
+	if enrolment.Stage != "" {
+		return enrolment.Stage
+	}
+
+	pipeline := enrolment.TeamCategory.GetStagePipeline()
+	if len(pipeline) == 0 {
+		return ""
+	}
+
+	return pipeline[0]
+}
+
+// Persyaratan pembayaran enrolment disetujui otomatis ketika invoice-nya lunas
+func ApprovePayment(tx *gorm.DB, enrolmentID uint) error {
+	condition := Enrolment{Model: gorm.Model{ID: enrolmentID}}
+	return tx.Model(&Enrolment{}).Where(&condition).Where("payment_approved_at IS NULL").Update("payment_approved_at", tx.NowFunc()).Error
+}
+
+func FindEnrolment(tx *gorm.DB, teamID uint, teamCategory types.TeamCategory) (Enrolment, error) {
+	condition := Enrolment{TeamID: teamID, TeamCategory: teamCategory}
+	enrolment := Enrolment{}
+	if err := tx.Where(&condition).Find(&enrolment).Error; err != nil {
+		return Enrolment{}, err
+	}
+	if enrolment.ID == 0 {
+		return Enrolment{}, fmt.Errorf("ERROR: TEAM IS NOT REGISTERED TO %s", string(teamCategory))
+	}
+
+	return enrolment, nil
+}
@@ -0,0 +1,157 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type Exam struct {
+	gorm.Model
+	Name              string                `gorm:"not null"`
+	Stage             types.SubmissionStage `gorm:"not null;unique"`
+	StartAt           time.Time             `gorm:"not null"`
+	EndAt             time.Time             `gorm:"not null"`
+	Duration          uint                  `gorm:"not null"`
+	AdminID           uint                  `gorm:"not null"`
+	ResultPublishedAt time.Time             `gorm:"default:null"`
+	CreatedBy         Admin                 `gorm:"foreignKey:AdminID;references:ID"`
+	Questions         []Question
+}
+
+type DisplayExam struct {
+	ID                uint                  `json:"id,omitempty"`
+	CreatedAt         time.Time             `json:"created_at,omitempty"`
+	UpdatedAt         time.Time             `json:"updated_at,omitempty"`
+	Name              string                `json:"name,omitempty"`
+	Stage             types.SubmissionStage `json:"stage,omitempty"`
+	StartAt           time.Time             `json:"start_at,omitempty"`
+	EndAt             time.Time             `json:"end_at,omitempty"`
+	Duration          uint                  `json:"duration,omitempty"`
+	AdminID           uint                  `json:"admin_id,omitempty"`
+	ResultPublishedAt *time.Time            `json:"result_published_at,omitempty"`
+	Questions         []Question            `json:"questions,omitempty"`
+}
+
+func (exam Exam) MarshalJSON() ([]byte, error) {
+	var resultPublishedAt *time.Time
+	if !exam.ResultPublishedAt.IsZero() {
+		resultPublishedAt = &exam.ResultPublishedAt
+	}
+
+	return json.Marshal(&DisplayExam{
+		ID:                exam.ID,
+		CreatedAt:         exam.CreatedAt,
+		UpdatedAt:         exam.UpdatedAt,
+		Name:              exam.Name,
+		Stage:             exam.Stage,
+		StartAt:           exam.StartAt,
+		EndAt:             exam.EndAt,
+		Duration:          exam.Duration,
+		AdminID:           exam.AdminID,
+		ResultPublishedAt: resultPublishedAt,
+		Questions:         exam.Questions,
+	})
+}
+
+// Menambahkan constraint untuk mengecek apakah waktu mulai ujian berada sebelum waktu selesai ujian
+func (exam *Exam) BeforeSave(tx *gorm.DB) error {
+	if !exam.StartAt.Before(exam.EndAt) {
+		return fmt.Errorf("ERROR: START TIME MUST BE BEFORE END TIME")
+	}
+	if exam.Duration == 0 {
+		return fmt.Errorf("ERROR: DURATION MUST BE POSITIVE")
+	}
+
+	return nil
+}
+
+type ExamResult struct {
+	Rank        int        `json:"rank"`
+	TeamID      uint       `json:"team_id"`
+	TeamName    string     `json:"team_name"`
+	AttemptID   uint       `json:"attempt_id"`
+	Score       float64    `json:"score"`
+	IsGraded    bool       `json:"is_graded"`
+	TimeUsed    uint       `json:"time_used"`
+	SubmittedAt *time.Time `json:"submitted_at,omitempty"`
+}
+
+func (exam Exam) IsResultPublished() bool {
+	return !exam.ResultPublishedAt.IsZero()
+}
+
+// Seluruh attempt yang telah berakhir dinilai ulang, termasuk attempt yang tidak dikumpulkan hingga waktunya habis
+func GradeExam(tx *gorm.DB, exam Exam) error {
+	conditionAttempt := Attempt{ExamID: exam.ID}
+	attempts := []Attempt{}
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(&conditionAttempt).Find(&attempts).Error; err != nil {
+		return err
+	}
+
+	now := tx.NowFunc()
+	for _, attempt := range attempts {
+		if attempt.IsOpen(now) {
+			continue
+		}
+		if err := attempt.Grade(tx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Hasil diurutkan berdasarkan nilai yang tersimpan, kemudian berdasarkan waktu pengerjaan yang lebih singkat,
+// attempt yang telah berakhir namun belum dinilai ditampilkan sebagai belum dinilai
+func GetExamResults(tx *gorm.DB, exam Exam) ([]ExamResult, error) {
+	conditionAttempt := Attempt{ExamID: exam.ID}
+	attempts := []Attempt{}
+	if err := tx.Preload("Team").Where(&conditionAttempt).Find(&attempts).Error; err != nil {
+		return nil, err
+	}
+
+	now := tx.NowFunc()
+	results := []ExamResult{}
+	for _, attempt := range attempts {
+		if attempt.IsOpen(now) {
+			continue
+		}
+
+		finishedAt := attempt.EndAt
+		isGraded := attempt.IsGraded
+		if attempt.SubmittedAt != nil {
+			finishedAt = *attempt.SubmittedAt
+		} else {
+			isGraded = false
+		}
+
+		results = append(results, ExamResult{
+			TeamID:      attempt.TeamID,
+			TeamName:    attempt.Team.TeamName,
+			AttemptID:   attempt.ID,
+			Score:       attempt.Score,
+			IsGraded:    isGraded,
+			TimeUsed:    uint(finishedAt.Sub(attempt.StartedAt).Seconds()),
+			SubmittedAt: attempt.SubmittedAt,
+		})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score == results[j].Score {
+			return results[i].TimeUsed < results[j].TimeUsed
+		}
+		return results[i].Score > results[j].Score
+	})
+	for i := range results {
+		results[i].Rank = i + 1
+	}
+
+	return results, nil
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type GroundTruth struct {
+	gorm.Model
+	FileName      uuid.UUID             `gorm:"type:uuid;unique"`
+	FileExtension string                `gorm:"not null"`
+	Stage         types.SubmissionStage `gorm:"not null;unique"`
+	Metric        types.ScoringMetric   `gorm:"not null"`
+	IDColumn      string                `gorm:"not null"`
+	TargetColumn  string                `gorm:"not null"`
+	DailyLimit    int                   `gorm:"not null"`
+	IsRevealed    bool                  `gorm:"not null;default:false"`
+	AdminID       uint                  `gorm:"not null"`
+	UploadedBy    Admin                 `gorm:"foreignKey:AdminID;references:ID"`
+}
+
+// NOTE: Lokasi file ground truth tidak ditampilkan agar tidak dapat diunduh
+type DisplayGroundTruth struct {
+	ID           uint                  `json:"id,omitempty"`
+	CreatedAt    time.Time             `json:"created_at,omitempty"`
+	UpdatedAt    time.Time             `json:"updated_at,omitempty"`
+	Stage        types.SubmissionStage `json:"stage,omitempty"`
+	Metric       types.ScoringMetric   `json:"metric,omitempty"`
+	IDColumn     string                `json:"id_column,omitempty"`
+	TargetColumn string                `json:"target_column,omitempty"`
+	DailyLimit   int                   `json:"daily_limit,omitempty"`
+	IsRevealed   bool                  `json:"is_revealed"`
+}
+
+func (groundTruth GroundTruth) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&DisplayGroundTruth{
+		ID:           groundTruth.ID,
+		CreatedAt:    groundTruth.CreatedAt,
+		UpdatedAt:    groundTruth.UpdatedAt,
+		Stage:        groundTruth.Stage,
+		Metric:       groundTruth.Metric,
+		IDColumn:     groundTruth.IDColumn,
+		TargetColumn: groundTruth.TargetColumn,
+		DailyLimit:   groundTruth.DailyLimit,
+		IsRevealed:   groundTruth.IsRevealed,
+	})
+}
+
+// Ground truth hanya berlaku untuk team Datavidia yang mengumpulkan pada stage yang memiliki ground truth
+func FindGroundTruth(tx *gorm.DB, teamCategory types.TeamCategory, stage types.SubmissionStage) (GroundTruth, bool, error) {
+	if teamCategory != types.Datavidia {
+		return GroundTruth{}, false, nil
+	}
+
+	conditionGroundTruth := GroundTruth{Stage: stage}
+	groundTruth := GroundTruth{}
+	if err := tx.Where(&conditionGroundTruth).Find(&groundTruth).Error; err != nil {
+		return GroundTruth{}, false, err
+	}
+
+	return groundTruth, groundTruth.ID != 0, nil
+}
+
+// Submission yang telah dihapus tetap dihitung agar batas harian tidak dapat diakali
+func (groundTruth GroundTruth) CheckDailyLimit(tx *gorm.DB, enrolmentID uint) error {
+	now := tx.NowFunc()
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	var count int64
+	condition := Submission{EnrolmentID: enrolmentID, Stage: groundTruth.Stage}
+	if err := tx.Unscoped().Model(&Submission{}).Where(&condition).Where("created_at >= ?", startOfDay).Count(&count).Error; err != nil {
+		return err
+	}
+	if count >= int64(groundTruth.DailyLimit) {
+		return fmt.Errorf("ERROR: DAILY SUBMISSION LIMIT REACHED")
+	}
+
+	return nil
+}
@@ -0,0 +1,191 @@
+package models
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type Invitation struct {
+	gorm.Model
+	TeamID       uint                   `gorm:"not null"`
+	MembershipID uint                   `gorm:"not null"`
+	Email        string                 `gorm:"not null"`
+	Role         types.MembershipRole   `gorm:"not null"`
+	Token        string                 `gorm:"not null;unique"`
+	Status       types.InvitationStatus `gorm:"not null"`
+	ExpiresAt    time.Time              `gorm:"not null"`
+	RespondedAt  time.Time              `gorm:"default:null"`
+	Team         Team                   `gorm:"foreignKey:TeamID;references:ID"`
+}
+
+type DisplayInvitation struct {
+	ID           uint                   `json:"id,omitempty"`
+	CreatedAt    time.Time              `json:"created_at,omitempty"`
+	UpdatedAt    time.Time              `json:"updated_at,omitempty"`
+	TeamID       uint                   `json:"team_id,omitempty"`
+	TeamName     string                 `json:"team_name,omitempty"`
+	MembershipID uint                   `json:"membership_id,omitempty"`
+	Email        string                 `json:"email,omitempty"`
+	Role         types.MembershipRole   `json:"role,omitempty"`
+	Token        string                 `json:"-"`
+	Status       types.InvitationStatus `json:"status,omitempty"`
+	ExpiresAt    time.Time              `json:"expires_at,omitempty"`
+	RespondedAt  *time.Time             `json:"responded_at,omitempty"`
+}
+
+func (invitation Invitation) MarshalJSON() ([]byte, error) {
+	var respondedAt *time.Time
+	if !invitation.RespondedAt.IsZero() {
+		respondedAt = &invitation.RespondedAt
+	}
+
+	return json.Marshal(&DisplayInvitation{
+		ID:           invitation.ID,
+		CreatedAt:    invitation.CreatedAt,
+		UpdatedAt:    invitation.UpdatedAt,
+		TeamID:       invitation.TeamID,
+		TeamName:     invitation.Team.TeamName,
+		MembershipID: invitation.MembershipID,
+		Email:        invitation.Email,
+		Role:         invitation.Role,
+		Status:       invitation.Status,
+		ExpiresAt:    invitation.ExpiresAt,
+		RespondedAt:  respondedAt,
+	})
+}
+
+// Menambahkan constraint untuk mengecek apakah masih terdapat undangan aktif untuk email yang sama pada team tersebut
+func (invitation *Invitation) BeforeCreate(tx *gorm.DB) error {
+	var count int64
+	condition := Invitation{TeamID: invitation.TeamID, Email: invitation.Email, Status: types.InvitationPending}
+	if err := tx.Model(&Invitation{}).Where(&condition).Where("expires_at > ?", time.Now()).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("ERROR: INVITATION ALREADY SENT")
+	}
+
+	return nil
+}
+
+func (invitation Invitation) IsExpired() bool {
+	return invitation.Status == types.InvitationExpired || (invitation.Status == types.InvitationPending && !time.Now().Before(invitation.ExpiresAt))
+}
+
+func GenerateInvitationToken() (string, error) {
+	buffer := make([]byte, 32)
+	if _, err := rand.Read(buffer); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(buffer), nil
+}
+
+// Membership pending dan undangan dibuat bersamaan, membership baru aktif setelah undangan diterima
+func CreateInvitation(tx *gorm.DB, teamID uint, participant Participant, role types.MembershipRole, expiresAt time.Time) (Invitation, error) {
+	membership := Membership{TeamID: teamID, ParticipantID: participant.ID, Role: role, Status: types.PendingMembership}
+	if err := tx.Create(&membership).Error; err != nil {
+		return Invitation{}, err
+	}
+
+	token, err := GenerateInvitationToken()
+	if err != nil {
+		return Invitation{}, err
+	}
+
+	invitation := Invitation{TeamID: teamID, MembershipID: membership.ID, Email: participant.Email, Role: role, Token: token, Status: types.InvitationPending, ExpiresAt: expiresAt}
+	if err := tx.Create(&invitation).Error; err != nil {
+		return Invitation{}, err
+	}
+
+	conditionTeam := Team{Model: gorm.Model{ID: teamID}}
+	if err := tx.Where(&conditionTeam).First(&invitation.Team).Error; err != nil {
+		return Invitation{}, err
+	}
+
+	return invitation, nil
+}
+
+// Undangan ditutup dengan status tertentu dan membership pending-nya dihapus permanen
+// agar participant dapat diundang kembali
+func (invitation *Invitation) Close(tx *gorm.DB, status types.InvitationStatus) error {
+	conditionMembership := Membership{Model: gorm.Model{ID: invitation.MembershipID}, Status: types.PendingMembership}
+	if err := tx.Unscoped().Where(&conditionMembership).Delete(&Membership{}).Error; err != nil {
+		return err
+	}
+
+	conditionInvitation := Invitation{Model: gorm.Model{ID: invitation.ID}}
+	newInvitation := Invitation{Status: status, RespondedAt: time.Now()}
+	if err := tx.Where(&conditionInvitation).Updates(&newInvitation).Error; err != nil {
+		return err
+	}
+
+	invitation.Status = newInvitation.Status
+	invitation.RespondedAt = newInvitation.RespondedAt
+	return nil
+}
+
+// Baris undangan dikunci agar dua permintaan accept yang bersamaan tidak sama-sama berhasil
+func (invitation *Invitation) Accept(tx *gorm.DB) error {
+	conditionLock := Invitation{Model: gorm.Model{ID: invitation.ID}}
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(&conditionLock).First(invitation).Error; err != nil {
+		return err
+	}
+
+	if invitation.IsExpired() {
+		return fmt.Errorf("ERROR: INVITATION EXPIRED")
+	}
+	if invitation.Status != types.InvitationPending {
+		return fmt.Errorf("ERROR: INVITATION IS NO LONGER PENDING")
+	}
+
+	conditionMembership := Membership{Model: gorm.Model{ID: invitation.MembershipID}}
+	newMembership := Membership{Status: types.ActiveMembership}
+	if err := tx.Where(&conditionMembership).Updates(&newMembership).Error; err != nil {
+		return err
+	}
+
+	// Undangan yang diterima melalui tautan email sekaligus mengonfirmasi email participant
+	membership := Membership{}
+	if err := tx.Where(&conditionMembership).First(&membership).Error; err != nil {
+		return err
+	}
+	if err := ConfirmEmail(tx, membership.ParticipantID); err != nil {
+		return err
+	}
+
+	conditionInvitation := Invitation{Model: gorm.Model{ID: invitation.ID}}
+	newInvitation := Invitation{Status: types.InvitationAccepted, RespondedAt: time.Now()}
+	if err := tx.Where(&conditionInvitation).Updates(&newInvitation).Error; err != nil {
+		return err
+	}
+
+	invitation.Status = newInvitation.Status
+	invitation.RespondedAt = newInvitation.RespondedAt
+	return nil
+}
+
+// Undangan pending yang telah melewati batas waktu ditandai expired
+func ExpireInvitations(tx *gorm.DB, teamID uint) error {
+	condition := Invitation{TeamID: teamID, Status: types.InvitationPending}
+	invitations := []Invitation{}
+	if err := tx.Where(&condition).Where("expires_at <= ?", time.Now()).Find(&invitations).Error; err != nil {
+		return err
+	}
+
+	for _, invitation := range invitations {
+		if err := invitation.Close(tx, types.InvitationExpired); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,282 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type Invoice struct {
+	gorm.Model
+	TeamID       uint                `gorm:"not null"`
+	EnrolmentID  uint                `gorm:"not null"`
+	TeamCategory types.TeamCategory  `gorm:"not null"`
+	Amount       uint                `gorm:"not null"`
+	Discount     uint                `gorm:"not null;default:0"`
+	VoucherID    uint                `gorm:"default:null"`
+	ExternalID   string              `gorm:"not null;unique"`
+	ProviderID   string              `gorm:"default:null"`
+	PaymentURL   string              `gorm:"default:null"`
+	Status       types.InvoiceStatus `gorm:"not null;default:'pending'"`
+	ExpiresAt    time.Time           `gorm:"default:null"`
+	PaidAt       time.Time           `gorm:"default:null"`
+	Team         Team                `gorm:"foreignKey:TeamID;references:ID"`
+	Enrolment    Enrolment           `gorm:"foreignKey:EnrolmentID;references:ID"`
+	Voucher      Voucher             `gorm:"foreignKey:VoucherID;references:ID"`
+}
+
+type DisplayInvoice struct {
+	ID           uint                `json:"id,omitempty"`
+	CreatedAt    time.Time           `json:"created_at,omitempty"`
+	UpdatedAt    time.Time           `json:"updated_at,omitempty"`
+	TeamID       uint                `json:"team_id,omitempty"`
+	EnrolmentID  uint                `json:"enrolment_id,omitempty"`
+	TeamCategory types.TeamCategory  `json:"team_category,omitempty"`
+	Amount       uint                `json:"amount"`
+	Discount     uint                `json:"discount"`
+	VoucherID    uint                `json:"voucher_id,omitempty"`
+	ExternalID   string              `json:"external_id,omitempty"`
+	PaymentURL   string              `json:"payment_url,omitempty"`
+	Status       types.InvoiceStatus `json:"status,omitempty"`
+	ExpiresAt    *time.Time          `json:"expires_at,omitempty"`
+	PaidAt       *time.Time          `json:"paid_at,omitempty"`
+}
+
+func (invoice Invoice) MarshalJSON() ([]byte, error) {
+	var expiresAt *time.Time
+	if !invoice.ExpiresAt.IsZero() {
+		expiresAt = &invoice.ExpiresAt
+	}
+	var paidAt *time.Time
+	if !invoice.PaidAt.IsZero() {
+		paidAt = &invoice.PaidAt
+	}
+
+	return json.Marshal(&DisplayInvoice{
+		ID:           invoice.ID,
+		CreatedAt:    invoice.CreatedAt,
+		UpdatedAt:    invoice.UpdatedAt,
+		TeamID:       invoice.TeamID,
+		EnrolmentID:  invoice.EnrolmentID,
+		TeamCategory: invoice.TeamCategory,
+		Amount:       invoice.Amount,
+		Discount:     invoice.Discount,
+		VoucherID:    invoice.VoucherID,
+		ExternalID:   invoice.ExternalID,
+		PaymentURL:   invoice.PaymentURL,
+		Status:       invoice.Status,
+		ExpiresAt:    expiresAt,
+		PaidAt:       paidAt,
+	})
+}
+
+// Menambahkan constraint untuk mengecek apakah enrolment masih memiliki invoice yang belum dibayar atau telah lunas
+func (invoice *Invoice) BeforeCreate(tx *gorm.DB) error {
+	var count int64
+	if err := tx.Model(&Invoice{}).Where("enrolment_id = ? AND status IN ?", invoice.EnrolmentID, []types.InvoiceStatus{types.InvoicePending, types.InvoicePaid}).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("ERROR: INVOICE ALREADY ISSUED")
+	}
+
+	if invoice.ExternalID == "" {
+		invoice.ExternalID = fmt.Sprintf("ARKAVIDIA-%d-%s", invoice.EnrolmentID, uuid.NewString())
+	}
+
+	return nil
+}
+
+// Mencatat penukaran voucher setelah invoice yang menggunakan voucher tersimpan
+func (invoice *Invoice) AfterCreate(tx *gorm.DB) error {
+	if invoice.VoucherID == 0 {
+		return nil
+	}
+
+	voucherRedemption := VoucherRedemption{VoucherID: invoice.VoucherID, TeamID: invoice.TeamID, InvoiceID: invoice.ID, TeamCategory: invoice.TeamCategory, DiscountAmount: invoice.Discount}
+	return tx.Create(&voucherRedemption).Error
+}
+
+// Invoice yang belum dibayar dan telah melewati batas waktu dianggap kedaluwarsa
+func (invoice Invoice) IsExpired(now time.Time) bool {
+	return invoice.Status == types.InvoicePending && !invoice.ExpiresAt.IsZero() && now.After(invoice.ExpiresAt)
+}
+
+// Mengembalikan invoice terakhir yang belum dibayar atau telah lunas milik enrolment
+func FindActiveInvoice(tx *gorm.DB, enrolmentID uint) (Invoice, error) {
+	invoice := Invoice{}
+	if err := tx.Where("enrolment_id = ? AND status IN ?", enrolmentID, []types.InvoiceStatus{types.InvoicePending, types.InvoicePaid}).Order("id DESC").Find(&invoice).Error; err != nil {
+		return Invoice{}, err
+	}
+
+	return invoice, nil
+}
+
+// Mengembalikan invoice aktif milik enrolment atau membuat invoice baru dengan nominal sesuai biaya pendaftaran jenis lomba
+// NOTE: Voucher pada invoice yang belum dibayar dapat diterapkan dengan menggantikan invoice tersebut
+func IssueInvoice(tx *gorm.DB, enrolmentID uint, voucherCode string) (Invoice, error) {
+	conditionEnrolment := Enrolment{Model: gorm.Model{ID: enrolmentID}}
+	enrolment := Enrolment{}
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(&conditionEnrolment).First(&enrolment).Error; err != nil {
+		return Invoice{}, err
+	}
+
+	invoice, err := FindActiveInvoice(tx, enrolmentID)
+	if err != nil {
+		return Invoice{}, err
+	}
+	if invoice.ID != 0 {
+		status := types.InvoiceCancelled
+		switch {
+		case invoice.IsExpired(tx.NowFunc()):
+			status = types.InvoiceExpired
+		case voucherCode == "":
+			return invoice, nil
+		case invoice.Status == types.InvoicePaid:
+			return Invoice{}, fmt.Errorf("ERROR: INVOICE ALREADY PAID")
+		case invoice.VoucherID != 0:
+			return Invoice{}, fmt.Errorf("ERROR: VOUCHER ALREADY APPLIED")
+		case invoice.ProviderID != "":
+			// Transaksi pada provider harus dibatalkan terlebih dahulu agar team tidak dapat membayar kedua invoice
+			return Invoice{}, fmt.Errorf("ERROR: PAYMENT TRANSACTION IS STILL ACTIVE")
+		}
+
+		condition := Invoice{Model: gorm.Model{ID: invoice.ID}}
+		if err := tx.Model(&Invoice{}).Where(&condition).Update("status", status).Error; err != nil {
+			return Invoice{}, err
+		}
+	}
+
+	conditionQuota := CategoryQuota{TeamCategory: enrolment.TeamCategory}
+	categoryQuota := CategoryQuota{}
+	if err := tx.Where(&conditionQuota).Find(&categoryQuota).Error; err != nil {
+		return Invoice{}, err
+	}
+	if categoryQuota.RegistrationFee == 0 {
+		return Invoice{}, fmt.Errorf("ERROR: NO REGISTRATION FEE FOR %s", string(enrolment.TeamCategory))
+	}
+
+	invoice = Invoice{TeamID: enrolment.TeamID, EnrolmentID: enrolment.ID, TeamCategory: enrolment.TeamCategory, Amount: categoryQuota.RegistrationFee, Status: types.InvoicePending}
+	if voucherCode != "" {
+		voucher, err := FindRedeemableVoucher(tx, voucherCode, enrolment.TeamID, enrolment.TeamCategory)
+		if err != nil {
+			return Invoice{}, err
+		}
+
+		invoice.VoucherID = voucher.ID
+		invoice.Discount = voucher.GetDiscount(invoice.Amount)
+		invoice.Amount -= invoice.Discount
+	}
+
+	// Invoice yang seluruh biayanya ditanggung voucher langsung dianggap lunas tanpa transaksi pada provider
+	if invoice.Amount == 0 {
+		invoice.Status = types.InvoicePaid
+		invoice.PaidAt = tx.NowFunc()
+	}
+
+	if err := tx.Create(&invoice).Error; err != nil {
+		return Invoice{}, err
+	}
+	if invoice.Status == types.InvoicePaid {
+		if err := ApprovePayment(tx, enrolment.ID); err != nil {
+			return Invoice{}, err
+		}
+	}
+
+	return invoice, nil
+}
+
+// Invoice yang belum dibayar dan belum memiliki transaksi pada provider, misalnya karena pemanggilan provider sebelumnya gagal
+func (invoice Invoice) RequiresTransaction(now time.Time) bool {
+	return invoice.Status == types.InvoicePending && invoice.ProviderID == "" && !invoice.IsExpired(now)
+}
+
+// Menyimpan data transaksi yang dikembalikan provider pada invoice, nilai kembalian false menandakan invoice
+// telah memiliki transaksi dari permintaan lain sehingga transaksi yang baru dibuat harus dibatalkan
+func AttachPaymentTransaction(tx *gorm.DB, invoice *Invoice, providerID string, paymentURL string, expiresAt time.Time) (bool, error) {
+	condition := Invoice{Model: gorm.Model{ID: invoice.ID}, Status: types.InvoicePending}
+	result := tx.Model(&Invoice{}).Where(&condition).Where("provider_id IS NULL").Updates(map[string]interface{}{"provider_id": providerID, "payment_url": paymentURL, "expires_at": expiresAt})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, tx.Where(&Invoice{Model: gorm.Model{ID: invoice.ID}}).First(invoice).Error
+	}
+
+	invoice.ProviderID = providerID
+	invoice.PaymentURL = paymentURL
+	invoice.ExpiresAt = expiresAt
+	return true, nil
+}
+
+// Memperbarui status invoice berdasarkan webhook provider, webhook yang dikirim ulang untuk invoice yang telah lunas diabaikan
+func SettleInvoice(tx *gorm.DB, externalID string, status types.InvoiceStatus, amount uint, paidAt time.Time) (Invoice, error) {
+	condition := Invoice{ExternalID: externalID}
+	invoice := Invoice{}
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(&condition).First(&invoice).Error; err != nil {
+		return Invoice{}, fmt.Errorf("ERROR: INVOICE NOT FOUND")
+	}
+	if invoice.Status == types.InvoicePaid {
+		return invoice, nil
+	}
+
+	switch status {
+	case types.InvoicePaid:
+		if amount != invoice.Amount {
+			return Invoice{}, fmt.Errorf("ERROR: PAID AMOUNT DOES NOT MATCH INVOICE")
+		}
+		if paidAt.IsZero() {
+			paidAt = tx.NowFunc()
+		}
+
+		// Pembayaran yang diterima setelah invoice kedaluwarsa atau dibatalkan tetap dicatat agar dana tidak hilang
+		invoice.Status = types.InvoicePaid
+		invoice.PaidAt = paidAt
+		if err := tx.Model(&Invoice{}).Where(&condition).Updates(map[string]interface{}{"status": invoice.Status, "paid_at": invoice.PaidAt}).Error; err != nil {
+			return Invoice{}, err
+		}
+		if err := ApprovePayment(tx, invoice.EnrolmentID); err != nil {
+			return Invoice{}, err
+		}
+	case types.InvoiceExpired, types.InvoiceCancelled:
+		if invoice.Status != types.InvoicePending {
+			return invoice, nil
+		}
+
+		invoice.Status = status
+		if err := tx.Model(&Invoice{}).Where(&condition).Update("status", invoice.Status).Error; err != nil {
+			return Invoice{}, err
+		}
+	}
+
+	return invoice, nil
+}
+
+// Membatalkan invoice yang belum dibayar, misalnya karena enrolment ditarik
+func CancelInvoices(tx *gorm.DB, condition Invoice) error {
+	condition.Status = types.InvoicePending
+	return tx.Model(&Invoice{}).Where(&condition).Update("status", types.InvoiceCancelled).Error
+}
+
+// Mengembalikan invoice terakhir milik setiap enrolment, dengan invoice lunas diutamakan
+func GetLatestInvoices(tx *gorm.DB, enrolmentIDs []uint) (map[uint]Invoice, error) {
+	invoices := []Invoice{}
+	if err := tx.Where("enrolment_id IN ?", enrolmentIDs).Order("id").Find(&invoices).Error; err != nil {
+		return nil, err
+	}
+
+	latest := map[uint]Invoice{}
+	for _, invoice := range invoices {
+		if latest[invoice.EnrolmentID].Status != types.InvoicePaid {
+			latest[invoice.EnrolmentID] = invoice
+		}
+	}
+
+	return latest, nil
+}
@@ -0,0 +1,138 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type JudgeConflict struct {
+	gorm.Model
+	JudgeID     uint   `gorm:"not null;uniqueIndex:judge_conflict_index"`
+	Institution string `gorm:"not null;uniqueIndex:judge_conflict_index"`
+	Judge       Judge  `gorm:"foreignKey:JudgeID;references:ID"`
+}
+
+type DisplayJudgeConflict struct {
+	ID          uint      `json:"id,omitempty"`
+	CreatedAt   time.Time `json:"created_at,omitempty"`
+	UpdatedAt   time.Time `json:"updated_at,omitempty"`
+	JudgeID     uint      `json:"judge_id,omitempty"`
+	Institution string    `json:"institution,omitempty"`
+}
+
+func (judgeConflict JudgeConflict) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&DisplayJudgeConflict{
+		ID:          judgeConflict.ID,
+		CreatedAt:   judgeConflict.CreatedAt,
+		UpdatedAt:   judgeConflict.UpdatedAt,
+		JudgeID:     judgeConflict.JudgeID,
+		Institution: judgeConflict.Institution,
+	})
+}
+
+// Nama institusi dinormalisasi agar perbedaan kapitalisasi dan spasi tidak meloloskan konflik kepentingan
+func NormalizeInstitution(institution string) string {
+	return strings.ToLower(strings.Join(strings.Fields(institution), " "))
+}
+
+func (judgeConflict *JudgeConflict) BeforeSave(tx *gorm.DB) error {
+	judgeConflict.Institution = NormalizeInstitution(judgeConflict.Institution)
+	return nil
+}
+
+// Institusi team terdiri dari institusi team dan institusi seluruh anggotanya,
+// unknown bernilai true jika ada institusi yang belum diisi sehingga konflik tidak dapat dipastikan
+func GetTeamInstitutions(tx *gorm.DB, teamID uint) ([]string, bool, error) {
+	conditionTeam := Team{Model: gorm.Model{ID: teamID}}
+	team := Team{}
+	if err := tx.Where(&conditionTeam).First(&team).Error; err != nil {
+		return nil, false, err
+	}
+
+	conditionMembership := Membership{TeamID: teamID}
+	memberships := []Membership{}
+	if err := tx.Preload("Participant").Where(&conditionMembership).Find(&memberships).Error; err != nil {
+		return nil, false, err
+	}
+
+	unknown := false
+	seen := map[string]bool{}
+	institutions := []string{}
+	candidates := []string{team.Institution}
+	for _, membership := range memberships {
+		candidates = append(candidates, membership.Participant.Institution)
+	}
+	for _, institution := range candidates {
+		normalized := NormalizeInstitution(institution)
+		if normalized == "" {
+			unknown = true
+			continue
+		}
+		if !seen[normalized] {
+			seen[normalized] = true
+			institutions = append(institutions, normalized)
+		}
+	}
+
+	return institutions, unknown, nil
+}
+
+// Mengembalikan apakah judge memiliki konflik kepentingan dengan team dan apakah assignment perlu ditinjau ulang
+// karena institusi team atau anggotanya tidak diketahui
+func HasConflict(tx *gorm.DB, judgeID uint, teamID uint) (bool, bool, error) {
+	institutions, unknown, err := GetTeamInstitutions(tx, teamID)
+	if err != nil {
+		return false, false, err
+	}
+	if len(institutions) == 0 {
+		return false, unknown, nil
+	}
+
+	var count int64
+	condition := JudgeConflict{JudgeID: judgeID}
+	if err := tx.Model(&JudgeConflict{}).Where(&condition).Where("institution IN ?", institutions).Count(&count).Error; err != nil {
+		return false, false, err
+	}
+
+	return count > 0, unknown, nil
+}
+
+// Assignment judge yang berkonflik dengan deklarasi terbaru dicabut jika belum dinilai,
+// sedangkan yang sudah memiliki nilai ditandai untuk ditinjau ulang oleh admin
+func RevokeConflictingAssignments(tx *gorm.DB, judgeID uint) ([]Assignment, []Assignment, error) {
+	condition := Assignment{JudgeID: judgeID}
+	assignments := []Assignment{}
+	if err := tx.Preload("Submission").Preload("Scores").Where(&condition).Find(&assignments).Error; err != nil {
+		return nil, nil, err
+	}
+
+	revoked := []Assignment{}
+	flagged := []Assignment{}
+	for _, assignment := range assignments {
+		hasConflict, _, err := HasConflict(tx, judgeID, assignment.Submission.TeamID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !hasConflict {
+			continue
+		}
+
+		if len(assignment.Scores) == 0 {
+			if err := tx.Unscoped().Delete(&assignment).Error; err != nil {
+				return nil, nil, err
+			}
+			revoked = append(revoked, assignment)
+			continue
+		}
+
+		if err := tx.Model(&assignment).Update("needs_review", true).Error; err != nil {
+			return nil, nil, err
+		}
+		flagged = append(flagged, assignment)
+	}
+
+	return revoked, flagged, nil
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type Judge struct {
+	gorm.Model
+	Username       string                `gorm:"not null;unique"`
+	HashedPassword types.EncryptedString `gorm:"not null"`
+	Name           string                `gorm:"not null"`
+	Assignments    []Assignment
+	Conflicts      []JudgeConflict
+}
+
+type DisplayJudge struct {
+	ID             uint                  `json:"id,omitempty"`
+	CreatedAt      time.Time             `json:"created_at,omitempty"`
+	UpdatedAt      time.Time             `json:"updated_at,omitempty"`
+	Username       string                `json:"username,omitempty"`
+	HashedPassword types.EncryptedString `json:"-"`
+	Name           string                `json:"name,omitempty"`
+	Assignments    []Assignment          `json:"assignments,omitempty"`
+	Conflicts      []JudgeConflict       `json:"conflicts,omitempty"`
+}
+
+func (judge Judge) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&DisplayJudge{
+		ID:          judge.ID,
+		CreatedAt:   judge.CreatedAt,
+		UpdatedAt:   judge.UpdatedAt,
+		Username:    judge.Username,
+		Name:        judge.Name,
+		Assignments: judge.Assignments,
+		Conflicts:   judge.Conflicts,
+	})
+}
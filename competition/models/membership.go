@@ -12,20 +12,22 @@ import (
 
 type Membership struct {
 	gorm.Model
-	TeamID        uint                 `gorm:"uniqueIndex:membership_index"`
-	ParticipantID uint                 `gorm:"uniqueIndex:membership_index"`
-	Role          types.MembershipRole `gorm:"not null"`
-	Team          Team                 `gorm:"foreignKey:TeamID;references:ID"`
-	Participant   Participant          `gorm:"foreignKey:ParticipantID;references:ID"`
+	TeamID        uint                   `gorm:"uniqueIndex:membership_index"`
+	ParticipantID uint                   `gorm:"uniqueIndex:membership_index"`
+	Role          types.MembershipRole   `gorm:"not null"`
+	Status        types.MembershipStatus `gorm:"not null;default:'active'"`
+	Team          Team                   `gorm:"foreignKey:TeamID;references:ID"`
+	Participant   Participant            `gorm:"foreignKey:ParticipantID;references:ID"`
 }
 
 type DisplayMembership struct {
-	ID            uint                 `json:"id,omitempty"`
-	CreatedAt     time.Time            `json:"created_at,omitempty"`
-	UpdatedAt     time.Time            `json:"updated_at,omitempty"`
-	TeamID        uint                 `json:"team_id,omitempty"`
-	ParticipantID uint                 `json:"participant_id,omitempty"`
-	Role          types.MembershipRole `json:"role,omitempty"`
+	ID            uint                   `json:"id,omitempty"`
+	CreatedAt     time.Time              `json:"created_at,omitempty"`
+	UpdatedAt     time.Time              `json:"updated_at,omitempty"`
+	TeamID        uint                   `json:"team_id,omitempty"`
+	ParticipantID uint                   `json:"participant_id,omitempty"`
+	Role          types.MembershipRole   `json:"role,omitempty"`
+	Status        types.MembershipStatus `json:"status,omitempty"`
 }
 
 func (membership Membership) MarshalJSON() ([]byte, error) {
@@ -36,6 +38,7 @@ func (membership Membership) MarshalJSON() ([]byte, error) {
 		TeamID:        membership.TeamID,
 		ParticipantID: membership.ParticipantID,
 		Role:          membership.Role,
+		Status:        membership.Status,
 	})
 }
 
@@ -43,15 +46,33 @@ func (membership Membership) MarshalJSON() ([]byte, error) {
 // dengan jenis lomba yang sama atau memiliki role leader lebih dari satu kali
 func (membership *Membership) BeforeSave(tx *gorm.DB) error {
 	if membership.TeamID != 0 && membership.ParticipantID != 0 {
+		conditionTeam := Team{Model: gorm.Model{ID: membership.TeamID}}
+		team := Team{}
+		if err := tx.Preload("Enrolments").Where(&conditionTeam).First(&team).Error; err != nil {
+			return err
+		}
+
+		categories := map[types.TeamCategory]bool{}
+		for _, enrolment := range team.Enrolments {
+			categories[enrolment.TeamCategory] = true
+		}
+
 		conditionParticipantID := Membership{ParticipantID: membership.ParticipantID}
 		oldMembershipsParticipantID := []Membership{}
-		if err := tx.Where(&conditionParticipantID).Find(&oldMembershipsParticipantID).Error; err != nil {
+		if err := tx.Preload("Team.Enrolments").Where(&conditionParticipantID).Find(&oldMembershipsParticipantID).Error; err != nil {
 			return err
 		}
 
 		for _, oldMembership := range oldMembershipsParticipantID {
-			if oldMembership.Team.TeamCategory != "" && oldMembership.Team.TeamCategory == membership.Team.TeamCategory {
-				return fmt.Errorf("ERROR: CANNOT PARTICIPATE MORE THAN ONCE")
+			if oldMembership.ID == membership.ID {
+				continue
+			}
+			if oldMembership.TeamID != membership.TeamID {
+				for _, enrolment := range oldMembership.Team.Enrolments {
+					if categories[enrolment.TeamCategory] {
+						return fmt.Errorf("ERROR: CANNOT PARTICIPATE MORE THAN ONCE")
+					}
+				}
 			}
 			if oldMembership.Role == types.Leader && membership.Role == types.Leader {
 				return fmt.Errorf("ERROR: INELIGIBLE LEADER")
@@ -65,6 +86,9 @@ func (membership *Membership) BeforeSave(tx *gorm.DB) error {
 		}
 
 		for _, oldMembership := range oldMembershipsTeamID {
+			if oldMembership.ID == membership.ID {
+				continue
+			}
 			if oldMembership.Role == types.Leader && membership.Role == types.Leader {
 				return fmt.Errorf("ERROR: INELIGIBLE LEADER")
 			}
@@ -73,3 +97,19 @@ func (membership *Membership) BeforeSave(tx *gorm.DB) error {
 
 	return nil
 }
+
+// Membership yang undangannya belum diterima tidak ikut menerima email team
+func GetMemberEmails(tx *gorm.DB, teamID uint) ([]string, error) {
+	condition := Membership{TeamID: teamID, Status: types.ActiveMembership}
+	memberships := []Membership{}
+	if err := tx.Preload("Participant").Where(&condition).Find(&memberships).Error; err != nil {
+		return nil, err
+	}
+
+	emails := []string{}
+	for _, membership := range memberships {
+		emails = append(emails, membership.Participant.Email)
+	}
+
+	return emails, nil
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Seluruh data yang terkait dengan seorang participant dalam format yang dapat dibaca mesin
+type ParticipantExport struct {
+	ExportedAt       time.Time         `json:"exported_at"`
+	Participant      Participant       `json:"participant"`
+	Teams            []Team            `json:"teams"`
+	Memberships      []Membership      `json:"memberships"`
+	Photos           []Photo           `json:"photos"`
+	Submissions      []Submission      `json:"submissions"`
+	RoleChanges      []RoleChange      `json:"role_changes"`
+	Invitations      []Invitation      `json:"invitations"`
+	DeletionRequests []DeletionRequest `json:"deletion_requests"`
+	Certificates     []Certificate     `json:"certificates"`
+}
+
+// Membership yang telah dihapus tetap disertakan karena submission team tersebut juga terkait dengan participant
+func ExportParticipant(tx *gorm.DB, participantID uint) (ParticipantExport, error) {
+	participantExport := ParticipantExport{ExportedAt: tx.NowFunc()}
+
+	conditionParticipant := Participant{Model: gorm.Model{ID: participantID}}
+	if err := tx.Where(&conditionParticipant).First(&participantExport.Participant).Error; err != nil {
+		return ParticipantExport{}, err
+	}
+
+	conditionMembership := Membership{ParticipantID: participantID}
+	if err := tx.Unscoped().Where(&conditionMembership).Find(&participantExport.Memberships).Error; err != nil {
+		return ParticipantExport{}, err
+	}
+
+	teamIDs := []uint{}
+	for _, membership := range participantExport.Memberships {
+		teamIDs = append(teamIDs, membership.TeamID)
+	}
+
+	if err := tx.Preload("Enrolments").Where("id IN ?", teamIDs).Find(&participantExport.Teams).Error; err != nil {
+		return ParticipantExport{}, err
+	}
+
+	if err := tx.Where("team_id IN ?", teamIDs).Order("team_id, stage, version").Find(&participantExport.Submissions).Error; err != nil {
+		return ParticipantExport{}, err
+	}
+
+	conditionPhoto := Photo{ParticipantID: participantID}
+	if err := tx.Where(&conditionPhoto).Find(&participantExport.Photos).Error; err != nil {
+		return ParticipantExport{}, err
+	}
+
+	conditionRoleChange := RoleChange{ParticipantID: participantID}
+	if err := tx.Where(&conditionRoleChange).Order("created_at").Find(&participantExport.RoleChanges).Error; err != nil {
+		return ParticipantExport{}, err
+	}
+
+	if err := tx.Where("LOWER(TRIM(email)) = ?", NormalizeEmail(participantExport.Participant.Email)).Order("created_at").Find(&participantExport.Invitations).Error; err != nil {
+		return ParticipantExport{}, err
+	}
+
+	conditionDeletionRequest := DeletionRequest{ParticipantID: participantID}
+	if err := tx.Where(&conditionDeletionRequest).Order("created_at").Find(&participantExport.DeletionRequests).Error; err != nil {
+		return ParticipantExport{}, err
+	}
+
+	conditionCertificate := Certificate{ParticipantID: participantID}
+	if err := tx.Where(&conditionCertificate).Order("issued_at").Find(&participantExport.Certificates).Error; err != nil {
+		return ParticipantExport{}, err
+	}
+
+	return participantExport, nil
+}
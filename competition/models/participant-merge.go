@@ -0,0 +1,234 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type ParticipantMerge struct {
+	gorm.Model
+	SurvivorID       uint        `gorm:"not null"`
+	DuplicateID      uint        `gorm:"not null;unique"`
+	AdminID          uint        `gorm:"not null"`
+	DuplicateName    string      `gorm:"not null"`
+	DuplicateEmail   string      `gorm:"not null"`
+	MovedMemberships int         `gorm:"not null"`
+	MovedPhotos      int         `gorm:"not null"`
+	Survivor         Participant `gorm:"foreignKey:SurvivorID;references:ID"`
+	MergedBy         Admin       `gorm:"foreignKey:AdminID;references:ID"`
+}
+
+type DisplayParticipantMerge struct {
+	ID               uint      `json:"id,omitempty"`
+	CreatedAt        time.Time `json:"created_at,omitempty"`
+	UpdatedAt        time.Time `json:"updated_at,omitempty"`
+	SurvivorID       uint      `json:"survivor_id,omitempty"`
+	DuplicateID      uint      `json:"duplicate_id,omitempty"`
+	AdminID          uint      `json:"admin_id,omitempty"`
+	DuplicateName    string    `json:"duplicate_name,omitempty"`
+	DuplicateEmail   string    `json:"duplicate_email,omitempty"`
+	MovedMemberships int       `json:"moved_memberships"`
+	MovedPhotos      int       `json:"moved_photos"`
+}
+
+func (participantMerge ParticipantMerge) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&DisplayParticipantMerge{
+		ID:               participantMerge.ID,
+		CreatedAt:        participantMerge.CreatedAt,
+		UpdatedAt:        participantMerge.UpdatedAt,
+		SurvivorID:       participantMerge.SurvivorID,
+		DuplicateID:      participantMerge.DuplicateID,
+		AdminID:          participantMerge.AdminID,
+		DuplicateName:    participantMerge.DuplicateName,
+		DuplicateEmail:   participantMerge.DuplicateEmail,
+		MovedMemberships: participantMerge.MovedMemberships,
+		MovedPhotos:      participantMerge.MovedPhotos,
+	})
+}
+
+type DuplicateGroup struct {
+	Reason       string        `json:"reason,omitempty"`
+	Key          string        `json:"key,omitempty"`
+	Participants []Participant `json:"participants,omitempty"`
+}
+
+func normalizeName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// Participant dianggap duplikat apabila email atau nama yang telah dinormalisasi sama
+func FindDuplicateParticipants(tx *gorm.DB) ([]DuplicateGroup, error) {
+	participants := []Participant{}
+	if err := tx.Order("id").Find(&participants).Error; err != nil {
+		return nil, err
+	}
+
+	byEmail := map[string][]Participant{}
+	byName := map[string][]Participant{}
+	for _, participant := range participants {
+		email := NormalizeEmail(participant.Email)
+		name := normalizeName(participant.Name)
+		byEmail[email] = append(byEmail[email], participant)
+		byName[name] = append(byName[name], participant)
+	}
+
+	groups := []DuplicateGroup{}
+	for key, members := range byEmail {
+		if len(members) > 1 {
+			groups = append(groups, DuplicateGroup{Reason: "email", Key: key, Participants: members})
+		}
+	}
+	for key, members := range byName {
+		if len(members) > 1 {
+			groups = append(groups, DuplicateGroup{Reason: "name", Key: key, Participants: members})
+		}
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Reason == groups[j].Reason {
+			return groups[i].Key < groups[j].Key
+		}
+		return groups[i].Reason < groups[j].Reason
+	})
+
+	return groups, nil
+}
+
+// Membership, photo, sertifikat, dan permintaan penghapusan data milik participant duplikat dipindahkan ke participant
+// yang dipertahankan, membership pada team yang sama digabungkan dengan role dan status terbaik dari keduanya
+func MergeParticipants(tx *gorm.DB, survivorID uint, duplicateID uint, adminID uint) (ParticipantMerge, error) {
+	if survivorID == duplicateID {
+		return ParticipantMerge{}, fmt.Errorf("ERROR: CANNOT MERGE PARTICIPANT WITH ITSELF")
+	}
+
+	participants := []Participant{}
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id IN ?", []uint{survivorID, duplicateID}).Find(&participants).Error; err != nil {
+		return ParticipantMerge{}, err
+	}
+	if len(participants) != 2 {
+		return ParticipantMerge{}, fmt.Errorf("ERROR: PARTICIPANT NOT FOUND")
+	}
+
+	duplicate := participants[0]
+	if duplicate.ID != duplicateID {
+		duplicate = participants[1]
+	}
+
+	conditionSurvivor := Membership{ParticipantID: survivorID}
+	survivorMemberships := []Membership{}
+	if err := tx.Where(&conditionSurvivor).Find(&survivorMemberships).Error; err != nil {
+		return ParticipantMerge{}, err
+	}
+
+	survivorTeams := map[uint]Membership{}
+	for _, membership := range survivorMemberships {
+		survivorTeams[membership.TeamID] = membership
+	}
+
+	conditionDuplicate := Membership{ParticipantID: duplicateID}
+	duplicateMemberships := []Membership{}
+	if err := tx.Where(&conditionDuplicate).Find(&duplicateMemberships).Error; err != nil {
+		return ParticipantMerge{}, err
+	}
+
+	movedMemberships := 0
+	teamIDs := []uint{}
+	for _, membership := range duplicateMemberships {
+		teamIDs = append(teamIDs, membership.TeamID)
+
+		survivorMembership, exists := survivorTeams[membership.TeamID]
+		if !exists {
+			// Membership disimpan secara utuh agar constraint BeforeSave (leader dan jenis lomba ganda) tetap dicek
+			membership.ParticipantID = survivorID
+			if err := tx.Save(&membership).Error; err != nil {
+				return ParticipantMerge{}, err
+			}
+			movedMemberships++
+			continue
+		}
+
+		if err := mergeMemberships(tx, survivorMembership, membership); err != nil {
+			return ParticipantMerge{}, err
+		}
+	}
+
+	for _, teamID := range teamIDs {
+		if err := ValidateComposition(tx, teamID); err != nil {
+			return ParticipantMerge{}, err
+		}
+	}
+
+	conditionPhoto := Photo{ParticipantID: duplicateID}
+	result := tx.Model(&Photo{}).Where(&conditionPhoto).UpdateColumn("participant_id", survivorID)
+	if result.Error != nil {
+		return ParticipantMerge{}, result.Error
+	}
+
+	conditionRoleChange := RoleChange{ParticipantID: duplicateID}
+	if err := tx.Model(&RoleChange{}).Where(&conditionRoleChange).UpdateColumn("participant_id", survivorID).Error; err != nil {
+		return ParticipantMerge{}, err
+	}
+
+	conditionDeletionRequest := DeletionRequest{ParticipantID: duplicateID}
+	if err := tx.Model(&DeletionRequest{}).Where(&conditionDeletionRequest).UpdateColumn("participant_id", survivorID).Error; err != nil {
+		return ParticipantMerge{}, err
+	}
+
+	// Sertifikat duplikat untuk enrolment yang sudah memiliki sertifikat participant yang dipertahankan
+	// tetap menunjuk participant duplikat agar serial yang telah dibagikan masih dapat diverifikasi
+	conditionCertificate := Certificate{ParticipantID: duplicateID}
+	if err := tx.Model(&Certificate{}).Where(&conditionCertificate).Where("enrolment_id NOT IN (?)", tx.Model(&Certificate{}).Select("enrolment_id").Where(&Certificate{ParticipantID: survivorID})).UpdateColumn("participant_id", survivorID).Error; err != nil {
+		return ParticipantMerge{}, err
+	}
+
+	if err := tx.Delete(&duplicate).Error; err != nil {
+		return ParticipantMerge{}, err
+	}
+
+	participantMerge := ParticipantMerge{SurvivorID: survivorID, DuplicateID: duplicateID, AdminID: adminID, DuplicateName: duplicate.Name, DuplicateEmail: duplicate.Email, MovedMemberships: movedMemberships, MovedPhotos: int(result.RowsAffected)}
+	if err := tx.Create(&participantMerge).Error; err != nil {
+		return ParticipantMerge{}, err
+	}
+
+	return participantMerge, nil
+}
+
+// Membership duplikat pada team yang sama dihapus setelah role leader dan status aktifnya dipindahkan
+// ke membership participant yang dipertahankan
+func mergeMemberships(tx *gorm.DB, survivorMembership Membership, duplicateMembership Membership) error {
+	conditionInvitation := Invitation{MembershipID: duplicateMembership.ID, Status: types.InvitationPending}
+	if err := tx.Model(&Invitation{}).Where(&conditionInvitation).Updates(map[string]interface{}{"status": types.InvitationRevoked, "responded_at": tx.NowFunc()}).Error; err != nil {
+		return err
+	}
+	if err := tx.Unscoped().Delete(&duplicateMembership).Error; err != nil {
+		return err
+	}
+
+	changed := false
+	if duplicateMembership.Role == types.Leader && survivorMembership.Role != types.Leader {
+		survivorMembership.Role = types.Leader
+		changed = true
+	}
+	if duplicateMembership.Status == types.ActiveMembership && survivorMembership.Status != types.ActiveMembership {
+		survivorMembership.Status = types.ActiveMembership
+		changed = true
+
+		conditionSurvivorInvitation := Invitation{MembershipID: survivorMembership.ID, Status: types.InvitationPending}
+		if err := tx.Model(&Invitation{}).Where(&conditionSurvivorInvitation).Updates(map[string]interface{}{"status": types.InvitationAccepted, "responded_at": tx.NowFunc()}).Error; err != nil {
+			return err
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	return tx.Save(&survivorMembership).Error
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"encoding/json"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -11,36 +12,85 @@ import (
 
 type Participant struct {
 	gorm.Model
-	Name           string                           `gorm:"not null;unique"`
-	Email          string                           `gorm:"not null;unique"`
-	CareerInterest types.ParticipantCareerInterests `gorm:"not null"`
-	Status         types.ParticipantStatus          `gorm:"not null"`
-	Memberships    []Membership
-	Photos         []Photo
+	Name             string                           `gorm:"not null;unique"`
+	Email            string                           `gorm:"not null;unique"`
+	CareerInterest   types.ParticipantCareerInterests `gorm:"not null"`
+	Institution      string                           `gorm:"default:null"`
+	EducationLevel   types.EducationLevel             `gorm:"default:null"`
+	StudentIDNumber  string                           `gorm:"default:null"`
+	GraduationYear   int                              `gorm:"default:null"`
+	Phone            string                           `gorm:"default:null"`
+	Status           types.ParticipantStatus          `gorm:"not null"`
+	EmailConfirmedAt time.Time                        `gorm:"default:null"`
+	AnonymisedAt     time.Time                        `gorm:"default:null"`
+	Memberships      []Membership
+	Photos           []Photo
 }
 
 type DisplayParticipant struct {
-	ID             uint                             `json:"id,omitempty"`
-	CreatedAt      time.Time                        `json:"created_at,omitempty"`
-	UpdatedAt      time.Time                        `json:"updated_at,omitempty"`
-	Name           string                           `json:"name,omitempty"`
-	Email          string                           `json:"email,omitempty"`
-	CareerInterest types.ParticipantCareerInterests `json:"career_interest,omitempty"`
-	Status         types.ParticipantStatus          `json:"status,omitempty"`
-	Memberships    []Membership                     `json:"memberships,omitempty"`
-	Photos         []Photo                          `json:"photos,omitempty"`
+	ID               uint                             `json:"id,omitempty"`
+	CreatedAt        time.Time                        `json:"created_at,omitempty"`
+	UpdatedAt        time.Time                        `json:"updated_at,omitempty"`
+	Name             string                           `json:"name,omitempty"`
+	Email            string                           `json:"email,omitempty"`
+	CareerInterest   types.ParticipantCareerInterests `json:"career_interest,omitempty"`
+	Institution      string                           `json:"institution,omitempty"`
+	EducationLevel   types.EducationLevel             `json:"education_level,omitempty"`
+	StudentIDNumber  string                           `json:"student_id_number,omitempty"`
+	GraduationYear   int                              `json:"graduation_year,omitempty"`
+	Phone            string                           `json:"phone,omitempty"`
+	Status           types.ParticipantStatus          `json:"status,omitempty"`
+	EmailConfirmedAt *time.Time                       `json:"email_confirmed_at,omitempty"`
+	AnonymisedAt     *time.Time                       `json:"anonymised_at,omitempty"`
+	Memberships      []Membership                     `json:"memberships,omitempty"`
+	Photos           []Photo                          `json:"photos,omitempty"`
 }
 
 func (participant Participant) MarshalJSON() ([]byte, error) {
+	var emailConfirmedAt *time.Time
+	if !participant.EmailConfirmedAt.IsZero() {
+		emailConfirmedAt = &participant.EmailConfirmedAt
+	}
+
+	var anonymisedAt *time.Time
+	if !participant.AnonymisedAt.IsZero() {
+		anonymisedAt = &participant.AnonymisedAt
+	}
+
 	return json.Marshal(&DisplayParticipant{
-		ID:             participant.ID,
-		CreatedAt:      participant.CreatedAt,
-		UpdatedAt:      participant.UpdatedAt,
-		Name:           participant.Name,
-		Email:          participant.Email,
-		CareerInterest: participant.CareerInterest,
-		Status:         participant.Status,
-		Memberships:    participant.Memberships,
-		Photos:         participant.Photos,
+		ID:               participant.ID,
+		CreatedAt:        participant.CreatedAt,
+		UpdatedAt:        participant.UpdatedAt,
+		Name:             participant.Name,
+		Email:            participant.Email,
+		CareerInterest:   participant.CareerInterest,
+		Institution:      participant.Institution,
+		EducationLevel:   participant.EducationLevel,
+		StudentIDNumber:  participant.StudentIDNumber,
+		GraduationYear:   participant.GraduationYear,
+		Phone:            participant.Phone,
+		Status:           participant.Status,
+		EmailConfirmedAt: emailConfirmedAt,
+		AnonymisedAt:     anonymisedAt,
+		Memberships:      participant.Memberships,
+		Photos:           participant.Photos,
 	})
 }
+
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// Email participant dianggap terkonfirmasi ketika participant membuka tautan yang dikirim ke email tersebut
+func ConfirmEmail(tx *gorm.DB, participantID uint) error {
+	return tx.Model(&Participant{}).Where("id = ? AND email_confirmed_at IS NULL", participantID).Update("email_confirmed_at", tx.NowFunc()).Error
+}
+
+// Email dinormalisasi agar participant yang sama dengan kapitalisasi email berbeda tidak tercatat dua kali
+func (participant *Participant) BeforeSave(tx *gorm.DB) error {
+	if participant.Email != "" {
+		participant.Email = NormalizeEmail(participant.Email)
+	}
+
+	return nil
+}
@@ -19,6 +19,7 @@ type Photo struct {
 	AdminID       uint              `gorm:"default:null"`
 	Status        types.PhotoStatus `gorm:"not null"`
 	Type          types.PhotoType   `gorm:"not null"`
+	WrappedKey    []byte            `gorm:"default:null"`
 	Participant   Participant       `gorm:"foreignKey:ParticipantID;references:ID"`
 	ApprovedBy    Admin             `gorm:"foreignKey:AdminID;references:ID"`
 }
@@ -32,6 +33,7 @@ type DisplayPhoto struct {
 	ParticipantID uint              `json:"participant_id,omitempty"`
 	AdminID       uint              `json:"admin_id,omitempty"`
 	Status        types.PhotoStatus `json:"status,omitempty"`
+	WrappedKey    []byte            `json:"-"`
 }
 
 func (photo Photo) MarshalJSON() ([]byte, error) {
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type Preview struct {
+	gorm.Model
+	FileName      uuid.UUID           `gorm:"type:uuid;unique"`
+	FileExtension string              `gorm:"default:null"`
+	ContentType   string              `gorm:"default:null"`
+	SubmissionID  uint                `gorm:"not null;unique"`
+	Status        types.PreviewStatus `gorm:"not null"`
+	Submission    Submission          `gorm:"foreignKey:SubmissionID;references:ID"`
+}
+
+type DisplayPreview struct {
+	ID            uint                `json:"id,omitempty"`
+	CreatedAt     time.Time           `json:"created_at,omitempty"`
+	UpdatedAt     time.Time           `json:"updated_at,omitempty"`
+	FileName      uuid.UUID           `json:"file_name,omitempty"`
+	FileExtension string              `json:"file_extension,omitempty"`
+	ContentType   string              `json:"content_type,omitempty"`
+	SubmissionID  uint                `json:"submission_id,omitempty"`
+	Status        types.PreviewStatus `json:"status,omitempty"`
+}
+
+func (preview Preview) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&DisplayPreview{
+		ID:            preview.ID,
+		CreatedAt:     preview.CreatedAt,
+		UpdatedAt:     preview.UpdatedAt,
+		FileName:      preview.FileName,
+		FileExtension: preview.FileExtension,
+		ContentType:   preview.ContentType,
+		SubmissionID:  preview.SubmissionID,
+		Status:        preview.Status,
+	})
+}
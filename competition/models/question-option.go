@@ -0,0 +1,34 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type QuestionOption struct {
+	gorm.Model
+	QuestionID uint     `gorm:"not null"`
+	Content    string   `gorm:"not null"`
+	IsCorrect  bool     `gorm:"not null;default:false"`
+	Question   Question `gorm:"foreignKey:QuestionID;references:ID"`
+}
+
+type DisplayQuestionOption struct {
+	ID         uint      `json:"id,omitempty"`
+	CreatedAt  time.Time `json:"created_at,omitempty"`
+	UpdatedAt  time.Time `json:"updated_at,omitempty"`
+	QuestionID uint      `json:"question_id,omitempty"`
+	Content    string    `json:"content,omitempty"`
+}
+
+func (questionOption QuestionOption) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&DisplayQuestionOption{
+		ID:         questionOption.ID,
+		CreatedAt:  questionOption.CreatedAt,
+		UpdatedAt:  questionOption.UpdatedAt,
+		QuestionID: questionOption.QuestionID,
+		Content:    questionOption.Content,
+	})
+}
@@ -0,0 +1,106 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type Question struct {
+	gorm.Model
+	ExamID  uint               `gorm:"not null"`
+	Type    types.QuestionType `gorm:"not null"`
+	Content string             `gorm:"not null"`
+	Point   float64            `gorm:"not null"`
+	Order   int                `gorm:"not null;default:0"`
+	Key     string             `gorm:"default:null"`
+	Exam    Exam               `gorm:"foreignKey:ExamID;references:ID"`
+	Options []QuestionOption
+}
+
+// NOTE: Kunci jawaban tidak pernah ditampilkan, hanya disertakan pada ekspor jawaban untuk admin
+type DisplayQuestion struct {
+	ID        uint               `json:"id,omitempty"`
+	CreatedAt time.Time          `json:"created_at,omitempty"`
+	UpdatedAt time.Time          `json:"updated_at,omitempty"`
+	ExamID    uint               `json:"exam_id,omitempty"`
+	Type      types.QuestionType `json:"type,omitempty"`
+	Content   string             `json:"content,omitempty"`
+	Point     float64            `json:"point,omitempty"`
+	Order     int                `json:"order"`
+	Options   []QuestionOption   `json:"options,omitempty"`
+}
+
+func (question Question) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&DisplayQuestion{
+		ID:        question.ID,
+		CreatedAt: question.CreatedAt,
+		UpdatedAt: question.UpdatedAt,
+		ExamID:    question.ExamID,
+		Type:      question.Type,
+		Content:   question.Content,
+		Point:     question.Point,
+		Order:     question.Order,
+		Options:   question.Options,
+	})
+}
+
+// Menambahkan constraint untuk mengecek apakah poin soal bernilai positif
+func (question *Question) BeforeSave(tx *gorm.DB) error {
+	if question.Point <= 0 {
+		return fmt.Errorf("ERROR: POINT MUST BE POSITIVE")
+	}
+
+	return nil
+}
+
+// Menambahkan constraint untuk mengecek apakah soal pilihan ganda memiliki tepat satu pilihan jawaban yang benar
+func (question *Question) BeforeCreate(tx *gorm.DB) error {
+	if question.Type != types.MultipleChoice {
+		return nil
+	}
+
+	correctCount := 0
+	for _, option := range question.Options {
+		if option.IsCorrect {
+			correctCount++
+		}
+	}
+	if len(question.Options) < 2 || correctCount != 1 {
+		return fmt.Errorf("ERROR: MULTIPLE CHOICE QUESTION MUST HAVE EXACTLY ONE CORRECT OPTION")
+	}
+
+	return nil
+}
+
+// Soal isian singkat tanpa kunci jawaban harus dinilai secara manual
+func (question Question) IsAutoGraded() bool {
+	return question.Type == types.MultipleChoice || question.Key != ""
+}
+
+// Kunci jawaban isian singkat dapat berisi beberapa alternatif yang dipisahkan dengan "|"
+func (question Question) IsCorrect(answer Answer) bool {
+	switch question.Type {
+	case types.MultipleChoice:
+		for _, option := range question.Options {
+			if answer.OptionID != nil && option.ID == *answer.OptionID {
+				return option.IsCorrect
+			}
+		}
+		return false
+	case types.ShortAnswer:
+		for _, key := range strings.Split(question.Key, "|") {
+			if strings.EqualFold(strings.Join(strings.Fields(key), " "), strings.Join(strings.Fields(answer.Text), " ")) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
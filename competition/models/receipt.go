@@ -0,0 +1,65 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+// Receipt menyimpan salinan data submission pada saat diterima beserta kode yang telah ditandatangani server
+type Receipt struct {
+	gorm.Model
+	Serial       uuid.UUID             `gorm:"type:uuid;not null;unique"`
+	SubmissionID uint                  `gorm:"not null;unique"`
+	TeamID       uint                  `gorm:"not null"`
+	TeamName     string                `gorm:"not null"`
+	Stage        types.SubmissionStage `gorm:"not null"`
+	Version      uint                  `gorm:"not null"`
+	Checksum     string                `gorm:"not null"`
+	FileSize     int64                 `gorm:"not null"`
+	SubmittedAt  time.Time             `gorm:"not null"`
+	IsLate       bool                  `gorm:"not null;default:false"`
+	Code         string                `gorm:"not null"`
+	Submission   Submission            `gorm:"foreignKey:SubmissionID;references:ID"`
+	Team         Team                  `gorm:"foreignKey:TeamID;references:ID"`
+}
+
+type DisplayReceipt struct {
+	ID           uint                  `json:"id,omitempty"`
+	CreatedAt    time.Time             `json:"created_at,omitempty"`
+	UpdatedAt    time.Time             `json:"updated_at,omitempty"`
+	Serial       uuid.UUID             `json:"serial,omitempty"`
+	SubmissionID uint                  `json:"submission_id,omitempty"`
+	TeamID       uint                  `json:"team_id,omitempty"`
+	TeamName     string                `json:"team_name,omitempty"`
+	Stage        types.SubmissionStage `json:"stage,omitempty"`
+	Version      uint                  `json:"version,omitempty"`
+	Checksum     string                `json:"checksum,omitempty"`
+	FileSize     int64                 `json:"file_size,omitempty"`
+	SubmittedAt  time.Time             `json:"submitted_at,omitempty"`
+	IsLate       bool                  `json:"is_late,omitempty"`
+	Code         string                `json:"code,omitempty"`
+}
+
+func (receipt Receipt) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&DisplayReceipt{
+		ID:           receipt.ID,
+		CreatedAt:    receipt.CreatedAt,
+		UpdatedAt:    receipt.UpdatedAt,
+		Serial:       receipt.Serial,
+		SubmissionID: receipt.SubmissionID,
+		TeamID:       receipt.TeamID,
+		TeamName:     receipt.TeamName,
+		Stage:        receipt.Stage,
+		Version:      receipt.Version,
+		Checksum:     receipt.Checksum,
+		FileSize:     receipt.FileSize,
+		SubmittedAt:  receipt.SubmittedAt,
+		IsLate:       receipt.IsLate,
+		Code:         receipt.Code,
+	})
+}
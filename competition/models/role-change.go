@@ -0,0 +1,180 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type RoleChange struct {
+	gorm.Model
+	TeamID        uint                 `gorm:"not null"`
+	ParticipantID uint                 `gorm:"not null"`
+	PreviousRole  types.MembershipRole `gorm:"not null"`
+	NewRole       types.MembershipRole `gorm:"not null"`
+	ActorID       uint                 `gorm:"default:null"`
+	ActorRole     string               `gorm:"default:null"`
+	Team          Team                 `gorm:"foreignKey:TeamID;references:ID"`
+	Participant   Participant          `gorm:"foreignKey:ParticipantID;references:ID"`
+}
+
+type DisplayRoleChange struct {
+	ID            uint                 `json:"id,omitempty"`
+	CreatedAt     time.Time            `json:"created_at,omitempty"`
+	UpdatedAt     time.Time            `json:"updated_at,omitempty"`
+	TeamID        uint                 `json:"team_id,omitempty"`
+	ParticipantID uint                 `json:"participant_id,omitempty"`
+	PreviousRole  types.MembershipRole `json:"previous_role,omitempty"`
+	NewRole       types.MembershipRole `json:"new_role,omitempty"`
+	ActorID       uint                 `json:"actor_id,omitempty"`
+	ActorRole     string               `json:"actor_role,omitempty"`
+}
+
+func (roleChange RoleChange) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&DisplayRoleChange{
+		ID:            roleChange.ID,
+		CreatedAt:     roleChange.CreatedAt,
+		UpdatedAt:     roleChange.UpdatedAt,
+		TeamID:        roleChange.TeamID,
+		ParticipantID: roleChange.ParticipantID,
+		PreviousRole:  roleChange.PreviousRole,
+		NewRole:       roleChange.NewRole,
+		ActorID:       roleChange.ActorID,
+		ActorRole:     roleChange.ActorRole,
+	})
+}
+
+// Seluruh membership team dikunci agar tidak ada perubahan role lain yang berjalan bersamaan
+func lockMemberships(tx *gorm.DB, teamID uint) ([]Membership, error) {
+	condition := Membership{TeamID: teamID}
+	memberships := []Membership{}
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(&condition).Order("id").Find(&memberships).Error; err != nil {
+		return nil, err
+	}
+
+	return memberships, nil
+}
+
+// Pihak yang melakukan perubahan role dicatat bersama perubahannya
+type RoleActor struct {
+	ID   uint
+	Role string
+}
+
+// Membership disimpan secara utuh agar constraint BeforeSave (leader ganda) ikut dicek
+func setRole(tx *gorm.DB, membership Membership, role types.MembershipRole, actor RoleActor) error {
+	if membership.Role == role {
+		return nil
+	}
+
+	previousRole := membership.Role
+	membership.Role = role
+	if err := tx.Save(&membership).Error; err != nil {
+		return err
+	}
+
+	roleChange := RoleChange{TeamID: membership.TeamID, ParticipantID: membership.ParticipantID, PreviousRole: previousRole, NewRole: role, ActorID: actor.ID, ActorRole: actor.Role}
+	if err := tx.Create(&roleChange).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Leader lama diturunkan menjadi member dan successor dinaikkan menjadi leader dalam satu transaksi
+func TransferLeadership(tx *gorm.DB, teamID uint, successorID uint, actor RoleActor) error {
+	memberships, err := lockMemberships(tx, teamID)
+	if err != nil {
+		return err
+	}
+
+	var successor *Membership
+	leaders := []Membership{}
+	for i, membership := range memberships {
+		if membership.ParticipantID == successorID {
+			successor = &memberships[i]
+		}
+		if membership.Role == types.Leader {
+			leaders = append(leaders, membership)
+		}
+	}
+
+	if successor == nil {
+		return fmt.Errorf("ERROR: SUCCESSOR IS NOT A MEMBER OF THE TEAM")
+	}
+	if successor.Status != types.ActiveMembership {
+		return fmt.Errorf("ERROR: SUCCESSOR HAS NOT ACCEPTED THE INVITATION")
+	}
+	if successor.Role == types.Leader {
+		return fmt.Errorf("ERROR: SUCCESSOR IS ALREADY THE LEADER")
+	}
+
+	for _, leader := range leaders {
+		if err := setRole(tx, leader, types.Member, actor); err != nil {
+			return err
+		}
+	}
+
+	return setRole(tx, *successor, types.Leader, actor)
+}
+
+// Perubahan role menjadi leader dilakukan melalui transfer leadership,
+// sedangkan leader tidak dapat diturunkan tanpa menunjuk successor
+func ChangeRole(tx *gorm.DB, teamID uint, participantID uint, role types.MembershipRole, actor RoleActor) error {
+	if role == types.Leader {
+		return TransferLeadership(tx, teamID, participantID, actor)
+	}
+
+	memberships, err := lockMemberships(tx, teamID)
+	if err != nil {
+		return err
+	}
+
+	for _, membership := range memberships {
+		if membership.ParticipantID != participantID {
+			continue
+		}
+		if membership.Role == types.Leader {
+			return fmt.Errorf("ERROR: LEADER MUST TRANSFER LEADERSHIP TO A SUCCESSOR")
+		}
+
+		return setRole(tx, membership, role, actor)
+	}
+
+	return fmt.Errorf("ERROR: PARTICIPANT IS NOT A MEMBER OF THE TEAM")
+}
+
+// Leader yang dihapus dari team harus menunjuk successor terlebih dahulu
+func RemoveMember(tx *gorm.DB, teamID uint, participantID uint, successorID uint, actor RoleActor) error {
+	memberships, err := lockMemberships(tx, teamID)
+	if err != nil {
+		return err
+	}
+
+	for _, membership := range memberships {
+		if membership.ParticipantID != participantID {
+			continue
+		}
+
+		if membership.Role == types.Leader {
+			if successorID == 0 {
+				return fmt.Errorf("ERROR: SUCCESSOR IS REQUIRED TO REMOVE THE LEADER")
+			}
+			if successorID == participantID {
+				return fmt.Errorf("ERROR: SUCCESSOR MUST BE ANOTHER MEMBER")
+			}
+			if err := TransferLeadership(tx, teamID, successorID, actor); err != nil {
+				return err
+			}
+		}
+
+		return tx.Delete(&membership).Error
+	}
+
+	return fmt.Errorf("ERROR: PARTICIPANT IS NOT A MEMBER OF THE TEAM")
+}
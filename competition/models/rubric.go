@@ -0,0 +1,41 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type Rubric struct {
+	gorm.Model
+	TeamCategory types.TeamCategory    `gorm:"not null;uniqueIndex:rubric_index"`
+	Stage        types.SubmissionStage `gorm:"not null;uniqueIndex:rubric_index"`
+	AdminID      uint                  `gorm:"not null"`
+	CreatedBy    Admin                 `gorm:"foreignKey:AdminID;references:ID"`
+	Criteria     []Criterion
+}
+
+type DisplayRubric struct {
+	ID           uint                  `json:"id,omitempty"`
+	CreatedAt    time.Time             `json:"created_at,omitempty"`
+	UpdatedAt    time.Time             `json:"updated_at,omitempty"`
+	TeamCategory types.TeamCategory    `json:"team_category,omitempty"`
+	Stage        types.SubmissionStage `json:"stage,omitempty"`
+	AdminID      uint                  `json:"admin_id,omitempty"`
+	Criteria     []Criterion           `json:"criteria,omitempty"`
+}
+
+func (rubric Rubric) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&DisplayRubric{
+		ID:           rubric.ID,
+		CreatedAt:    rubric.CreatedAt,
+		UpdatedAt:    rubric.UpdatedAt,
+		TeamCategory: rubric.TeamCategory,
+		Stage:        rubric.Stage,
+		AdminID:      rubric.AdminID,
+		Criteria:     rubric.Criteria,
+	})
+}
@@ -0,0 +1,141 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type Score struct {
+	gorm.Model
+	AssignmentID uint       `gorm:"not null;uniqueIndex:score_index"`
+	CriterionID  uint       `gorm:"not null;uniqueIndex:score_index"`
+	Value        float64    `gorm:"not null"`
+	Comment      string     `gorm:"default:null"`
+	Assignment   Assignment `gorm:"foreignKey:AssignmentID;references:ID"`
+	Criterion    Criterion  `gorm:"foreignKey:CriterionID;references:ID"`
+}
+
+type DisplayScore struct {
+	ID           uint      `json:"id,omitempty"`
+	CreatedAt    time.Time `json:"created_at,omitempty"`
+	UpdatedAt    time.Time `json:"updated_at,omitempty"`
+	AssignmentID uint      `json:"assignment_id,omitempty"`
+	CriterionID  uint      `json:"criterion_id,omitempty"`
+	Value        float64   `json:"value"`
+	Comment      string    `json:"comment,omitempty"`
+}
+
+func (score Score) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&DisplayScore{
+		ID:           score.ID,
+		CreatedAt:    score.CreatedAt,
+		UpdatedAt:    score.UpdatedAt,
+		AssignmentID: score.AssignmentID,
+		CriterionID:  score.CriterionID,
+		Value:        score.Value,
+		Comment:      score.Comment,
+	})
+}
+
+// Menambahkan constraint untuk mengecek apakah nilai berada di antara nol dan nilai maksimum kriteria
+func (score *Score) BeforeSave(tx *gorm.DB) error {
+	if score.CriterionID != 0 {
+		condition := Criterion{Model: gorm.Model{ID: score.CriterionID}}
+		criterion := Criterion{}
+		if err := tx.Where(&condition).First(&criterion).Error; err != nil {
+			return err
+		}
+
+		if score.Value < 0 || score.Value > criterion.MaxScore {
+			return fmt.Errorf("ERROR: SCORE OUT OF RANGE")
+		}
+	}
+
+	return nil
+}
+
+type Ranking struct {
+	Rank          int     `json:"rank"`
+	TeamID        uint    `json:"team_id"`
+	EnrolmentID   uint    `json:"enrolment_id"`
+	TeamName      string  `json:"team_name"`
+	SubmissionID  uint    `json:"submission_id"`
+	NumberOfJudge int     `json:"number_of_judge"`
+	WeightedScore float64 `json:"weighted_score"`
+}
+
+// Menghitung peringkat team berdasarkan submission final pada suatu kategori dan stage
+// Nilai setiap kriteria dirata-ratakan antar juri, dinormalisasi terhadap nilai maksimum, lalu dibobot (skala 0-100)
+func GetRankings(tx *gorm.DB, teamCategory types.TeamCategory, stage types.SubmissionStage) ([]Ranking, error) {
+	conditionRubric := Rubric{TeamCategory: teamCategory, Stage: stage}
+	rubric := Rubric{}
+	if err := tx.Preload("Criteria").Where(&conditionRubric).First(&rubric).Error; err != nil {
+		return nil, fmt.Errorf("ERROR: RUBRIC NOT FOUND")
+	}
+
+	totalWeight := 0.0
+	criteria := map[uint]Criterion{}
+	for _, criterion := range rubric.Criteria {
+		totalWeight += criterion.Weight
+		criteria[criterion.ID] = criterion
+	}
+	if totalWeight == 0 {
+		return nil, fmt.Errorf("ERROR: RUBRIC HAS NO CRITERIA")
+	}
+
+	submissions := []Submission{}
+	if err := tx.Preload("Team").Joins("JOIN enrolments ON enrolments.id = submissions.enrolment_id AND enrolments.deleted_at IS NULL").Where("enrolments.team_category = ? AND submissions.stage = ? AND submissions.is_final", teamCategory, stage).Find(&submissions).Error; err != nil {
+		return nil, err
+	}
+
+	rankings := []Ranking{}
+	for _, submission := range submissions {
+		conditionAssignment := Assignment{SubmissionID: submission.ID}
+		assignments := []Assignment{}
+		if err := tx.Preload("Scores").Where(&conditionAssignment).Find(&assignments).Error; err != nil {
+			return nil, err
+		}
+
+		sums := map[uint]float64{}
+		counts := map[uint]int{}
+		for _, assignment := range assignments {
+			for _, score := range assignment.Scores {
+				sums[score.CriterionID] += score.Value
+				counts[score.CriterionID]++
+			}
+		}
+
+		weightedScore := 0.0
+		for criterionID, criterion := range criteria {
+			if counts[criterionID] == 0 {
+				continue
+			}
+			average := sums[criterionID] / float64(counts[criterionID])
+			weightedScore += average / criterion.MaxScore * criterion.Weight
+		}
+
+		rankings = append(rankings, Ranking{
+			TeamID:        submission.TeamID,
+			EnrolmentID:   submission.EnrolmentID,
+			TeamName:      submission.Team.TeamName,
+			SubmissionID:  submission.ID,
+			NumberOfJudge: len(assignments),
+			WeightedScore: weightedScore / totalWeight * 100,
+		})
+	}
+
+	sort.SliceStable(rankings, func(i, j int) bool {
+		return rankings[i].WeightedScore > rankings[j].WeightedScore
+	})
+	for i := range rankings {
+		rankings[i].Rank = i + 1
+	}
+
+	return rankings, nil
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type SimilarityAnalysis struct {
+	gorm.Model
+	TeamCategory types.TeamCategory    `gorm:"not null"`
+	Stage        types.SubmissionStage `gorm:"not null"`
+	Threshold    float64               `gorm:"not null"`
+	Status       types.AnalysisStatus  `gorm:"not null"`
+	AdminID      uint                  `gorm:"not null"`
+	RequestedBy  Admin                 `gorm:"foreignKey:AdminID;references:ID"`
+	Reports      []SimilarityReport    `gorm:"foreignKey:AnalysisID"`
+}
+
+type DisplaySimilarityAnalysis struct {
+	ID           uint                  `json:"id,omitempty"`
+	CreatedAt    time.Time             `json:"created_at,omitempty"`
+	UpdatedAt    time.Time             `json:"updated_at,omitempty"`
+	TeamCategory types.TeamCategory    `json:"team_category,omitempty"`
+	Stage        types.SubmissionStage `json:"stage,omitempty"`
+	Threshold    float64               `json:"threshold"`
+	Status       types.AnalysisStatus  `json:"status,omitempty"`
+	AdminID      uint                  `json:"admin_id,omitempty"`
+	Reports      []SimilarityReport    `json:"reports,omitempty"`
+}
+
+func (similarityAnalysis SimilarityAnalysis) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&DisplaySimilarityAnalysis{
+		ID:           similarityAnalysis.ID,
+		CreatedAt:    similarityAnalysis.CreatedAt,
+		UpdatedAt:    similarityAnalysis.UpdatedAt,
+		TeamCategory: similarityAnalysis.TeamCategory,
+		Stage:        similarityAnalysis.Stage,
+		Threshold:    similarityAnalysis.Threshold,
+		Status:       similarityAnalysis.Status,
+		AdminID:      similarityAnalysis.AdminID,
+		Reports:      similarityAnalysis.Reports,
+	})
+}
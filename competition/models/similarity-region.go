@@ -0,0 +1,33 @@
+package models
+
+import (
+	"encoding/json"
+
+	"gorm.io/gorm"
+)
+
+type SimilarityRegion struct {
+	gorm.Model
+	ReportID       uint             `gorm:"not null"`
+	LeftStartLine  int              `gorm:"not null"`
+	LeftEndLine    int              `gorm:"not null"`
+	RightStartLine int              `gorm:"not null"`
+	RightEndLine   int              `gorm:"not null"`
+	Report         SimilarityReport `gorm:"foreignKey:ReportID;references:ID"`
+}
+
+type DisplaySimilarityRegion struct {
+	LeftStartLine  int `json:"left_start_line"`
+	LeftEndLine    int `json:"left_end_line"`
+	RightStartLine int `json:"right_start_line"`
+	RightEndLine   int `json:"right_end_line"`
+}
+
+func (similarityRegion SimilarityRegion) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&DisplaySimilarityRegion{
+		LeftStartLine:  similarityRegion.LeftStartLine,
+		LeftEndLine:    similarityRegion.LeftEndLine,
+		RightStartLine: similarityRegion.RightStartLine,
+		RightEndLine:   similarityRegion.RightEndLine,
+	})
+}
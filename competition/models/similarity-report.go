@@ -0,0 +1,53 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type SimilarityReport struct {
+	gorm.Model
+	AnalysisID        uint                 `gorm:"not null"`
+	LeftSubmissionID  uint                 `gorm:"not null"`
+	RightSubmissionID uint                 `gorm:"not null"`
+	Kind              types.SimilarityKind `gorm:"not null"`
+	Score             float64              `gorm:"not null"`
+	Analysis          SimilarityAnalysis   `gorm:"foreignKey:AnalysisID;references:ID"`
+	LeftSubmission    Submission           `gorm:"foreignKey:LeftSubmissionID;references:ID"`
+	RightSubmission   Submission           `gorm:"foreignKey:RightSubmissionID;references:ID"`
+	Regions           []SimilarityRegion   `gorm:"foreignKey:ReportID"`
+}
+
+type DisplaySimilarityReport struct {
+	ID                uint                 `json:"id,omitempty"`
+	CreatedAt         time.Time            `json:"created_at,omitempty"`
+	UpdatedAt         time.Time            `json:"updated_at,omitempty"`
+	AnalysisID        uint                 `json:"analysis_id,omitempty"`
+	LeftSubmissionID  uint                 `json:"left_submission_id,omitempty"`
+	LeftTeamID        uint                 `json:"left_team_id,omitempty"`
+	RightSubmissionID uint                 `json:"right_submission_id,omitempty"`
+	RightTeamID       uint                 `json:"right_team_id,omitempty"`
+	Kind              types.SimilarityKind `json:"kind,omitempty"`
+	Score             float64              `json:"score"`
+	Regions           []SimilarityRegion   `json:"regions,omitempty"`
+}
+
+func (similarityReport SimilarityReport) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&DisplaySimilarityReport{
+		ID:                similarityReport.ID,
+		CreatedAt:         similarityReport.CreatedAt,
+		UpdatedAt:         similarityReport.UpdatedAt,
+		AnalysisID:        similarityReport.AnalysisID,
+		LeftSubmissionID:  similarityReport.LeftSubmissionID,
+		LeftTeamID:        similarityReport.LeftSubmission.TeamID,
+		RightSubmissionID: similarityReport.RightSubmissionID,
+		RightTeamID:       similarityReport.RightSubmission.TeamID,
+		Kind:              similarityReport.Kind,
+		Score:             similarityReport.Score,
+		Regions:           similarityReport.Regions,
+	})
+}
@@ -0,0 +1,137 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type StageTransition struct {
+	gorm.Model
+	TeamID      uint                  `gorm:"not null"`
+	EnrolmentID uint                  `gorm:"default:null"`
+	FromStage   types.SubmissionStage `gorm:"not null"`
+	ToStage     types.SubmissionStage `gorm:"not null"`
+	Status      types.TeamStatus      `gorm:"not null"`
+	AdminID     uint                  `gorm:"not null"`
+	Team        Team                  `gorm:"foreignKey:TeamID;references:ID"`
+	Enrolment   Enrolment             `gorm:"foreignKey:EnrolmentID;references:ID"`
+	DecidedBy   Admin                 `gorm:"foreignKey:AdminID;references:ID"`
+}
+
+type DisplayStageTransition struct {
+	ID          uint                  `json:"id,omitempty"`
+	CreatedAt   time.Time             `json:"created_at,omitempty"`
+	UpdatedAt   time.Time             `json:"updated_at,omitempty"`
+	TeamID      uint                  `json:"team_id,omitempty"`
+	EnrolmentID uint                  `json:"enrolment_id,omitempty"`
+	FromStage   types.SubmissionStage `json:"from_stage,omitempty"`
+	ToStage     types.SubmissionStage `json:"to_stage,omitempty"`
+	Status      types.TeamStatus      `json:"status,omitempty"`
+	AdminID     uint                  `json:"admin_id,omitempty"`
+}
+
+func (stageTransition StageTransition) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&DisplayStageTransition{
+		ID:          stageTransition.ID,
+		CreatedAt:   stageTransition.CreatedAt,
+		UpdatedAt:   stageTransition.UpdatedAt,
+		TeamID:      stageTransition.TeamID,
+		EnrolmentID: stageTransition.EnrolmentID,
+		FromStage:   stageTransition.FromStage,
+		ToStage:     stageTransition.ToStage,
+		Status:      stageTransition.Status,
+		AdminID:     stageTransition.AdminID,
+	})
+}
+
+// Mengubah status enrolment team pada stage saat ini sesuai dengan pipeline jenis lombanya
+// Enrolment yang lolos dan masih memiliki stage berikutnya akan dipindahkan ke stage tersebut dengan status menunggu evaluasi,
+// sedangkan enrolment yang tereliminasi tetap pada stage saat ini sehingga seluruh pengumpulan berikutnya terkunci
+func TransitionEnrolment(tx *gorm.DB, enrolmentID uint, status types.TeamStatus, adminID uint) (StageTransition, error) {
+	condition := Enrolment{Model: gorm.Model{ID: enrolmentID}}
+	enrolment := Enrolment{}
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(&condition).Find(&enrolment).Error; err != nil {
+		return StageTransition{}, err
+	}
+	if enrolment.ID == 0 {
+		return StageTransition{}, fmt.Errorf("ERROR: ENROLMENT NOT FOUND")
+	}
+
+	conditionTeam := Team{Model: gorm.Model{ID: enrolment.TeamID}}
+	if err := tx.Where(&conditionTeam).First(&enrolment.Team).Error; err != nil {
+		return StageTransition{}, err
+	}
+
+	stageTransition := StageTransition{TeamID: enrolment.TeamID, EnrolmentID: enrolment.ID, FromStage: enrolment.GetCurrentStage(), ToStage: enrolment.GetCurrentStage(), Status: status, AdminID: adminID}
+	updates := map[string]interface{}{"stage": stageTransition.FromStage, "status": status, "admin_id": adminID}
+	enrolment.Status = status
+	enrolment.AdminID = adminID
+
+	if status == types.WaitingForEvaluation {
+		updates["admin_id"] = nil
+		enrolment.AdminID = 0
+	}
+	if status == types.Passed {
+		if nextStage, exists := enrolment.TeamCategory.GetNextStage(stageTransition.FromStage); exists {
+			if nextStage == types.FinalStage {
+				categoryQuota, err := LockCategoryQuota(tx, enrolment.TeamCategory)
+				if err != nil {
+					return StageTransition{}, err
+				}
+				if err := categoryQuota.CheckFinalCapacity(tx); err != nil {
+					return StageTransition{}, err
+				}
+			}
+
+			stageTransition.ToStage = nextStage
+			updates["stage"] = nextStage
+			updates["status"] = types.WaitingForEvaluation
+			updates["admin_id"] = nil
+			enrolment.Status = types.WaitingForEvaluation
+			enrolment.AdminID = 0
+		}
+	}
+	enrolment.Stage = stageTransition.ToStage
+
+	if err := tx.Model(&Enrolment{Model: gorm.Model{ID: enrolment.ID}}).Updates(updates).Error; err != nil {
+		return StageTransition{}, err
+	}
+	if err := tx.Create(&stageTransition).Error; err != nil {
+		return StageTransition{}, err
+	}
+
+	stageTransition.Team = enrolment.Team
+	stageTransition.Enrolment = enrolment
+	return stageTransition, nil
+}
+
+// Mengembalikan subject dan nama template email (static/html) yang dikirimkan kepada team
+// NOTE: Pengembalian status menjadi menunggu evaluasi tidak dianggap sebagai perpindahan stage
+func (stageTransition StageTransition) GetMailTemplate() (string, string, bool) {
+	switch stageTransition.Status {
+	case types.Passed:
+		if stageTransition.ToStage != stageTransition.FromStage {
+			return "Stage Result: Advanced", "stage-advanced", true
+		}
+		return "Stage Result: Passed", "stage-passed", true
+	case types.Eliminated:
+		return "Stage Result: Eliminated", "stage-eliminated", true
+	default:
+		return "", "", false
+	}
+}
+
+func (stageTransition StageTransition) GetMailData() map[string]interface{} {
+	return map[string]interface{}{
+		"TeamName":     stageTransition.Team.TeamName,
+		"TeamCategory": stageTransition.Enrolment.TeamCategory,
+		"FromStage":    stageTransition.FromStage,
+		"ToStage":      stageTransition.ToStage,
+	}
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type SubmissionWindow struct {
+	gorm.Model
+	TeamCategory types.TeamCategory    `gorm:"not null;uniqueIndex:submission_window_index"`
+	Stage        types.SubmissionStage `gorm:"not null;uniqueIndex:submission_window_index"`
+	OpenAt       time.Time             `gorm:"not null"`
+	CloseAt      time.Time             `gorm:"not null"`
+	GracePeriod  uint                  `gorm:"not null;default:0"`
+	AdminID      uint                  `gorm:"not null"`
+	SetBy        Admin                 `gorm:"foreignKey:AdminID;references:ID"`
+}
+
+type DisplaySubmissionWindow struct {
+	ID           uint                  `json:"id,omitempty"`
+	CreatedAt    time.Time             `json:"created_at,omitempty"`
+	UpdatedAt    time.Time             `json:"updated_at,omitempty"`
+	TeamCategory types.TeamCategory    `json:"team_category,omitempty"`
+	Stage        types.SubmissionStage `json:"stage,omitempty"`
+	OpenAt       time.Time             `json:"open_at,omitempty"`
+	CloseAt      time.Time             `json:"close_at,omitempty"`
+	GracePeriod  uint                  `json:"grace_period,omitempty"`
+}
+
+func (submissionWindow SubmissionWindow) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&DisplaySubmissionWindow{
+		ID:           submissionWindow.ID,
+		CreatedAt:    submissionWindow.CreatedAt,
+		UpdatedAt:    submissionWindow.UpdatedAt,
+		TeamCategory: submissionWindow.TeamCategory,
+		Stage:        submissionWindow.Stage,
+		OpenAt:       submissionWindow.OpenAt,
+		CloseAt:      submissionWindow.CloseAt,
+		GracePeriod:  submissionWindow.GracePeriod,
+	})
+}
+
+// Mengembalikan batas waktu pengumpulan sebuah team dengan memperhitungkan perpanjangan dari admin
+// (GracePeriod dalam satuan detik tidak termasuk di dalamnya)
+func (submissionWindow SubmissionWindow) GetCloseAt(tx *gorm.DB, teamID uint) (time.Time, error) {
+	condition := DeadlineExtension{TeamID: teamID, TeamCategory: submissionWindow.TeamCategory, Stage: submissionWindow.Stage}
+	extension := DeadlineExtension{}
+	if err := tx.Where(&condition).Find(&extension).Error; err != nil {
+		return time.Time{}, err
+	}
+
+	if extension.ID != 0 && extension.CloseAt.After(submissionWindow.CloseAt) {
+		return extension.CloseAt, nil
+	}
+
+	return submissionWindow.CloseAt, nil
+}
+
+// Mengecek apakah jendela waktu pengumpulan sebuah team telah ditutup termasuk masa tenggangnya
+func (submissionWindow SubmissionWindow) IsClosed(tx *gorm.DB, teamID uint) (bool, error) {
+	closeAt, err := submissionWindow.GetCloseAt(tx, teamID)
+	if err != nil {
+		return false, err
+	}
+
+	return tx.NowFunc().After(closeAt.Add(time.Duration(submissionWindow.GracePeriod) * time.Second)), nil
+}
+
+// Menambahkan constraint untuk mengecek apakah waktu dibuka lebih awal dari waktu ditutup
+func (submissionWindow *SubmissionWindow) BeforeSave(tx *gorm.DB) error {
+	if !submissionWindow.OpenAt.IsZero() && !submissionWindow.CloseAt.IsZero() && !submissionWindow.OpenAt.Before(submissionWindow.CloseAt) {
+		return fmt.Errorf("ERROR: INVALID SUBMISSION WINDOW")
+	}
+
+	return nil
+}
+
+// Jendela waktu pengumpulan bersifat opsional, stage yang belum memiliki jendela waktu dianggap selalu terbuka
+func FindSubmissionWindow(tx *gorm.DB, teamCategory types.TeamCategory, stage types.SubmissionStage) (SubmissionWindow, bool, error) {
+	conditionWindow := SubmissionWindow{TeamCategory: teamCategory, Stage: stage}
+	submissionWindow := SubmissionWindow{}
+	if err := tx.Where(&conditionWindow).Find(&submissionWindow).Error; err != nil {
+		return SubmissionWindow{}, false, err
+	}
+
+	return submissionWindow, submissionWindow.ID != 0, nil
+}
@@ -2,10 +2,12 @@ package models
 
 import (
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"arkavidia-backend-8.0/competition/types"
 )
@@ -15,8 +17,15 @@ type Submission struct {
 	FileName      uuid.UUID             `gorm:"type:uuid;unique"`
 	FileExtension string                `gorm:"not null"`
 	TeamID        uint                  `gorm:"not null"`
-	Stage         types.SubmissionStage `gorm:"not null"`
-	Team          Team                  `gorm:"foreignKey:TeamID;references:ID"`
+	EnrolmentID   uint                  `gorm:"default:null;uniqueIndex:submission_version_index"`
+	Stage         types.SubmissionStage `gorm:"not null;uniqueIndex:submission_version_index"`
+	Version       uint                  `gorm:"not null;uniqueIndex:submission_version_index"`
+	IsFinal       bool                  `gorm:"not null;default:false"`
+	IsLate        bool                  `gorm:"not null;default:false"`
+	Score         *DatavidiaScore
+	Receipt       *Receipt
+	Team          Team      `gorm:"foreignKey:TeamID;references:ID"`
+	Enrolment     Enrolment `gorm:"foreignKey:EnrolmentID;references:ID"`
 }
 
 type DisplaySubmission struct {
@@ -26,7 +35,13 @@ type DisplaySubmission struct {
 	FileName      uuid.UUID             `json:"file_name,omitempty" gorm:"type:uuid;unique"`
 	FileExtension string                `json:"file_extension,omitempty" gorm:"not null"`
 	TeamID        uint                  `json:"team_id,omitempty" gorm:"not null"`
+	EnrolmentID   uint                  `json:"enrolment_id,omitempty"`
 	Stage         types.SubmissionStage `json:"stage,omitempty" gorm:"not null"`
+	Version       uint                  `json:"version,omitempty"`
+	IsFinal       bool                  `json:"is_final,omitempty"`
+	IsLate        bool                  `json:"is_late,omitempty"`
+	Score         *DatavidiaScore       `json:"score,omitempty"`
+	Receipt       *Receipt              `json:"receipt,omitempty"`
 }
 
 func (submission Submission) MarshalJSON() ([]byte, error) {
@@ -37,6 +52,115 @@ func (submission Submission) MarshalJSON() ([]byte, error) {
 		FileName:      submission.FileName,
 		FileExtension: submission.FileExtension,
 		TeamID:        submission.TeamID,
+		EnrolmentID:   submission.EnrolmentID,
 		Stage:         submission.Stage,
+		Version:       submission.Version,
+		IsFinal:       submission.IsFinal,
+		IsLate:        submission.IsLate,
+		Score:         submission.Score,
+		Receipt:       submission.Receipt,
 	})
 }
+
+// Menambahkan constraint untuk mengecek apakah enrolment team masih berada pada stage submission yang dikumpulkan,
+// apakah submission dikumpulkan di dalam jendela waktu pengumpulan, dan menandai submission pada masa tenggang sebagai terlambat
+func (submission *Submission) BeforeCreate(tx *gorm.DB) error {
+	// Baris enrolment dikunci agar dua unggahan bersamaan tidak memperoleh nomor versi yang sama
+	conditionEnrolment := Enrolment{Model: gorm.Model{ID: submission.EnrolmentID}, TeamID: submission.TeamID}
+	enrolment := Enrolment{}
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(&conditionEnrolment).Find(&enrolment).Error; err != nil {
+		return err
+	}
+	if submission.EnrolmentID == 0 || enrolment.ID == 0 {
+		return fmt.Errorf("ERROR: TEAM IS NOT REGISTERED TO THE COMPETITION")
+	}
+	if enrolment.Status == types.Eliminated {
+		return fmt.Errorf("ERROR: TEAM ELIMINATED")
+	}
+	if enrolment.GetCurrentStage() != submission.Stage {
+		return fmt.Errorf("ERROR: STAGE LOCKED")
+	}
+
+	submissionWindow, exists, err := FindSubmissionWindow(tx, enrolment.TeamCategory, submission.Stage)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		closeAt, err := submissionWindow.GetCloseAt(tx, submission.TeamID)
+		if err != nil {
+			return err
+		}
+
+		now := tx.NowFunc()
+		if now.Before(submissionWindow.OpenAt) {
+			return fmt.Errorf("ERROR: SUBMISSION WINDOW NOT OPEN")
+		}
+		if now.After(closeAt.Add(time.Duration(submissionWindow.GracePeriod) * time.Second)) {
+			return fmt.Errorf("ERROR: SUBMISSION WINDOW CLOSED")
+		}
+
+		submission.IsLate = now.After(closeAt)
+	}
+
+	// Versi terbaru secara default menjadi versi final
+	var latestVersion uint
+	condition := Submission{EnrolmentID: submission.EnrolmentID, Stage: submission.Stage}
+	if err := tx.Unscoped().Model(&Submission{}).Where(&condition).Select("COALESCE(MAX(version), 0)").Scan(&latestVersion).Error; err != nil {
+		return err
+	}
+	submission.Version = latestVersion + 1
+	submission.IsFinal = true
+
+	return nil
+}
+
+func (submission *Submission) AfterCreate(tx *gorm.DB) error {
+	condition := Submission{EnrolmentID: submission.EnrolmentID, Stage: submission.Stage}
+	return tx.Model(&Submission{}).Where(&condition).Where("id <> ?", submission.ID).Update("is_final", false).Error
+}
+
+// Menambahkan constraint untuk mengecek apakah submission yang dihapus merupakan versi final
+// atau jendela waktu pengumpulan telah ditutup sehingga seluruh versi tidak dapat diubah
+func (submission *Submission) BeforeDelete(tx *gorm.DB) error {
+	if submission.ID != 0 {
+		if submission.IsFinal {
+			return fmt.Errorf("ERROR: FINAL SUBMISSION CANNOT BE DELETED")
+		}
+		if err := submission.CheckMutable(tx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (submission Submission) CheckMutable(tx *gorm.DB) error {
+	// Enrolment yang telah ditarik (soft delete) mengunci seluruh submission-nya
+	conditionEnrolment := Enrolment{Model: gorm.Model{ID: submission.EnrolmentID}}
+	enrolment := Enrolment{}
+	if err := tx.Where(&conditionEnrolment).Find(&enrolment).Error; err != nil {
+		return err
+	}
+	if submission.EnrolmentID == 0 || enrolment.ID == 0 {
+		return fmt.Errorf("ERROR: SUBMISSION IS IMMUTABLE")
+	}
+
+	submissionWindow, exists, err := FindSubmissionWindow(tx, enrolment.TeamCategory, submission.Stage)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+
+	closed, err := submissionWindow.IsClosed(tx, submission.TeamID)
+	if err != nil {
+		return err
+	}
+	if closed {
+		return fmt.Errorf("ERROR: SUBMISSION IS IMMUTABLE")
+	}
+
+	return nil
+}
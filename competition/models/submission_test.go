@@ -0,0 +1,75 @@
+package models_test
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"arkavidia-backend-8.0/competition/models"
+	databaseService "arkavidia-backend-8.0/competition/services/database"
+	"arkavidia-backend-8.0/competition/types"
+)
+
+// NOTE: Membutuhkan basis data PostgreSQL (POSTGRES_HOST, POSTGRES_PORT, dst.) dan dijalankan dari root repository
+// agar migration dapat dibaca
+func TestSubmissionVersionConcurrentUploads(t *testing.T) {
+	if os.Getenv("POSTGRES_HOST") == "" {
+		t.Skip("POSTGRES_HOST is not set")
+	}
+	if err := os.Chdir("../.."); err != nil {
+		t.Fatal(err)
+	}
+
+	db := databaseService.DB.GetConnection()
+	suffix := uuid.NewString()[:8]
+	team := models.Team{Username: fmt.Sprintf("version-%s", suffix), HashedPassword: []byte("password"), TeamName: fmt.Sprintf("Version %s", suffix)}
+	if err := db.Create(&team).Error; err != nil {
+		t.Fatal(err)
+	}
+	enrolment := models.Enrolment{TeamID: team.ID, TeamCategory: types.Datavidia, Status: types.WaitingForEvaluation}
+	if err := db.Create(&enrolment).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	const uploads = 2
+	submissions := make([]models.Submission, uploads)
+	errs := make([]error, uploads)
+	wg := sync.WaitGroup{}
+	for i := range submissions {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			submissions[i] = models.Submission{FileName: uuid.New(), FileExtension: ".csv", TeamID: team.ID, EnrolmentID: enrolment.ID, Stage: enrolment.GetCurrentStage()}
+			errs[i] = db.Create(&submissions[i]).Error
+		}(i)
+	}
+	wg.Wait()
+
+	versions := []int{}
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("upload %d error = %v", i, err)
+		}
+		versions = append(versions, int(submissions[i].Version))
+	}
+	sort.Ints(versions)
+	for i, version := range versions {
+		if version != i+1 {
+			t.Errorf("versions = %v, expected 1 to %d", versions, uploads)
+			break
+		}
+	}
+
+	finals := int64(0)
+	condition := models.Submission{EnrolmentID: enrolment.ID, IsFinal: true}
+	if err := db.Model(&models.Submission{}).Where(&condition).Count(&finals).Error; err != nil {
+		t.Fatal(err)
+	}
+	if finals != 1 {
+		t.Errorf("final submissions = %d, expected 1", finals)
+	}
+}
@@ -0,0 +1,346 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type TeamWithdrawal struct {
+	gorm.Model
+	TeamID           uint                 `gorm:"not null"`
+	EnrolmentID      uint                 `gorm:"default:null"`
+	Type             types.WithdrawalType `gorm:"not null"`
+	Reason           string               `gorm:"not null"`
+	PreviousCategory types.TeamCategory   `gorm:"default:null"`
+	AdminID          uint                 `gorm:"default:null"`
+	RevertedAt       time.Time            `gorm:"default:null"`
+	Team             Team                 `gorm:"foreignKey:TeamID;references:ID"`
+	Enrolment        Enrolment            `gorm:"foreignKey:EnrolmentID;references:ID"`
+	RevertedBy       Admin                `gorm:"foreignKey:AdminID;references:ID"`
+}
+
+type DisplayTeamWithdrawal struct {
+	ID               uint                 `json:"id,omitempty"`
+	CreatedAt        time.Time            `json:"created_at,omitempty"`
+	UpdatedAt        time.Time            `json:"updated_at,omitempty"`
+	TeamID           uint                 `json:"team_id,omitempty"`
+	EnrolmentID      uint                 `json:"enrolment_id,omitempty"`
+	Type             types.WithdrawalType `json:"type,omitempty"`
+	Reason           string               `json:"reason,omitempty"`
+	PreviousCategory types.TeamCategory   `json:"previous_category,omitempty"`
+	AdminID          uint                 `json:"admin_id,omitempty"`
+	RevertedAt       *time.Time           `json:"reverted_at,omitempty"`
+}
+
+func (teamWithdrawal TeamWithdrawal) MarshalJSON() ([]byte, error) {
+	var revertedAt *time.Time
+	if !teamWithdrawal.RevertedAt.IsZero() {
+		revertedAt = &teamWithdrawal.RevertedAt
+	}
+
+	return json.Marshal(&DisplayTeamWithdrawal{
+		ID:               teamWithdrawal.ID,
+		CreatedAt:        teamWithdrawal.CreatedAt,
+		UpdatedAt:        teamWithdrawal.UpdatedAt,
+		TeamID:           teamWithdrawal.TeamID,
+		EnrolmentID:      teamWithdrawal.EnrolmentID,
+		Type:             teamWithdrawal.Type,
+		Reason:           teamWithdrawal.Reason,
+		PreviousCategory: teamWithdrawal.PreviousCategory,
+		AdminID:          teamWithdrawal.AdminID,
+		RevertedAt:       revertedAt,
+	})
+}
+
+// Team yang mengundurkan diri dari suatu jenis lomba menghapus (soft delete) enrolment-nya sehingga seluruh submission dikunci,
+// team yang dibubarkan menghapus seluruh enrolment dan membership sehingga anggotanya dapat bergabung dengan team lain
+// NOTE: Seluruh baris yang diubah dicatat pada WithdrawalRecord agar dapat dikembalikan secara tepat
+func WithdrawTeam(tx *gorm.DB, teamID uint, withdrawalType types.WithdrawalType, teamCategory types.TeamCategory, reason string) (TeamWithdrawal, error) {
+	conditionTeam := Team{Model: gorm.Model{ID: teamID}}
+	team := Team{}
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(&conditionTeam).First(&team).Error; err != nil {
+		return TeamWithdrawal{}, err
+	}
+	if team.IsDisbanded {
+		return TeamWithdrawal{}, fmt.Errorf("ERROR: TEAM ALREADY DISBANDED")
+	}
+
+	teamWithdrawal := TeamWithdrawal{TeamID: teamID, Type: withdrawalType, Reason: reason}
+	if withdrawalType == types.Withdrawal {
+		if teamCategory == "" {
+			return TeamWithdrawal{}, fmt.Errorf("ERROR: TEAM CATEGORY IS REQUIRED")
+		}
+
+		enrolment, err := FindEnrolment(tx, teamID, teamCategory)
+		if err != nil {
+			return TeamWithdrawal{}, err
+		}
+		teamWithdrawal.EnrolmentID = enrolment.ID
+		teamWithdrawal.PreviousCategory = enrolment.TeamCategory
+	}
+
+	if err := tx.Create(&teamWithdrawal).Error; err != nil {
+		return TeamWithdrawal{}, err
+	}
+
+	conditionEnrolment := Enrolment{Model: gorm.Model{ID: teamWithdrawal.EnrolmentID}}
+	conditionInvoice := Invoice{EnrolmentID: teamWithdrawal.EnrolmentID, Status: types.InvoicePending}
+	if withdrawalType == types.Disband {
+		conditionEnrolment = Enrolment{TeamID: teamID}
+		conditionInvoice = Invoice{TeamID: teamID, Status: types.InvoicePending}
+	}
+
+	enrolmentIDs := []uint{}
+	if err := tx.Model(&Enrolment{}).Where(&conditionEnrolment).Pluck("id", &enrolmentIDs).Error; err != nil {
+		return TeamWithdrawal{}, err
+	}
+	if err := createWithdrawalRecords(tx, teamWithdrawal.ID, types.EnrolmentRecord, enrolmentIDs); err != nil {
+		return TeamWithdrawal{}, err
+	}
+	if err := tx.Where("id IN ?", enrolmentIDs).Delete(&Enrolment{}).Error; err != nil {
+		return TeamWithdrawal{}, err
+	}
+
+	invoiceIDs := []uint{}
+	if err := tx.Model(&Invoice{}).Where(&conditionInvoice).Pluck("id", &invoiceIDs).Error; err != nil {
+		return TeamWithdrawal{}, err
+	}
+	if err := createWithdrawalRecords(tx, teamWithdrawal.ID, types.InvoiceRecord, invoiceIDs); err != nil {
+		return TeamWithdrawal{}, err
+	}
+	if err := CancelInvoices(tx, conditionInvoice); err != nil {
+		return TeamWithdrawal{}, err
+	}
+
+	if withdrawalType == types.Disband {
+		conditionWaitlistEntry := WaitlistEntry{TeamID: teamID, Status: types.WaitlistWaiting}
+		if err := tx.Model(&WaitlistEntry{}).Where(&conditionWaitlistEntry).Update("status", types.WaitlistLeft).Error; err != nil {
+			return TeamWithdrawal{}, err
+		}
+
+		// Membership pending milik undangan ikut dihapus (soft delete) bersama membership lain sehingga undangan dapat dikembalikan
+		conditionInvitation := Invitation{TeamID: teamID, Status: types.InvitationPending}
+		invitationIDs := []uint{}
+		if err := tx.Model(&Invitation{}).Where(&conditionInvitation).Pluck("id", &invitationIDs).Error; err != nil {
+			return TeamWithdrawal{}, err
+		}
+		if err := createWithdrawalRecords(tx, teamWithdrawal.ID, types.InvitationRecord, invitationIDs); err != nil {
+			return TeamWithdrawal{}, err
+		}
+		if err := tx.Model(&Invitation{}).Where("id IN ?", invitationIDs).Updates(map[string]interface{}{"status": types.InvitationRevoked, "responded_at": tx.NowFunc()}).Error; err != nil {
+			return TeamWithdrawal{}, err
+		}
+
+		conditionMembership := Membership{TeamID: teamID}
+		membershipIDs := []uint{}
+		if err := tx.Model(&Membership{}).Where(&conditionMembership).Pluck("id", &membershipIDs).Error; err != nil {
+			return TeamWithdrawal{}, err
+		}
+		if err := createWithdrawalRecords(tx, teamWithdrawal.ID, types.MembershipRecord, membershipIDs); err != nil {
+			return TeamWithdrawal{}, err
+		}
+		if err := tx.Where("id IN ?", membershipIDs).Delete(&Membership{}).Error; err != nil {
+			return TeamWithdrawal{}, err
+		}
+
+		if err := tx.Model(&Team{}).Where(&conditionTeam).Update("is_disbanded", true).Error; err != nil {
+			return TeamWithdrawal{}, err
+		}
+	}
+
+	teamWithdrawal.Team = team
+	return teamWithdrawal, nil
+}
+
+// Pembatalan pengunduran diri hanya mengembalikan enrolment, membership, undangan, dan invoice yang tercatat saat pengunduran diri,
+// aturan keikutsertaan dan komposisi team dicek kembali karena anggotanya mungkin telah bergabung dengan team lain
+func RevertWithdrawal(tx *gorm.DB, teamWithdrawalID uint, adminID uint) (TeamWithdrawal, error) {
+	conditionWithdrawal := TeamWithdrawal{Model: gorm.Model{ID: teamWithdrawalID}}
+	teamWithdrawal := TeamWithdrawal{}
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(&conditionWithdrawal).First(&teamWithdrawal).Error; err != nil {
+		return TeamWithdrawal{}, err
+	}
+	if !teamWithdrawal.RevertedAt.IsZero() {
+		return TeamWithdrawal{}, fmt.Errorf("ERROR: WITHDRAWAL ALREADY REVERTED")
+	}
+
+	conditionTeam := Team{Model: gorm.Model{ID: teamWithdrawal.TeamID}}
+	team := Team{}
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(&conditionTeam).First(&team).Error; err != nil {
+		return TeamWithdrawal{}, err
+	}
+
+	switch teamWithdrawal.Type {
+	case types.Withdrawal:
+		if team.IsDisbanded {
+			return TeamWithdrawal{}, fmt.Errorf("ERROR: TEAM DISBANDED")
+		}
+	case types.Disband:
+		if !team.IsDisbanded {
+			return TeamWithdrawal{}, fmt.Errorf("ERROR: TEAM IS NOT DISBANDED")
+		}
+
+		if err := restoreWithdrawnMemberships(tx, teamWithdrawal.ID); err != nil {
+			return TeamWithdrawal{}, err
+		}
+		if err := tx.Model(&Team{}).Where(&conditionTeam).Update("is_disbanded", false).Error; err != nil {
+			return TeamWithdrawal{}, err
+		}
+	}
+
+	if err := restoreWithdrawnEnrolments(tx, teamWithdrawal); err != nil {
+		return TeamWithdrawal{}, err
+	}
+	if err := restoreWithdrawnInvoices(tx, teamWithdrawal.ID); err != nil {
+		return TeamWithdrawal{}, err
+	}
+
+	newWithdrawal := TeamWithdrawal{AdminID: adminID, RevertedAt: tx.NowFunc()}
+	if err := tx.Where(&conditionWithdrawal).Updates(&newWithdrawal).Error; err != nil {
+		return TeamWithdrawal{}, err
+	}
+
+	if err := ValidateComposition(tx, teamWithdrawal.TeamID); err != nil {
+		return TeamWithdrawal{}, err
+	}
+
+	teamWithdrawal.AdminID = newWithdrawal.AdminID
+	teamWithdrawal.RevertedAt = newWithdrawal.RevertedAt
+	teamWithdrawal.Team = team
+	return teamWithdrawal, nil
+}
+
+// Membership disimpan ulang setelah dikembalikan agar constraint BeforeSave (leader dan jenis lomba ganda) tetap dicek,
+// undangan yang telah melewati batas waktu ditutup sebagai expired
+func restoreWithdrawnMemberships(tx *gorm.DB, teamWithdrawalID uint) error {
+	membershipIDs, err := getWithdrawalRecordIDs(tx, teamWithdrawalID, types.MembershipRecord)
+	if err != nil {
+		return err
+	}
+	if err := tx.Unscoped().Model(&Membership{}).Where("id IN ? AND deleted_at IS NOT NULL", membershipIDs).Update("deleted_at", nil).Error; err != nil {
+		return err
+	}
+
+	memberships := []Membership{}
+	if err := tx.Where("id IN ?", membershipIDs).Find(&memberships).Error; err != nil {
+		return err
+	}
+	for _, membership := range memberships {
+		if err := tx.Save(&membership).Error; err != nil {
+			return err
+		}
+	}
+
+	invitationIDs, err := getWithdrawalRecordIDs(tx, teamWithdrawalID, types.InvitationRecord)
+	if err != nil {
+		return err
+	}
+
+	invitations := []Invitation{}
+	if err := tx.Where("id IN ?", invitationIDs).Find(&invitations).Error; err != nil {
+		return err
+	}
+	for _, invitation := range invitations {
+		if !tx.NowFunc().Before(invitation.ExpiresAt) {
+			if err := invitation.Close(tx, types.InvitationExpired); err != nil {
+				return err
+			}
+			continue
+		}
+
+		conditionInvitation := Invitation{Model: gorm.Model{ID: invitation.ID}}
+		if err := tx.Model(&Invitation{}).Where(&conditionInvitation).Updates(map[string]interface{}{"status": types.InvitationPending, "responded_at": nil}).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Enrolment dikembalikan satu per satu setelah dicek apakah anggotanya telah mengikuti jenis lomba yang sama melalui team lain
+// dan apakah kuota pendaftaran jenis lomba tersebut masih tersedia
+func restoreWithdrawnEnrolments(tx *gorm.DB, teamWithdrawal TeamWithdrawal) error {
+	enrolmentIDs, err := getWithdrawalRecordIDs(tx, teamWithdrawal.ID, types.EnrolmentRecord)
+	if err != nil {
+		return err
+	}
+	// Pengunduran diri yang tercatat sebelum WithdrawalRecord tersedia hanya menyimpan enrolment-nya secara langsung
+	if len(enrolmentIDs) == 0 && teamWithdrawal.EnrolmentID != 0 {
+		enrolmentIDs = []uint{teamWithdrawal.EnrolmentID}
+	}
+
+	enrolments := []Enrolment{}
+	if err := tx.Unscoped().Where("id IN ?", enrolmentIDs).Find(&enrolments).Error; err != nil {
+		return err
+	}
+	for _, enrolment := range enrolments {
+		if err := CheckDuplicateParticipation(tx, enrolment.TeamID, enrolment.TeamCategory); err != nil {
+			return err
+		}
+
+		// Slot yang dilepas saat pengunduran diri mungkin telah ditempati team dari waitlist
+		categoryQuota, err := LockCategoryQuota(tx, enrolment.TeamCategory)
+		if err != nil {
+			return err
+		}
+		available, limited, err := categoryQuota.GetAvailableSlots(tx)
+		if err != nil {
+			return err
+		}
+		if limited && available == 0 {
+			return fmt.Errorf("ERROR: %s REGISTRATION QUOTA REACHED", string(enrolment.TeamCategory))
+		}
+
+		if err := tx.Unscoped().Model(&Enrolment{}).Where("id = ? AND deleted_at IS NOT NULL", enrolment.ID).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Invoice yang dibatalkan saat pengunduran diri kembali menunggu pembayaran selama belum kedaluwarsa
+// dan enrolment-nya belum memiliki invoice aktif lain, invoice yang terlanjur dibayar tetap lunas
+func restoreWithdrawnInvoices(tx *gorm.DB, teamWithdrawalID uint) error {
+	invoiceIDs, err := getWithdrawalRecordIDs(tx, teamWithdrawalID, types.InvoiceRecord)
+	if err != nil {
+		return err
+	}
+
+	invoices := []Invoice{}
+	if err := tx.Where("id IN ?", invoiceIDs).Find(&invoices).Error; err != nil {
+		return err
+	}
+	for _, invoice := range invoices {
+		if invoice.Status != types.InvoiceCancelled {
+			continue
+		}
+
+		activeInvoice, err := FindActiveInvoice(tx, invoice.EnrolmentID)
+		if err != nil {
+			return err
+		}
+		if activeInvoice.ID != 0 {
+			continue
+		}
+
+		invoice.Status = types.InvoicePending
+		status := types.InvoicePending
+		if invoice.IsExpired(tx.NowFunc()) {
+			status = types.InvoiceExpired
+		}
+
+		conditionInvoice := Invoice{Model: gorm.Model{ID: invoice.ID}}
+		if err := tx.Model(&Invoice{}).Where(&conditionInvoice).Update("status", status).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
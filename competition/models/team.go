@@ -2,7 +2,6 @@ package models
 
 import (
 	"encoding/json"
-	"fmt"
 	"time"
 
 	"gorm.io/gorm"
@@ -15,10 +14,10 @@ type Team struct {
 	Username       string                `gorm:"not null;unique"`
 	HashedPassword types.EncryptedString `gorm:"not null"`
 	TeamName       string                `gorm:"not null;unique"`
-	TeamCategory   types.TeamCategory    `gorm:"default:null"`
-	AdminID        uint                  `gorm:"default:null"`
-	Status         types.TeamStatus      `gorm:"not null"`
-	ApprovedBy     Admin                 `gorm:"foreignKey:AdminID;references:ID"`
+	Institution    string                `gorm:"default:null"`
+	EducationLevel types.EducationLevel  `gorm:"default:null"`
+	IsDisbanded    bool                  `gorm:"not null;default:false"`
+	Enrolments     []Enrolment
 	Memberships    []Membership
 	Submissions    []Submission
 }
@@ -30,39 +29,26 @@ type DisplayTeam struct {
 	Username       string                `json:"username,omitempty"`
 	HashedPassword types.EncryptedString `json:"-"`
 	TeamName       string                `json:"team_name,omitempty"`
-	TeamCategory   types.TeamCategory    `json:"team_category,omitempty"`
-	AdminID        uint                  `json:"admin_id,omitempty"`
-	Status         types.TeamStatus      `json:"status,omitempty"`
+	Institution    string                `json:"institution,omitempty"`
+	EducationLevel types.EducationLevel  `json:"education_level,omitempty"`
+	IsDisbanded    bool                  `json:"is_disbanded,omitempty"`
+	Enrolments     []Enrolment           `json:"enrolments,omitempty"`
 	Memberships    []Membership          `json:"memberships,omitempty"`
 	Submissions    []Submission          `json:"submissions,omitempty"`
 }
 
 func (team Team) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&DisplayTeam{
-		ID:           team.ID,
-		CreatedAt:    team.CreatedAt,
-		UpdatedAt:    team.UpdatedAt,
-		Username:     team.Username,
-		TeamName:     team.TeamName,
-		TeamCategory: team.TeamCategory,
-		AdminID:      team.AdminID,
-		Status:       team.Status,
-		Memberships:  team.Memberships,
-		Submissions:  team.Submissions,
+		ID:             team.ID,
+		CreatedAt:      team.CreatedAt,
+		UpdatedAt:      team.UpdatedAt,
+		Username:       team.Username,
+		TeamName:       team.TeamName,
+		Institution:    team.Institution,
+		EducationLevel: team.EducationLevel,
+		IsDisbanded:    team.IsDisbanded,
+		Enrolments:     team.Enrolments,
+		Memberships:    team.Memberships,
+		Submissions:    team.Submissions,
 	})
 }
-
-// Menambahkan constraint untuk mengecek apakah terdapat photos yang telah diapprove namun admin tidak tercatat
-// atau photos yang belum diapprove namun admin tercatat
-func (team *Team) BeforeSave(tx *gorm.DB) error {
-	if team.Status != "" {
-		if team.Status != types.WaitingForEvaluation && team.AdminID == 0 {
-			return fmt.Errorf("ERROR: ADMIN MUST BE RECORDED")
-		}
-		if team.Status == types.WaitingForEvaluation && team.AdminID != 0 {
-			return fmt.Errorf("ERROR: STATUS MUST BE RECORDED")
-		}
-	}
-
-	return nil
-}
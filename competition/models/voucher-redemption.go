@@ -0,0 +1,75 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type VoucherRedemption struct {
+	gorm.Model
+	VoucherID      uint               `gorm:"not null"`
+	TeamID         uint               `gorm:"not null"`
+	InvoiceID      uint               `gorm:"not null;unique"`
+	TeamCategory   types.TeamCategory `gorm:"not null"`
+	DiscountAmount uint               `gorm:"not null"`
+	Voucher        Voucher            `gorm:"foreignKey:VoucherID;references:ID"`
+	Team           Team               `gorm:"foreignKey:TeamID;references:ID"`
+	Invoice        Invoice            `gorm:"foreignKey:InvoiceID;references:ID"`
+}
+
+type DisplayVoucherRedemption struct {
+	ID             uint               `json:"id,omitempty"`
+	CreatedAt      time.Time          `json:"created_at,omitempty"`
+	VoucherID      uint               `json:"voucher_id,omitempty"`
+	TeamID         uint               `json:"team_id,omitempty"`
+	InvoiceID      uint               `json:"invoice_id,omitempty"`
+	TeamCategory   types.TeamCategory `json:"team_category,omitempty"`
+	DiscountAmount uint               `json:"discount_amount"`
+}
+
+func (voucherRedemption VoucherRedemption) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&DisplayVoucherRedemption{
+		ID:             voucherRedemption.ID,
+		CreatedAt:      voucherRedemption.CreatedAt,
+		VoucherID:      voucherRedemption.VoucherID,
+		TeamID:         voucherRedemption.TeamID,
+		InvoiceID:      voucherRedemption.InvoiceID,
+		TeamCategory:   voucherRedemption.TeamCategory,
+		DiscountAmount: voucherRedemption.DiscountAmount,
+	})
+}
+
+// Rekap penggunaan voucher per institusi team
+type VoucherRedemptionReport struct {
+	VoucherID     uint   `json:"voucher_id"`
+	Code          string `json:"code"`
+	Institution   string `json:"institution"`
+	TeamCount     uint   `json:"team_count"`
+	PaidCount     uint   `json:"paid_count"`
+	TotalDiscount uint   `json:"total_discount"`
+}
+
+// Team tanpa institusi dikelompokkan dengan institusi kosong
+func GetVoucherRedemptionReport(tx *gorm.DB, voucherID uint, teamCategory types.TeamCategory) ([]VoucherRedemptionReport, error) {
+	query := activeRedemptions(tx).
+		Select("vouchers.id AS voucher_id, vouchers.code AS code, COALESCE(teams.institution, '') AS institution, COUNT(DISTINCT voucher_redemptions.team_id) AS team_count, COUNT(*) FILTER (WHERE invoices.status = ?) AS paid_count, COALESCE(SUM(voucher_redemptions.discount_amount), 0) AS total_discount", types.InvoicePaid).
+		Joins("JOIN vouchers ON vouchers.id = voucher_redemptions.voucher_id").
+		Joins("JOIN teams ON teams.id = voucher_redemptions.team_id")
+	if voucherID != 0 {
+		query = query.Where("voucher_redemptions.voucher_id = ?", voucherID)
+	}
+	if teamCategory != "" {
+		query = query.Where("voucher_redemptions.team_category = ?", teamCategory)
+	}
+
+	reports := []VoucherRedemptionReport{}
+	if err := query.Group("vouchers.id, vouchers.code, COALESCE(teams.institution, '')").Order("vouchers.code, institution").Scan(&reports).Error; err != nil {
+		return nil, err
+	}
+
+	return reports, nil
+}
@@ -0,0 +1,158 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type Voucher struct {
+	gorm.Model
+	Code           string               `gorm:"not null;unique"`
+	DiscountType   types.DiscountType   `gorm:"not null"`
+	DiscountValue  uint                 `gorm:"not null"`
+	TeamCategories types.TeamCategories `gorm:"default:null"`
+	ValidFrom      time.Time            `gorm:"default:null"`
+	ValidUntil     time.Time            `gorm:"default:null"`
+	UsageLimit     uint                 `gorm:"not null;default:0"`
+	IsDisabled     bool                 `gorm:"not null;default:false"`
+	AdminID        uint                 `gorm:"not null"`
+	CreatedBy      Admin                `gorm:"foreignKey:AdminID;references:ID"`
+	Redemptions    []VoucherRedemption
+}
+
+type DisplayVoucher struct {
+	ID             uint                 `json:"id,omitempty"`
+	CreatedAt      time.Time            `json:"created_at,omitempty"`
+	UpdatedAt      time.Time            `json:"updated_at,omitempty"`
+	Code           string               `json:"code,omitempty"`
+	DiscountType   types.DiscountType   `json:"discount_type,omitempty"`
+	DiscountValue  uint                 `json:"discount_value"`
+	TeamCategories types.TeamCategories `json:"team_categories,omitempty"`
+	ValidFrom      *time.Time           `json:"valid_from,omitempty"`
+	ValidUntil     *time.Time           `json:"valid_until,omitempty"`
+	UsageLimit     uint                 `json:"usage_limit"`
+	IsDisabled     bool                 `json:"is_disabled"`
+	AdminID        uint                 `json:"admin_id,omitempty"`
+}
+
+func (voucher Voucher) MarshalJSON() ([]byte, error) {
+	var validFrom *time.Time
+	if !voucher.ValidFrom.IsZero() {
+		validFrom = &voucher.ValidFrom
+	}
+	var validUntil *time.Time
+	if !voucher.ValidUntil.IsZero() {
+		validUntil = &voucher.ValidUntil
+	}
+
+	return json.Marshal(&DisplayVoucher{
+		ID:             voucher.ID,
+		CreatedAt:      voucher.CreatedAt,
+		UpdatedAt:      voucher.UpdatedAt,
+		Code:           voucher.Code,
+		DiscountType:   voucher.DiscountType,
+		DiscountValue:  voucher.DiscountValue,
+		TeamCategories: voucher.TeamCategories,
+		ValidFrom:      validFrom,
+		ValidUntil:     validUntil,
+		UsageLimit:     voucher.UsageLimit,
+		IsDisabled:     voucher.IsDisabled,
+		AdminID:        voucher.AdminID,
+	})
+}
+
+// Kode voucher tidak membedakan huruf besar dan kecil
+func NormalizeVoucherCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Menambahkan constraint untuk mengecek apakah nilai diskon dan masa berlaku voucher valid
+func (voucher Voucher) Validate() error {
+	if voucher.DiscountValue == 0 {
+		return fmt.Errorf("ERROR: DISCOUNT VALUE MUST BE GREATER THAN 0")
+	}
+	if voucher.DiscountType == types.PercentageDiscount && voucher.DiscountValue > 100 {
+		return fmt.Errorf("ERROR: PERCENTAGE DISCOUNT CANNOT EXCEED 100")
+	}
+	if !voucher.ValidFrom.IsZero() && !voucher.ValidUntil.IsZero() && !voucher.ValidUntil.After(voucher.ValidFrom) {
+		return fmt.Errorf("ERROR: VALID UNTIL MUST BE AFTER VALID FROM")
+	}
+
+	return nil
+}
+
+func (voucher *Voucher) BeforeCreate(tx *gorm.DB) error {
+	voucher.Code = NormalizeVoucherCode(voucher.Code)
+	return voucher.Validate()
+}
+
+// Menghitung potongan untuk nominal tertentu, potongan tidak pernah melebihi nominal
+func (voucher Voucher) GetDiscount(amount uint) uint {
+	discount := voucher.DiscountValue
+	if voucher.DiscountType == types.PercentageDiscount {
+		discount = uint(uint64(amount) * uint64(voucher.DiscountValue) / 100)
+	}
+	if discount > amount {
+		discount = amount
+	}
+
+	return discount
+}
+
+// Redemption hanya dihitung apabila invoice terkait belum kedaluwarsa atau dibatalkan
+func activeRedemptions(tx *gorm.DB) *gorm.DB {
+	return tx.Model(&VoucherRedemption{}).
+		Joins("JOIN invoices ON invoices.id = voucher_redemptions.invoice_id AND invoices.deleted_at IS NULL").
+		Where("invoices.status IN ?", []types.InvoiceStatus{types.InvoicePending, types.InvoicePaid})
+}
+
+// Mencari voucher yang dapat digunakan team untuk jenis lomba tertentu,
+// voucher dikunci agar batas penggunaan tidak terlampaui oleh penukaran yang bersamaan
+func FindRedeemableVoucher(tx *gorm.DB, code string, teamID uint, teamCategory types.TeamCategory) (Voucher, error) {
+	condition := Voucher{Code: NormalizeVoucherCode(code)}
+	voucher := Voucher{}
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(&condition).First(&voucher).Error; err != nil {
+		return Voucher{}, fmt.Errorf("ERROR: VOUCHER NOT FOUND")
+	}
+
+	now := tx.NowFunc()
+	if voucher.IsDisabled {
+		return Voucher{}, fmt.Errorf("ERROR: VOUCHER DISABLED")
+	}
+	if !voucher.ValidFrom.IsZero() && now.Before(voucher.ValidFrom) {
+		return Voucher{}, fmt.Errorf("ERROR: VOUCHER NOT YET VALID")
+	}
+	if !voucher.ValidUntil.IsZero() && now.After(voucher.ValidUntil) {
+		return Voucher{}, fmt.Errorf("ERROR: VOUCHER EXPIRED")
+	}
+	if len(voucher.TeamCategories) > 0 && !voucher.TeamCategories.Contains(teamCategory) {
+		return Voucher{}, fmt.Errorf("ERROR: VOUCHER NOT APPLICABLE TO %s", string(teamCategory))
+	}
+
+	var used int64
+	if err := activeRedemptions(tx).Where("voucher_redemptions.voucher_id = ? AND voucher_redemptions.team_id = ?", voucher.ID, teamID).Count(&used).Error; err != nil {
+		return Voucher{}, err
+	}
+	if used > 0 {
+		return Voucher{}, fmt.Errorf("ERROR: VOUCHER ALREADY USED BY TEAM")
+	}
+
+	if voucher.UsageLimit > 0 {
+		var count int64
+		if err := activeRedemptions(tx).Where("voucher_redemptions.voucher_id = ?", voucher.ID).Count(&count).Error; err != nil {
+			return Voucher{}, err
+		}
+		if count >= int64(voucher.UsageLimit) {
+			return Voucher{}, fmt.Errorf("ERROR: VOUCHER USAGE LIMIT REACHED")
+		}
+	}
+
+	return voucher, nil
+}
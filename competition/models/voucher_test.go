@@ -0,0 +1,31 @@
+package models
+
+import (
+	"testing"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+func TestGetDiscount(t *testing.T) {
+	testCases := []struct {
+		name     string
+		voucher  Voucher
+		amount   uint
+		expected uint
+	}{
+		{name: "fixed discount", voucher: Voucher{DiscountType: types.FixedDiscount, DiscountValue: 50000}, amount: 150000, expected: 50000},
+		{name: "fixed discount above amount", voucher: Voucher{DiscountType: types.FixedDiscount, DiscountValue: 200000}, amount: 150000, expected: 150000},
+		{name: "percentage discount", voucher: Voucher{DiscountType: types.PercentageDiscount, DiscountValue: 25}, amount: 150000, expected: 37500},
+		{name: "percentage discount rounded down", voucher: Voucher{DiscountType: types.PercentageDiscount, DiscountValue: 33}, amount: 100001, expected: 33000},
+		{name: "full percentage discount", voucher: Voucher{DiscountType: types.PercentageDiscount, DiscountValue: 100}, amount: 150000, expected: 150000},
+		{name: "zero amount", voucher: Voucher{DiscountType: types.FixedDiscount, DiscountValue: 50000}, amount: 0, expected: 0},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if actual := testCase.voucher.GetDiscount(testCase.amount); actual != testCase.expected {
+				t.Errorf("GetDiscount() = %v, expected %v", actual, testCase.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type WaitlistEntry struct {
+	gorm.Model
+	TeamID       uint                 `gorm:"not null"`
+	TeamCategory types.TeamCategory   `gorm:"not null"`
+	Position     uint                 `gorm:"not null"`
+	Status       types.WaitlistStatus `gorm:"not null;default:'waiting'"`
+	EnrolmentID  uint                 `gorm:"default:null"`
+	PromotedAt   time.Time            `gorm:"default:null"`
+	SkipReason   string               `gorm:"default:null"`
+	SkippedAt    time.Time            `gorm:"default:null"`
+	Team         Team                 `gorm:"foreignKey:TeamID;references:ID"`
+	Enrolment    Enrolment            `gorm:"foreignKey:EnrolmentID;references:ID"`
+}
+
+type DisplayWaitlistEntry struct {
+	ID           uint                 `json:"id,omitempty"`
+	CreatedAt    time.Time            `json:"created_at,omitempty"`
+	UpdatedAt    time.Time            `json:"updated_at,omitempty"`
+	TeamID       uint                 `json:"team_id,omitempty"`
+	TeamCategory types.TeamCategory   `json:"team_category,omitempty"`
+	Position     uint                 `json:"position,omitempty"`
+	Status       types.WaitlistStatus `json:"status,omitempty"`
+	EnrolmentID  uint                 `json:"enrolment_id,omitempty"`
+	PromotedAt   *time.Time           `json:"promoted_at,omitempty"`
+	SkipReason   string               `json:"skip_reason,omitempty"`
+	SkippedAt    *time.Time           `json:"skipped_at,omitempty"`
+}
+
+func (waitlistEntry WaitlistEntry) MarshalJSON() ([]byte, error) {
+	var promotedAt *time.Time
+	if !waitlistEntry.PromotedAt.IsZero() {
+		promotedAt = &waitlistEntry.PromotedAt
+	}
+	var skippedAt *time.Time
+	if !waitlistEntry.SkippedAt.IsZero() {
+		skippedAt = &waitlistEntry.SkippedAt
+	}
+
+	return json.Marshal(&DisplayWaitlistEntry{
+		ID:           waitlistEntry.ID,
+		CreatedAt:    waitlistEntry.CreatedAt,
+		UpdatedAt:    waitlistEntry.UpdatedAt,
+		TeamID:       waitlistEntry.TeamID,
+		TeamCategory: waitlistEntry.TeamCategory,
+		Position:     waitlistEntry.Position,
+		Status:       waitlistEntry.Status,
+		EnrolmentID:  waitlistEntry.EnrolmentID,
+		PromotedAt:   promotedAt,
+		SkipReason:   waitlistEntry.SkipReason,
+		SkippedAt:    skippedAt,
+	})
+}
+
+// Menambahkan constraint untuk mengecek apakah team telah terdaftar atau berada pada waitlist jenis lomba yang sama
+// dan menempatkan team pada urutan terakhir waitlist
+func (waitlistEntry *WaitlistEntry) BeforeCreate(tx *gorm.DB) error {
+	var enrolled int64
+	conditionEnrolment := Enrolment{TeamID: waitlistEntry.TeamID, TeamCategory: waitlistEntry.TeamCategory}
+	if err := tx.Unscoped().Model(&Enrolment{}).Where(&conditionEnrolment).Count(&enrolled).Error; err != nil {
+		return err
+	}
+	if enrolled > 0 {
+		return fmt.Errorf("ERROR: TEAM ALREADY REGISTERED TO %s", string(waitlistEntry.TeamCategory))
+	}
+
+	var count int64
+	condition := WaitlistEntry{TeamID: waitlistEntry.TeamID, TeamCategory: waitlistEntry.TeamCategory, Status: types.WaitlistWaiting}
+	if err := tx.Model(&WaitlistEntry{}).Where(&condition).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("ERROR: TEAM ALREADY WAITLISTED")
+	}
+
+	var lastPosition uint
+	conditionCategory := WaitlistEntry{TeamCategory: waitlistEntry.TeamCategory, Status: types.WaitlistWaiting}
+	if err := tx.Model(&WaitlistEntry{}).Where(&conditionCategory).Select("COALESCE(MAX(position), 0)").Scan(&lastPosition).Error; err != nil {
+		return err
+	}
+	waitlistEntry.Position = lastPosition + 1
+	waitlistEntry.Status = types.WaitlistWaiting
+
+	return nil
+}
+
+func GetWaitlist(tx *gorm.DB, teamCategory types.TeamCategory) ([]WaitlistEntry, error) {
+	condition := WaitlistEntry{TeamCategory: teamCategory, Status: types.WaitlistWaiting}
+	waitlistEntries := []WaitlistEntry{}
+	if err := tx.Where(&condition).Order("position, id").Find(&waitlistEntries).Error; err != nil {
+		return nil, err
+	}
+
+	return waitlistEntries, nil
+}
+
+// Memindahkan team pada waitlist ke posisi baru kemudian menomori ulang seluruh posisi secara berurutan
+func ReorderWaitlist(tx *gorm.DB, waitlistEntryID uint, position uint) ([]WaitlistEntry, error) {
+	conditionEntry := WaitlistEntry{Model: gorm.Model{ID: waitlistEntryID}, Status: types.WaitlistWaiting}
+	waitlistEntry := WaitlistEntry{}
+	if err := tx.Where(&conditionEntry).First(&waitlistEntry).Error; err != nil {
+		return nil, fmt.Errorf("ERROR: WAITLIST ENTRY NOT FOUND")
+	}
+
+	condition := WaitlistEntry{TeamCategory: waitlistEntry.TeamCategory, Status: types.WaitlistWaiting}
+	waitlistEntries := []WaitlistEntry{}
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(&condition).Order("position, id").Find(&waitlistEntries).Error; err != nil {
+		return nil, err
+	}
+
+	ordered := []WaitlistEntry{}
+	for _, entry := range waitlistEntries {
+		if entry.ID != waitlistEntry.ID {
+			ordered = append(ordered, entry)
+		}
+	}
+
+	index := int(position) - 1
+	if index < 0 {
+		index = 0
+	}
+	if index > len(ordered) {
+		index = len(ordered)
+	}
+	ordered = append(ordered[:index], append([]WaitlistEntry{waitlistEntry}, ordered[index:]...)...)
+
+	for i := range ordered {
+		ordered[i].Position = uint(i + 1)
+		if err := tx.Model(&WaitlistEntry{}).Where("id = ?", ordered[i].ID).Update("position", ordered[i].Position).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
+
+// Mengeluarkan team dari waitlist, misalnya karena team mundur atau dibubarkan
+func LeaveWaitlist(tx *gorm.DB, teamID uint, teamCategory types.TeamCategory) error {
+	condition := WaitlistEntry{TeamID: teamID, TeamCategory: teamCategory, Status: types.WaitlistWaiting}
+	return tx.Model(&WaitlistEntry{}).Where(&condition).Update("status", types.WaitlistLeft).Error
+}
+
+// Mempromosikan team pada waitlist sesuai urutan selama slot pendaftaran masih tersedia
+// Team yang tidak lagi memenuhi aturan komposisi atau kelayakan dilewati dan tetap berada pada waitlist dengan alasan yang tercatat
+func PromoteWaitlist(tx *gorm.DB, teamCategory types.TeamCategory) ([]WaitlistEntry, error) {
+	categoryQuota, err := LockCategoryQuota(tx, teamCategory)
+	if err != nil {
+		return nil, err
+	}
+
+	waitlistEntries, err := GetWaitlist(tx, teamCategory)
+	if err != nil {
+		return nil, err
+	}
+
+	promoted := []WaitlistEntry{}
+	for _, waitlistEntry := range waitlistEntries {
+		available, limited, err := categoryQuota.GetAvailableSlots(tx)
+		if err != nil {
+			return nil, err
+		}
+		if limited && available == 0 {
+			break
+		}
+
+		conditionTeam := Team{Model: gorm.Model{ID: waitlistEntry.TeamID}}
+		if err := tx.Where(&conditionTeam).First(&waitlistEntry.Team).Error; err != nil {
+			return nil, err
+		}
+		if waitlistEntry.Team.IsDisbanded {
+			if err := LeaveWaitlist(tx, waitlistEntry.TeamID, teamCategory); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		enrolment := Enrolment{TeamID: waitlistEntry.TeamID, TeamCategory: teamCategory, Stage: teamCategory.GetStagePipeline()[0], Status: types.WaitingForEvaluation}
+		if err := tx.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Create(&enrolment).Error; err != nil {
+				return err
+			}
+			if err := ValidateComposition(tx, waitlistEntry.TeamID); err != nil {
+				return err
+			}
+
+			return CheckEligibility(tx, waitlistEntry.TeamID, teamCategory)
+		}); err != nil {
+			// Alasan team dilewati dicatat pada waitlist agar dapat ditindaklanjuti oleh admin
+			log.Printf("WARNING: WAITLIST ENTRY %d FOR TEAM %d SKIPPED: %s", waitlistEntry.ID, waitlistEntry.TeamID, err.Error())
+
+			conditionWaitlistEntry := WaitlistEntry{Model: gorm.Model{ID: waitlistEntry.ID}}
+			if err := tx.Model(&WaitlistEntry{}).Where(&conditionWaitlistEntry).Updates(map[string]interface{}{"skip_reason": err.Error(), "skipped_at": tx.NowFunc()}).Error; err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		// Status waitlist diperbarui oleh hook AfterCreate enrolment
+		waitlistEntry.Status = types.WaitlistPromoted
+		waitlistEntry.EnrolmentID = enrolment.ID
+		waitlistEntry.PromotedAt = tx.NowFunc()
+		waitlistEntry.Enrolment = enrolment
+		promoted = append(promoted, waitlistEntry)
+	}
+
+	return promoted, nil
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+// WithdrawalRecord mencatat baris yang diubah saat pengunduran diri agar pembatalannya hanya mengembalikan baris tersebut
+type WithdrawalRecord struct {
+	gorm.Model
+	TeamWithdrawalID uint                       `gorm:"not null;uniqueIndex:withdrawal_record_index"`
+	Kind             types.WithdrawalRecordKind `gorm:"not null;uniqueIndex:withdrawal_record_index"`
+	RecordID         uint                       `gorm:"not null;uniqueIndex:withdrawal_record_index"`
+	TeamWithdrawal   TeamWithdrawal             `gorm:"foreignKey:TeamWithdrawalID;references:ID"`
+}
+
+type DisplayWithdrawalRecord struct {
+	ID               uint                       `json:"id,omitempty"`
+	CreatedAt        time.Time                  `json:"created_at,omitempty"`
+	UpdatedAt        time.Time                  `json:"updated_at,omitempty"`
+	TeamWithdrawalID uint                       `json:"team_withdrawal_id,omitempty"`
+	Kind             types.WithdrawalRecordKind `json:"kind,omitempty"`
+	RecordID         uint                       `json:"record_id,omitempty"`
+}
+
+func (withdrawalRecord WithdrawalRecord) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&DisplayWithdrawalRecord{
+		ID:               withdrawalRecord.ID,
+		CreatedAt:        withdrawalRecord.CreatedAt,
+		UpdatedAt:        withdrawalRecord.UpdatedAt,
+		TeamWithdrawalID: withdrawalRecord.TeamWithdrawalID,
+		Kind:             withdrawalRecord.Kind,
+		RecordID:         withdrawalRecord.RecordID,
+	})
+}
+
+func createWithdrawalRecords(tx *gorm.DB, teamWithdrawalID uint, kind types.WithdrawalRecordKind, recordIDs []uint) error {
+	for _, recordID := range recordIDs {
+		withdrawalRecord := WithdrawalRecord{TeamWithdrawalID: teamWithdrawalID, Kind: kind, RecordID: recordID}
+		if err := tx.Create(&withdrawalRecord).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Mengembalikan ID baris yang tercatat pada pengunduran diri untuk jenis baris tertentu
+func getWithdrawalRecordIDs(tx *gorm.DB, teamWithdrawalID uint, kind types.WithdrawalRecordKind) ([]uint, error) {
+	condition := WithdrawalRecord{TeamWithdrawalID: teamWithdrawalID, Kind: kind}
+	recordIDs := []uint{}
+	if err := tx.Model(&WithdrawalRecord{}).Where(&condition).Order("record_id").Pluck("record_id", &recordIDs).Error; err != nil {
+		return nil, err
+	}
+
+	return recordIDs, nil
+}
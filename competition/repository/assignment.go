@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type GetAssignmentQuery struct {
+	JudgeID uint `form:"judge_id" field:"judge_id" binding:"required,gt=0"`
+}
+
+type AddAssignmentRequest struct {
+	JudgeID      uint `json:"judge_id" binding:"required,gt=0"`
+	SubmissionID uint `json:"submission_id" binding:"required,gt=0"`
+}
+
+type DeleteAssignmentRequest struct {
+	AssignmentID uint `json:"assignment_id" binding:"required,gt=0"`
+}
+
+type AutoAssignRequest struct {
+	TeamCategory        types.TeamCategory    `json:"team_category" binding:"required"`
+	Stage               types.SubmissionStage `json:"stage" binding:"required"`
+	JudgesPerSubmission int                   `json:"judges_per_submission" binding:"required,gt=0"`
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type GenerateCertificatesRequest struct {
+	TeamCategory types.TeamCategory `json:"team_category" binding:"required,oneof=competitive-programming datavidia uxvidia arkalogica"`
+}
+
+type GetCertificateBatchesQuery struct {
+	TeamCategory types.TeamCategory `form:"team_category" field:"team_category" binding:"omitempty,oneof=competitive-programming datavidia uxvidia arkalogica"`
+}
+
+type GetCertificatesQuery struct {
+	BatchID       uint               `form:"batch_id" field:"batch_id" binding:"omitempty,gt=0"`
+	ParticipantID uint               `form:"participant_id" field:"participant_id" binding:"omitempty,gt=0"`
+	TeamCategory  types.TeamCategory `form:"team_category" field:"team_category" binding:"omitempty,oneof=competitive-programming datavidia uxvidia arkalogica"`
+}
+
+type CertificateSerialQuery struct {
+	Serial string `form:"serial" field:"serial" binding:"required,uuid"`
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"time"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type AddQuestionOptionRequest struct {
+	Content   string `json:"content" binding:"required"`
+	IsCorrect bool   `json:"is_correct" binding:"omitempty"`
+}
+
+type AddQuestionRequest struct {
+	Type    types.QuestionType         `json:"type" binding:"required,oneof=multiple-choice short-answer"`
+	Content string                     `json:"content" binding:"required"`
+	Point   float64                    `json:"point" binding:"required,gt=0"`
+	Order   int                        `json:"order" binding:"omitempty"`
+	Key     string                     `json:"key" binding:"omitempty"`
+	Options []AddQuestionOptionRequest `json:"options" binding:"omitempty,dive"`
+}
+
+type AddExamRequest struct {
+	Name      string                `json:"name" binding:"required"`
+	Stage     types.SubmissionStage `json:"stage" binding:"required,oneof=first-stage second-stage final-stage"`
+	StartAt   time.Time             `json:"start_at" binding:"required"`
+	EndAt     time.Time             `json:"end_at" binding:"required,gtfield=StartAt"`
+	Duration  uint                  `json:"duration" binding:"required,gt=0"`
+	Questions []AddQuestionRequest  `json:"questions" binding:"required,min=1,dive"`
+}
+
+type ExamQuery struct {
+	ExamID uint `form:"exam_id" field:"exam_id" binding:"required,gt=0"`
+}
+
+type AttemptQuery struct {
+	AttemptID uint `form:"attempt_id" field:"attempt_id" binding:"required,gt=0"`
+}
+
+type SaveAnswerRequest struct {
+	QuestionID uint   `json:"question_id" binding:"required,gt=0"`
+	OptionID   *uint  `json:"option_id" binding:"omitempty"`
+	Text       string `json:"text" binding:"omitempty"`
+}
+
+type SaveAnswersRequest struct {
+	Answers []SaveAnswerRequest `json:"answers" binding:"required,min=1,dive"`
+}
+
+type GradeAnswerQuery struct {
+	AnswerID uint `form:"answer_id" field:"answer_id" binding:"required,gt=0"`
+}
+
+type GradeAnswerRequest struct {
+	Point *float64 `json:"point" binding:"required,gte=0"`
+}
+
+type CloseExamRequest struct {
+	ExamID uint `json:"exam_id" binding:"required,gt=0"`
+}
+
+type DecideExamRequest struct {
+	ExamID      uint `json:"exam_id" binding:"required,gt=0"`
+	PassedCount int  `json:"passed_count" binding:"gte=0"`
+}
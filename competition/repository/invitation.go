@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type GetInvitationsQuery struct {
+	TeamID uint `form:"team_id" field:"team_id" binding:"omitempty,gt=0"`
+}
+
+type InviteMemberRequest struct {
+	Name            string                           `json:"name" binding:"required,ascii"`
+	Email           string                           `json:"email" binding:"required,email"`
+	CareerInterests types.ParticipantCareerInterests `json:"career_interest" binding:"required,dive,oneof=software-engineering product-management ui-designer ux-designer ux-researcher it-consultant game-developer cyber-security business-analyst business-intelligence data-scientist data-analyst"`
+	Role            types.MembershipRole             `json:"role" binding:"required,oneof=leader member"`
+	Institution     string                           `json:"institution" binding:"omitempty"`
+	EducationLevel  types.EducationLevel             `json:"education_level" binding:"omitempty,oneof=high-school university"`
+	StudentIDNumber string                           `json:"student_id_number" binding:"omitempty,alphanum"`
+	GraduationYear  int                              `json:"graduation_year" binding:"omitempty,gt=1900"`
+	Phone           string                           `json:"phone" binding:"omitempty,e164"`
+}
+
+type RevokeInvitationRequest struct {
+	InvitationID uint `json:"invitation_id" binding:"required,gt=0"`
+}
+
+type InvitationTokenQuery struct {
+	Token string `form:"token" field:"token" binding:"required,hexadecimal"`
+}
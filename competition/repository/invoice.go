@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type GetInvoicesQuery struct {
+	TeamID       uint                `form:"team_id" field:"team_id" binding:"omitempty,gt=0"`
+	TeamCategory types.TeamCategory  `form:"team_category" field:"team_category" binding:"omitempty,oneof=competitive-programming datavidia uxvidia arkalogica"`
+	Status       types.InvoiceStatus `form:"status" field:"status" binding:"omitempty,oneof=pending paid expired cancelled"`
+}
+
+type CreateInvoiceRequest struct {
+	TeamCategory types.TeamCategory `json:"team_category" binding:"required,oneof=competitive-programming datavidia uxvidia arkalogica"`
+	VoucherCode  string             `json:"voucher_code" binding:"omitempty"`
+}
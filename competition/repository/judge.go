@@ -0,0 +1,25 @@
+package repository
+
+type SignInJudgeRequest struct {
+	Username string `json:"username" binding:"required,ascii"`
+	Password string `json:"password" binding:"required,ascii"`
+}
+
+type GetAllJudgesQuery struct {
+	Page int `form:"page" field:"page" binding:"required,gt=0"`
+	Size int `form:"size" field:"size" binding:"required,gt=0"`
+}
+
+type AddJudgeRequest struct {
+	Username string `json:"username" binding:"required,ascii"`
+	Password string `json:"password" binding:"required,ascii"`
+	Name     string `json:"name" binding:"required"`
+}
+
+type GetJudgeConflictsQuery struct {
+	JudgeID uint `form:"judge_id" field:"judge_id" binding:"omitempty,gt=0"`
+}
+
+type SetJudgeConflictsRequest struct {
+	Institutions []string `json:"institutions" binding:"omitempty,dive,required"`
+}
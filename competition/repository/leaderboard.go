@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"mime/multipart"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type GetLeaderboardQuery struct {
+	Stage     types.SubmissionStage `form:"stage" field:"stage" binding:"required,oneof=first-stage second-stage final-stage"`
+	IsPrivate bool                  `form:"is_private" field:"is_private" binding:"omitempty"`
+}
+
+type SetGroundTruthRequest struct {
+	Stage        types.SubmissionStage `form:"stage" field:"stage" binding:"required,oneof=first-stage second-stage final-stage"`
+	Metric       types.ScoringMetric   `form:"metric" field:"metric" binding:"required,oneof=rmse mae accuracy macro-f1"`
+	IDColumn     string                `form:"id_column" field:"id_column" binding:"required"`
+	TargetColumn string                `form:"target_column" field:"target_column" binding:"required"`
+	DailyLimit   int                   `form:"daily_limit" field:"daily_limit" binding:"required,gt=0"`
+	File         *multipart.FileHeader `form:"file" field:"file" binding:"required"`
+}
+
+type RevealLeaderboardQuery struct {
+	Stage types.SubmissionStage `form:"stage" field:"stage" binding:"required,oneof=first-stage second-stage final-stage"`
+}
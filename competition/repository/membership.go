@@ -9,4 +9,9 @@ type SignUpMembership struct {
 	Email           string                           `json:"email" binding:"required,email"`
 	CareerInterests types.ParticipantCareerInterests `json:"career_interest" binding:"required,dive,oneof=software-engineering product-management ui-designer ux-designer ux-researcher it-consultant game-developer cyber-security business-analyst business-intelligence data-scientist data-analyst"`
 	Role            types.MembershipRole             `json:"role" binding:"required,oneof=leader member"`
+	Institution     string                           `json:"institution" binding:"omitempty"`
+	EducationLevel  types.EducationLevel             `json:"education_level" binding:"omitempty,oneof=high-school university"`
+	StudentIDNumber string                           `json:"student_id_number" binding:"omitempty,alphanum"`
+	GraduationYear  int                              `json:"graduation_year" binding:"omitempty,gt=1900"`
+	Phone           string                           `json:"phone" binding:"omitempty,e164"`
 }
@@ -13,13 +13,6 @@ type GetAllMembersQuery struct {
 	Size int `form:"size" field:"size" binding:"required,gt=0"`
 }
 
-type AddMemberRequest struct {
-	Name            string                           `json:"name" binding:"required,ascii"`
-	Email           string                           `json:"email" binding:"required,email"`
-	CareerInterests types.ParticipantCareerInterests `json:"career_interest" binding:"required,dive,oneof=software-engineering product-management ui-designer ux-designer ux-researcher it-consultant game-developer cyber-security business-analyst business-intelligence data-scientist data-analyst"`
-	Role            types.MembershipRole             `json:"role" binding:"required,oneof=leader member"`
-}
-
 type ChangeCareerInterestQuery struct {
 	ParticipantID uint `form:"participant_id" field:"participant_id" binding:"required,gt=0"`
 }
@@ -28,6 +21,18 @@ type ChangeCareerInterestRequest struct {
 	CareerInterests types.ParticipantCareerInterests `json:"career_interest" binding:"required,dive,oneof=software-engineering product-management ui-designer ux-designer ux-researcher it-consultant game-developer cyber-security business-analyst business-intelligence data-scientist data-analyst"`
 }
 
+type ChangeProfileQuery struct {
+	ParticipantID uint `form:"participant_id" field:"participant_id" binding:"required,gt=0"`
+}
+
+type ChangeProfileRequest struct {
+	Institution     string               `json:"institution" binding:"omitempty"`
+	EducationLevel  types.EducationLevel `json:"education_level" binding:"omitempty,oneof=high-school university"`
+	StudentIDNumber string               `json:"student_id_number" binding:"omitempty,alphanum"`
+	GraduationYear  int                  `json:"graduation_year" binding:"omitempty,gt=1900"`
+	Phone           string               `json:"phone" binding:"omitempty,e164"`
+}
+
 type ChangeRoleQuery struct {
 	ParticipantID uint `form:"participant_id" field:"participant_id" binding:"required,gt=0"`
 }
@@ -46,4 +51,46 @@ type ChangeStatusParticipantRequest struct {
 
 type DeleteMemberRequest struct {
 	ParticipantID uint `json:"participant_id" binding:"required,gt=0"`
+	SuccessorID   uint `json:"successor_id" binding:"omitempty,gt=0"`
+}
+
+type TransferLeadershipRequest struct {
+	SuccessorID uint `json:"successor_id" binding:"required,gt=0"`
+}
+
+type GetRoleChangesQuery struct {
+	TeamID uint `form:"team_id" field:"team_id" binding:"required,gt=0"`
+}
+
+type MergeParticipantsRequest struct {
+	SurvivorID  uint `json:"survivor_id" binding:"required,gt=0"`
+	DuplicateID uint `json:"duplicate_id" binding:"required,gt=0,nefield=SurvivorID"`
+}
+
+type RequestParticipantAccessRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type GetProfileQuery struct {
+	ParticipantID uint `form:"participant_id" field:"participant_id" binding:"omitempty,gt=0"`
+}
+
+type ExportParticipantQuery struct {
+	ParticipantID uint `form:"participant_id" field:"participant_id" binding:"omitempty,gt=0"`
+}
+
+type GetDeletionRequestsQuery struct {
+	Status types.DeletionRequestStatus `form:"status" field:"status" binding:"omitempty,oneof=pending confirmed rejected"`
+}
+
+type RequestDeletionQuery struct {
+	ParticipantID uint `form:"participant_id" field:"participant_id" binding:"omitempty,gt=0"`
+}
+
+type RequestDeletionRequest struct {
+	Reason string `json:"reason" binding:"omitempty"`
+}
+
+type ProcessDeletionRequestRequest struct {
+	DeletionRequestID uint `json:"deletion_request_id" binding:"required,gt=0"`
 }
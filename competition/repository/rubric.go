@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type GetRubricQuery struct {
+	TeamCategory types.TeamCategory    `form:"team_category" field:"team_category" binding:"required,oneof=competitive-programming datavidia uxvidia arkalogica"`
+	Stage        types.SubmissionStage `form:"stage" field:"stage" binding:"required,oneof=first-stage second-stage final-stage"`
+}
+
+type AddCriterionRequest struct {
+	Name        string  `json:"name" binding:"required"`
+	Description string  `json:"description" binding:"omitempty"`
+	Weight      float64 `json:"weight" binding:"required,gt=0"`
+	MaxScore    float64 `json:"max_score" binding:"required,gt=0"`
+}
+
+type AddRubricRequest struct {
+	TeamCategory types.TeamCategory    `json:"team_category" binding:"required,oneof=competitive-programming datavidia uxvidia arkalogica"`
+	Stage        types.SubmissionStage `json:"stage" binding:"required,oneof=first-stage second-stage final-stage"`
+	Criteria     []AddCriterionRequest `json:"criteria" binding:"required,min=1,dive"`
+}
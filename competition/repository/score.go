@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type GetScoreQuery struct {
+	AssignmentID uint `form:"assignment_id" field:"assignment_id" binding:"required,gt=0"`
+}
+
+type SetScoreCriterion struct {
+	CriterionID uint    `json:"criterion_id" binding:"required,gt=0"`
+	Value       float64 `json:"value" binding:"gte=0"`
+	Comment     string  `json:"comment" binding:"omitempty"`
+}
+
+type SetScoreQuery struct {
+	AssignmentID uint `form:"assignment_id" field:"assignment_id" binding:"required,gt=0"`
+}
+
+type SetScoreRequest struct {
+	Scores []SetScoreCriterion `json:"scores" binding:"required,min=1,dive"`
+}
+
+type GetRankingQuery struct {
+	TeamCategory types.TeamCategory    `form:"team_category" field:"team_category" binding:"required,oneof=competitive-programming datavidia uxvidia arkalogica"`
+	Stage        types.SubmissionStage `form:"stage" field:"stage" binding:"required,oneof=first-stage second-stage final-stage"`
+}
+
+type DecideStageRequest struct {
+	TeamCategory types.TeamCategory    `json:"team_category" binding:"required,oneof=competitive-programming datavidia uxvidia arkalogica"`
+	Stage        types.SubmissionStage `json:"stage" binding:"required,oneof=first-stage second-stage final-stage"`
+	PassedCount  int                   `json:"passed_count" binding:"gte=0"`
+}
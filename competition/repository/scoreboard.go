@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"mime/multipart"
+)
+
+type GetScoreboardQuery struct {
+	ContestID uint `form:"contest_id" field:"contest_id" binding:"required,gt=0"`
+}
+
+type ImportEventFeedRequest struct {
+	File *multipart.FileHeader `form:"file" field:"file" binding:"omitempty"`
+}
+
+type FreezeScoreboardQuery struct {
+	ContestID uint  `form:"contest_id" field:"contest_id" binding:"required,gt=0"`
+	IsFrozen  *bool `form:"is_frozen" field:"is_frozen" binding:"required"`
+}
+
+type GetContestTeamsQuery struct {
+	ContestID uint `form:"contest_id" field:"contest_id" binding:"required,gt=0"`
+}
+
+type MapContestTeamQuery struct {
+	ContestTeamID uint `form:"contest_team_id" field:"contest_team_id" binding:"required,gt=0"`
+	TeamID        uint `form:"team_id" field:"team_id" binding:"required,gt=0"`
+}
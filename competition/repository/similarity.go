@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type GetSimilarityAnalysesQuery struct {
+	TeamCategory types.TeamCategory    `form:"team_category" field:"team_category" binding:"omitempty,oneof=competitive-programming datavidia uxvidia arkalogica"`
+	Stage        types.SubmissionStage `form:"stage" field:"stage" binding:"omitempty,oneof=first-stage second-stage final-stage"`
+}
+
+type GetSimilarityReportQuery struct {
+	AnalysisID uint `form:"analysis_id" field:"analysis_id" binding:"required,gt=0"`
+}
+
+type AddSimilarityAnalysisRequest struct {
+	TeamCategory types.TeamCategory    `json:"team_category" binding:"required,oneof=competitive-programming datavidia uxvidia arkalogica"`
+	Stage        types.SubmissionStage `json:"stage" binding:"required,oneof=first-stage second-stage final-stage"`
+	Threshold    float64               `json:"threshold" binding:"gte=0,lte=1"`
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"time"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type GetSubmissionWindowsQuery struct {
+	TeamCategory types.TeamCategory `form:"team_category" field:"team_category" binding:"omitempty,oneof=competitive-programming datavidia uxvidia arkalogica"`
+}
+
+type SetSubmissionWindowRequest struct {
+	TeamCategory types.TeamCategory    `json:"team_category" binding:"required,oneof=competitive-programming datavidia uxvidia arkalogica"`
+	Stage        types.SubmissionStage `json:"stage" binding:"required,oneof=first-stage second-stage final-stage"`
+	OpenAt       time.Time             `json:"open_at" binding:"required"`
+	CloseAt      time.Time             `json:"close_at" binding:"required,gtfield=OpenAt"`
+	GracePeriod  uint                  `json:"grace_period" binding:"omitempty"`
+}
+
+type GrantDeadlineExtensionQuery struct {
+	TeamID uint `form:"team_id" field:"team_id" binding:"required,gt=0"`
+}
+
+type GrantDeadlineExtensionRequest struct {
+	TeamCategory types.TeamCategory    `json:"team_category" binding:"required,oneof=competitive-programming datavidia uxvidia arkalogica"`
+	Stage        types.SubmissionStage `json:"stage" binding:"required,oneof=first-stage second-stage final-stage"`
+	CloseAt      time.Time             `json:"close_at" binding:"required"`
+}
@@ -7,7 +7,12 @@ import (
 )
 
 type GetSubmissionQuery struct {
-	TeamID uint `form:"team_id" field:"team_id" binding:"required,gt=0"`
+	TeamID       uint               `form:"team_id" field:"team_id" binding:"required,gt=0"`
+	TeamCategory types.TeamCategory `form:"team_category" field:"team_category" binding:"omitempty,oneof=competitive-programming datavidia uxvidia arkalogica"`
+}
+
+type GetTeamSubmissionQuery struct {
+	TeamCategory types.TeamCategory `form:"team_category" field:"team_category" binding:"omitempty,oneof=competitive-programming datavidia uxvidia arkalogica"`
 }
 
 type GetAllSubmissionsQuery struct {
@@ -20,11 +25,25 @@ type DownloadSubmissionQuery struct {
 }
 
 type AddSubmissionRequest struct {
-	Stage types.SubmissionStage `form:"stage" field:"stage" binding:"required,oneof=first-stage second-stage final-stage"`
-	File  *multipart.FileHeader `form:"file" field:"file" binding:"required"`
+	TeamCategory types.TeamCategory    `form:"team_category" field:"team_category" binding:"required,oneof=competitive-programming datavidia uxvidia arkalogica"`
+	Stage        types.SubmissionStage `form:"stage" field:"stage" binding:"required,oneof=first-stage second-stage final-stage"`
+	File         *multipart.FileHeader `form:"file" field:"file" binding:"required"`
+}
+
+type SetFinalSubmissionQuery struct {
+	SubmissionID uint `form:"submission_id" field:"submission_id" binding:"required,gt=0"`
 }
 
 type DeleteSubmissionRequest struct {
 	FileName      string `json:"file_name" binding:"required,uuid"`
 	FileExtension string `json:"file_extension" binding:"required,alpha"`
 }
+
+type GetReceiptQuery struct {
+	SubmissionID uint `form:"submission_id" field:"submission_id" binding:"required,gt=0"`
+}
+
+type VerifyReceiptQuery struct {
+	Code   string `form:"code" field:"code" binding:"required_without=Serial"`
+	Serial string `form:"serial" field:"serial" binding:"omitempty,uuid"`
+}
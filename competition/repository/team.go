@@ -10,10 +10,12 @@ type SignInTeamRequest struct {
 }
 
 type SignUpTeamRequest struct {
-	Username string             `json:"username" binding:"required,ascii"`
-	Password string             `json:"password" binding:"required,ascii"`
-	TeamName string             `json:"team_name" binding:"required,ascii"`
-	Members  []SignUpMembership `json:"member_list" binding:"required,dive"`
+	Username       string               `json:"username" binding:"required,ascii"`
+	Password       string               `json:"password" binding:"required,ascii"`
+	TeamName       string               `json:"team_name" binding:"required,ascii"`
+	Institution    string               `json:"institution" binding:"omitempty"`
+	EducationLevel types.EducationLevel `json:"education_level" binding:"omitempty,oneof=high-school university"`
+	Members        []SignUpMembership   `json:"member_list" binding:"required,dive"`
 }
 
 type GetTeamQuery struct {
@@ -30,14 +32,44 @@ type ChangePasswordRequest struct {
 	Password string `json:"password" binding:"required,ascii"`
 }
 
+type ChangeTeamProfileRequest struct {
+	Institution    string               `json:"institution" binding:"omitempty"`
+	EducationLevel types.EducationLevel `json:"education_level" binding:"omitempty,oneof=high-school university"`
+}
+
 type CompetitionRegistrationQuery struct {
 	TeamCategory types.TeamCategory `form:"competition" field:"competition" binding:"required,oneof=competitive-programming datavidia uxvidia arkalogica"`
 }
 
 type ChangeStatusTeamQuery struct {
-	TeamID uint `form:"team_id" field:"team_id" binding:"required,gt=0"`
+	EnrolmentID uint `form:"enrolment_id" field:"enrolment_id" binding:"required,gt=0"`
 }
 
 type ChangeStatusTeamRequest struct {
 	Status types.TeamStatus `json:"status" binding:"required,oneof=waiting-for-evaluation passed eliminated"`
 }
+
+type WithdrawTeamRequest struct {
+	TeamCategory types.TeamCategory `json:"team_category" binding:"omitempty,oneof=competitive-programming datavidia uxvidia arkalogica"`
+	Reason       string             `json:"reason" binding:"required"`
+}
+
+type GetTeamWithdrawalsQuery struct {
+	TeamID uint `form:"team_id" field:"team_id" binding:"omitempty,gt=0"`
+}
+
+type RevertWithdrawalRequest struct {
+	WithdrawalID uint `json:"withdrawal_id" binding:"required,gt=0"`
+}
+
+type GetChecklistQuery struct {
+	TeamID uint `form:"team_id" field:"team_id" binding:"required,gt=0"`
+}
+
+type GetAllChecklistsQuery struct {
+	Page         int                `form:"page" field:"page" binding:"required,gt=0"`
+	Size         int                `form:"size" field:"size" binding:"required,gt=0"`
+	TeamCategory types.TeamCategory `form:"team_category" field:"team_category" binding:"omitempty,oneof=competitive-programming datavidia uxvidia arkalogica"`
+	IsIncomplete bool               `form:"is_incomplete" field:"is_incomplete" binding:"omitempty"`
+	Incomplete   string             `form:"incomplete" field:"incomplete" binding:"omitempty,oneof=photo email-confirmation payment team-size submission"`
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"time"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type GetVouchersQuery struct {
+	TeamCategory types.TeamCategory `form:"team_category" field:"team_category" binding:"omitempty,oneof=competitive-programming datavidia uxvidia arkalogica"`
+}
+
+type SetVoucherRequest struct {
+	Code           string               `json:"code" binding:"required,max=32"`
+	DiscountType   types.DiscountType   `json:"discount_type" binding:"required,oneof=percentage fixed"`
+	DiscountValue  uint                 `json:"discount_value" binding:"required,gt=0"`
+	TeamCategories types.TeamCategories `json:"team_categories" binding:"omitempty,dive,oneof=competitive-programming datavidia uxvidia arkalogica"`
+	ValidFrom      *time.Time           `json:"valid_from" binding:"omitempty"`
+	ValidUntil     *time.Time           `json:"valid_until" binding:"omitempty"`
+	UsageLimit     uint                 `json:"usage_limit" binding:"omitempty"`
+	IsDisabled     bool                 `json:"is_disabled" binding:"omitempty"`
+}
+
+type GetVoucherRedemptionsQuery struct {
+	VoucherID    uint               `form:"voucher_id" field:"voucher_id" binding:"omitempty,gt=0"`
+	TeamCategory types.TeamCategory `form:"team_category" field:"team_category" binding:"omitempty,oneof=competitive-programming datavidia uxvidia arkalogica"`
+}
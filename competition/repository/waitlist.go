@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type GetCategoryQuotasQuery struct {
+	TeamCategory types.TeamCategory `form:"team_category" field:"team_category" binding:"omitempty,oneof=competitive-programming datavidia uxvidia arkalogica"`
+}
+
+type SetCategoryQuotaRequest struct {
+	TeamCategory         types.TeamCategory `json:"team_category" binding:"required,oneof=competitive-programming datavidia uxvidia arkalogica"`
+	RegistrationCapacity uint               `json:"registration_capacity" binding:"omitempty"`
+	FinalCapacity        uint               `json:"final_capacity" binding:"omitempty"`
+	RegistrationFee      uint               `json:"registration_fee" binding:"omitempty"`
+}
+
+type GetWaitlistQuery struct {
+	TeamCategory types.TeamCategory `form:"team_category" field:"team_category" binding:"required,oneof=competitive-programming datavidia uxvidia arkalogica"`
+}
+
+type ReorderWaitlistRequest struct {
+	WaitlistEntryID uint `json:"waitlist_entry_id" binding:"required,gt=0"`
+	Position        uint `json:"position" binding:"required,gt=0"`
+}
+
+type LeaveWaitlistQuery struct {
+	TeamCategory types.TeamCategory `form:"team_category" field:"team_category" binding:"required,oneof=competitive-programming datavidia uxvidia arkalogica"`
+}
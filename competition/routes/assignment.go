@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"arkavidia-backend-8.0/competition/controllers"
+	"arkavidia-backend-8.0/competition/middlewares"
+)
+
+func AssignmentRoute(route *gin.Engine) {
+	assignmentGroup := route.Group("/assignment")
+
+	assignmentGroup.GET("/", middlewares.AuthMiddleware(), controllers.GetAssignmentsHandler())
+	assignmentGroup.POST("/", middlewares.AuthMiddleware(), controllers.AddAssignmentHandler())
+	assignmentGroup.POST("/auto", middlewares.AuthMiddleware(), controllers.AutoAssignHandler())
+	assignmentGroup.DELETE("/", middlewares.AuthMiddleware(), controllers.DeleteAssignmentHandler())
+}
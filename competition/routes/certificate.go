@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"arkavidia-backend-8.0/competition/controllers"
+	"arkavidia-backend-8.0/competition/middlewares"
+)
+
+func CertificateRoute(route *gin.Engine) {
+	certificateGroup := route.Group("/certificate")
+
+	certificateGroup.GET("/", middlewares.AuthMiddleware(), controllers.GetCertificatesHandler())
+	certificateGroup.GET("/download", middlewares.AuthMiddleware(), controllers.DownloadCertificateHandler())
+	certificateGroup.GET("/verify", controllers.VerifyCertificateHandler())
+	certificateGroup.GET("/batch", middlewares.AuthMiddleware(), controllers.GetCertificateBatchesHandler())
+	certificateGroup.POST("/batch", middlewares.AuthMiddleware(), controllers.GenerateCertificatesHandler())
+}
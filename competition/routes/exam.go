@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"arkavidia-backend-8.0/competition/controllers"
+	"arkavidia-backend-8.0/competition/middlewares"
+	"arkavidia-backend-8.0/competition/utils/cache"
+)
+
+func ExamRoute(route *gin.Engine) {
+	examGroup := route.Group("/exam")
+
+	examGroup.GET("/", middlewares.AuthMiddleware(), cache.Store.GetHandlerFunc(controllers.GetExamsHandler()))
+	examGroup.GET("/question", middlewares.AuthMiddleware(), controllers.GetExamQuestionsHandler())
+	examGroup.GET("/attempt", middlewares.AuthMiddleware(), controllers.GetAttemptHandler())
+	examGroup.GET("/export", middlewares.AuthMiddleware(), controllers.ExportAnswersHandler())
+	examGroup.GET("/result", middlewares.AuthMiddleware(), controllers.GetExamResultHandler())
+	examGroup.POST("/", middlewares.AuthMiddleware(), controllers.AddExamHandler())
+	examGroup.POST("/attempt", middlewares.AuthMiddleware(), controllers.StartAttemptHandler())
+	examGroup.POST("/close", middlewares.AuthMiddleware(), controllers.CloseExamHandler())
+	examGroup.PUT("/answer", middlewares.AuthMiddleware(), controllers.SaveAnswersHandler())
+	examGroup.PUT("/submit", middlewares.AuthMiddleware(), controllers.SubmitAttemptHandler())
+	examGroup.PUT("/grade", middlewares.AuthMiddleware(), controllers.GradeAnswerHandler())
+	examGroup.PUT("/decision", middlewares.AuthMiddleware(), controllers.DecideExamHandler())
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"arkavidia-backend-8.0/competition/controllers"
+	"arkavidia-backend-8.0/competition/middlewares"
+)
+
+func InvitationRoute(route *gin.Engine) {
+	invitationGroup := route.Group("/invitation")
+
+	invitationGroup.GET("/", middlewares.AuthMiddleware(), controllers.GetInvitationsHandler())
+	invitationGroup.POST("/", middlewares.AuthMiddleware(), controllers.InviteMemberHandler())
+	invitationGroup.DELETE("/", middlewares.AuthMiddleware(), controllers.RevokeInvitationHandler())
+	invitationGroup.GET("/detail", controllers.GetInvitationDetailHandler())
+	invitationGroup.PUT("/accept", controllers.AcceptInvitationHandler())
+	invitationGroup.PUT("/decline", controllers.DeclineInvitationHandler())
+}
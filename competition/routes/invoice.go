@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"arkavidia-backend-8.0/competition/controllers"
+	"arkavidia-backend-8.0/competition/middlewares"
+)
+
+func InvoiceRoute(route *gin.Engine) {
+	invoiceGroup := route.Group("/invoice")
+
+	invoiceGroup.GET("/", middlewares.AuthMiddleware(), controllers.GetInvoicesHandler())
+	invoiceGroup.POST("/", middlewares.AuthMiddleware(), controllers.CreateInvoiceHandler())
+	invoiceGroup.POST("/webhook", controllers.PaymentWebhookHandler())
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"arkavidia-backend-8.0/competition/controllers"
+	"arkavidia-backend-8.0/competition/middlewares"
+	"arkavidia-backend-8.0/competition/utils/cache"
+)
+
+func JudgeRoute(route *gin.Engine) {
+	judgeGroup := route.Group("/judge")
+
+	judgeGroup.GET("/all", middlewares.AuthMiddleware(), cache.Store.GetHandlerFunc(controllers.GetAllJudgesHandler()))
+	judgeGroup.POST("/sign-in", controllers.SignInJudgeHandler())
+	judgeGroup.POST("/", middlewares.AuthMiddleware(), controllers.AddJudgeHandler())
+	judgeGroup.GET("/conflict", middlewares.AuthMiddleware(), controllers.GetJudgeConflictsHandler())
+	judgeGroup.PUT("/conflict", middlewares.AuthMiddleware(), controllers.SetJudgeConflictsHandler())
+}
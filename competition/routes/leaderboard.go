@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"arkavidia-backend-8.0/competition/controllers"
+	"arkavidia-backend-8.0/competition/middlewares"
+)
+
+func LeaderboardRoute(route *gin.Engine) {
+	leaderboardGroup := route.Group("/leaderboard")
+
+	leaderboardGroup.GET("/", controllers.GetLeaderboardHandler())
+	leaderboardGroup.PUT("/ground-truth", middlewares.AuthMiddleware(), controllers.SetGroundTruthHandler())
+	leaderboardGroup.PUT("/reveal", middlewares.AuthMiddleware(), controllers.RevealLeaderboardHandler())
+}
@@ -13,9 +13,22 @@ func ParticipantRoute(route *gin.Engine) {
 
 	participantGroup.GET("/", middlewares.AuthMiddleware(), cache.Store.GetHandlerFunc(controllers.GetMemberHandler()))
 	participantGroup.GET("/all", middlewares.AuthMiddleware(), cache.Store.GetHandlerFunc(controllers.GetAllMembersHandler()))
-	participantGroup.POST("/", middlewares.AuthMiddleware(), controllers.AddMemberHandler())
 	participantGroup.PUT("/career-interest", middlewares.AuthMiddleware(), controllers.ChangeCareerInterestHandler())
+	participantGroup.GET("/profile", middlewares.AuthMiddleware(), controllers.GetProfileHandler())
+	participantGroup.PUT("/profile", middlewares.AuthMiddleware(), controllers.ChangeProfileHandler())
+	participantGroup.POST("/access", controllers.RequestParticipantAccessHandler())
+	participantGroup.PUT("/email-confirmation", middlewares.AuthMiddleware(), controllers.ConfirmEmailHandler())
+	participantGroup.GET("/export", middlewares.AuthMiddleware(), controllers.ExportParticipantHandler())
+	participantGroup.GET("/deletion", middlewares.AuthMiddleware(), controllers.GetDeletionRequestsHandler())
+	participantGroup.POST("/deletion", middlewares.AuthMiddleware(), controllers.RequestDeletionHandler())
+	participantGroup.PUT("/deletion/confirm", middlewares.AuthMiddleware(), controllers.ConfirmDeletionRequestHandler())
+	participantGroup.PUT("/deletion/reject", middlewares.AuthMiddleware(), controllers.RejectDeletionRequestHandler())
 	participantGroup.PUT("/role", middlewares.AuthMiddleware(), controllers.ChangeRoleHandler())
+	participantGroup.PUT("/leader", middlewares.AuthMiddleware(), controllers.TransferLeadershipHandler())
+	participantGroup.GET("/role-history", middlewares.AuthMiddleware(), controllers.GetRoleChangesHandler())
 	participantGroup.PUT("/status", middlewares.AuthMiddleware(), controllers.ChangeStatusParticipantHandler())
+	participantGroup.GET("/duplicate", middlewares.AuthMiddleware(), controllers.GetDuplicateParticipantsHandler())
+	participantGroup.GET("/merge", middlewares.AuthMiddleware(), controllers.GetParticipantMergesHandler())
+	participantGroup.POST("/merge", middlewares.AuthMiddleware(), controllers.MergeParticipantsHandler())
 	participantGroup.DELETE("/", middlewares.AuthMiddleware(), controllers.DeleteParticipantHandler())
 }
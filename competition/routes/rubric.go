@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"arkavidia-backend-8.0/competition/controllers"
+	"arkavidia-backend-8.0/competition/middlewares"
+	"arkavidia-backend-8.0/competition/utils/cache"
+)
+
+func RubricRoute(route *gin.Engine) {
+	rubricGroup := route.Group("/rubric")
+
+	rubricGroup.GET("/", middlewares.AuthMiddleware(), cache.Store.GetHandlerFunc(controllers.GetRubricHandler()))
+	rubricGroup.POST("/", middlewares.AuthMiddleware(), controllers.AddRubricHandler())
+}
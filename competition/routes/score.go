@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"arkavidia-backend-8.0/competition/controllers"
+	"arkavidia-backend-8.0/competition/middlewares"
+)
+
+func ScoreRoute(route *gin.Engine) {
+	scoreGroup := route.Group("/score")
+
+	scoreGroup.GET("/", middlewares.AuthMiddleware(), controllers.GetScoreHandler())
+	scoreGroup.GET("/ranking", middlewares.AuthMiddleware(), controllers.GetRankingHandler())
+	scoreGroup.PUT("/", middlewares.AuthMiddleware(), controllers.SetScoreHandler())
+	scoreGroup.PUT("/decision", middlewares.AuthMiddleware(), controllers.DecideStageHandler())
+}
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"arkavidia-backend-8.0/competition/controllers"
+	"arkavidia-backend-8.0/competition/middlewares"
+	"arkavidia-backend-8.0/competition/utils/cache"
+)
+
+func ScoreboardRoute(route *gin.Engine) {
+	scoreboardGroup := route.Group("/scoreboard")
+
+	scoreboardGroup.GET("/", controllers.GetScoreboardHandler())
+	scoreboardGroup.GET("/contest", cache.Store.GetHandlerFunc(controllers.GetContestsHandler()))
+	scoreboardGroup.GET("/unfrozen", middlewares.AuthMiddleware(), controllers.GetUnfrozenScoreboardHandler())
+	scoreboardGroup.GET("/team", middlewares.AuthMiddleware(), controllers.GetContestTeamsHandler())
+	scoreboardGroup.POST("/import", middlewares.AuthMiddleware(), controllers.ImportEventFeedHandler())
+	scoreboardGroup.PUT("/freeze", middlewares.AuthMiddleware(), controllers.FreezeScoreboardHandler())
+	scoreboardGroup.PUT("/team", middlewares.AuthMiddleware(), controllers.MapContestTeamHandler())
+}
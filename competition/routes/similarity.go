@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"arkavidia-backend-8.0/competition/controllers"
+	"arkavidia-backend-8.0/competition/middlewares"
+)
+
+func SimilarityRoute(route *gin.Engine) {
+	similarityGroup := route.Group("/similarity")
+
+	similarityGroup.GET("/", middlewares.AuthMiddleware(), controllers.GetSimilarityAnalysesHandler())
+	similarityGroup.GET("/report", middlewares.AuthMiddleware(), controllers.GetSimilarityReportHandler())
+	similarityGroup.POST("/", middlewares.AuthMiddleware(), controllers.AddSimilarityAnalysisHandler())
+}
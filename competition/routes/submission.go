@@ -13,8 +13,15 @@ func SubmissionRoute(route *gin.Engine) {
 
 	submissionGroup.GET("/", middlewares.AuthMiddleware(), cache.Store.GetHandlerFunc(controllers.GetSubmissionHandler()))
 	submissionGroup.GET("/all", middlewares.AuthMiddleware(), cache.Store.GetHandlerFunc(controllers.GetAllSubmissionsHandler()))
-	submissionGroup.GET("/download", middlewares.AuthMiddleware(), cache.Store.GetHandlerFunc(controllers.DownloadSubmissionHandler()))
+	submissionGroup.GET("/download", middlewares.AuthMiddleware(), controllers.DownloadSubmissionHandler())
 	submissionGroup.GET("/render", middlewares.AuthMiddleware(), cache.Store.GetHandlerFunc(controllers.RenderSubmissionHandler()))
+	submissionGroup.GET("/preview", middlewares.AuthMiddleware(), controllers.PreviewSubmissionHandler())
+	submissionGroup.GET("/receipt", middlewares.AuthMiddleware(), controllers.DownloadReceiptHandler())
+	submissionGroup.GET("/receipt/verify", controllers.VerifyReceiptHandler())
+	submissionGroup.GET("/window", cache.Store.GetHandlerFunc(controllers.GetSubmissionWindowsHandler()))
 	submissionGroup.POST("/", middlewares.AuthMiddleware(), controllers.AddSubmissionHandler())
+	submissionGroup.PUT("/final", middlewares.AuthMiddleware(), controllers.SetFinalSubmissionHandler())
+	submissionGroup.PUT("/window", middlewares.AuthMiddleware(), controllers.SetSubmissionWindowHandler())
+	submissionGroup.PUT("/extension", middlewares.AuthMiddleware(), controllers.GrantDeadlineExtensionHandler())
 	submissionGroup.DELETE("/", middlewares.AuthMiddleware(), controllers.DeleteSubmissionHandler())
 }
@@ -15,7 +15,14 @@ func TeamRoute(route *gin.Engine) {
 	groupTeam.GET("/all", middlewares.AuthMiddleware(), cache.Store.GetHandlerFunc(controllers.GetAllTeamsHandler()))
 	groupTeam.POST("/sign-in", controllers.SignInTeamHandler())
 	groupTeam.POST("/", controllers.SignUpTeamHandler())
+	groupTeam.GET("/checklist", middlewares.AuthMiddleware(), controllers.GetChecklistHandler())
+	groupTeam.GET("/checklist/all", middlewares.AuthMiddleware(), controllers.GetAllChecklistsHandler())
 	groupTeam.PUT("/password", middlewares.AuthMiddleware(), controllers.ChangePasswordHandler())
+	groupTeam.PUT("/profile", middlewares.AuthMiddleware(), controllers.ChangeTeamProfileHandler())
 	groupTeam.PUT("/registration", middlewares.AuthMiddleware(), controllers.CompetitionRegistration())
 	groupTeam.PUT("/status", middlewares.AuthMiddleware(), controllers.ChangeStatusTeamHandler())
+	groupTeam.GET("/withdrawal", middlewares.AuthMiddleware(), controllers.GetTeamWithdrawalsHandler())
+	groupTeam.POST("/withdrawal", middlewares.AuthMiddleware(), controllers.WithdrawTeamHandler())
+	groupTeam.PUT("/withdrawal/revert", middlewares.AuthMiddleware(), controllers.RevertWithdrawalHandler())
+	groupTeam.POST("/disband", middlewares.AuthMiddleware(), controllers.DisbandTeamHandler())
 }
@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"arkavidia-backend-8.0/competition/controllers"
+	"arkavidia-backend-8.0/competition/middlewares"
+)
+
+func VoucherRoute(route *gin.Engine) {
+	voucherGroup := route.Group("/voucher")
+
+	voucherGroup.GET("/", middlewares.AuthMiddleware(), controllers.GetVouchersHandler())
+	voucherGroup.PUT("/", middlewares.AuthMiddleware(), controllers.SetVoucherHandler())
+	voucherGroup.GET("/redemption", middlewares.AuthMiddleware(), controllers.GetVoucherRedemptionsHandler())
+}
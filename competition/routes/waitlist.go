@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"arkavidia-backend-8.0/competition/controllers"
+	"arkavidia-backend-8.0/competition/middlewares"
+)
+
+func WaitlistRoute(route *gin.Engine) {
+	waitlistGroup := route.Group("/waitlist")
+
+	waitlistGroup.GET("/", middlewares.AuthMiddleware(), controllers.GetWaitlistHandler())
+	waitlistGroup.DELETE("/", middlewares.AuthMiddleware(), controllers.LeaveWaitlistHandler())
+	waitlistGroup.PUT("/reorder", middlewares.AuthMiddleware(), controllers.ReorderWaitlistHandler())
+	waitlistGroup.GET("/quota", controllers.GetCategoryQuotasHandler())
+	waitlistGroup.PUT("/quota", middlewares.AuthMiddleware(), controllers.SetCategoryQuotaHandler())
+}
@@ -39,7 +39,7 @@ func (database *Database) lazyInit() {
 		db.Use(Plugins)
 
 		// Migrate Class
-		if err := db.AutoMigrate(&models.Participant{}, &models.Team{}, &models.Membership{}, &models.Photo{}, &models.Submission{}); err != nil {
+		if err := db.AutoMigrate(&models.Participant{}, &models.Team{}, &models.Enrolment{}, &models.Membership{}, &models.Photo{}, &models.Submission{}, &models.Preview{}, &models.SubmissionWindow{}, &models.DeadlineExtension{}, &models.Judge{}, &models.Rubric{}, &models.Criterion{}, &models.Assignment{}, &models.Score{}, &models.StageTransition{}, &models.GroundTruth{}, &models.DatavidiaScore{}, &models.Contest{}, &models.ContestTeam{}, &models.ContestProblem{}, &models.ContestJudgementType{}, &models.ContestSubmission{}, &models.Exam{}, &models.Question{}, &models.QuestionOption{}, &models.Attempt{}, &models.Answer{}, &models.SimilarityAnalysis{}, &models.SimilarityReport{}, &models.SimilarityRegion{}, &models.Receipt{}, &models.JudgeConflict{}, &models.Invitation{}, &models.RoleChange{}, &models.ParticipantMerge{}, &models.TeamWithdrawal{}, &models.WithdrawalRecord{}, &models.CategoryQuota{}, &models.WaitlistEntry{}, &models.DeletionRequest{}, &models.Voucher{}, &models.Invoice{}, &models.VoucherRedemption{}, &models.CertificateBatch{}, &models.Certificate{}); err != nil {
 			panic(err)
 		}
 
@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -36,11 +37,11 @@ func (storageClient *StorageClient) lazyInit() {
 }
 
 // Public
-func (storageClient *StorageClient) DownloadFile(filename string, downloadPath string) (io.Writer, error) {
+func (storageClient *StorageClient) DownloadFile(filename string, downloadPath string) ([]byte, error) {
 	storageClient.lazyInit()
 
 	// Duplicate Function Call Suppression Mechanism
-	v, err, _ := storageClient.requestGroup.Do(filename, func() (interface{}, error) {
+	v, err, _ := storageClient.requestGroup.Do(fmt.Sprintf("%s/%s", downloadPath, filename), func() (interface{}, error) {
 		config := storageConfig.Config.GetMetadata()
 		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(config.FileTimeout)*time.Second)
 		defer cancel()
@@ -51,18 +52,18 @@ func (storageClient *StorageClient) DownloadFile(filename string, downloadPath s
 		}
 		defer storageReader.Close()
 
-		var file io.Writer
-		if _, err := io.Copy(file, storageReader); err != nil {
+		file := bytes.Buffer{}
+		if _, err := io.Copy(&file, storageReader); err != nil {
 			return nil, err
 		}
 
-		return file, nil
+		return file.Bytes(), nil
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	file := v.(io.Writer)
+	file := v.([]byte)
 
 	return file, nil
 }
@@ -0,0 +1,26 @@
+package types
+
+import (
+	"database/sql/driver"
+)
+
+type AnalysisStatus string
+
+const (
+	AnalysisPending   AnalysisStatus = "pending"
+	AnalysisCompleted AnalysisStatus = "completed"
+	AnalysisFailed    AnalysisStatus = "failed"
+)
+
+func (analysisStatus *AnalysisStatus) Scan(value interface{}) error {
+	*analysisStatus = AnalysisStatus(value.(string))
+	return nil
+}
+
+func (analysisStatus AnalysisStatus) Value() (driver.Value, error) {
+	return string(analysisStatus), nil
+}
+
+func (AnalysisStatus) GormDataType() string {
+	return "analysis_status"
+}
@@ -0,0 +1,26 @@
+package types
+
+import (
+	"database/sql/driver"
+)
+
+type BatchStatus string
+
+const (
+	BatchPending   BatchStatus = "pending"
+	BatchCompleted BatchStatus = "completed"
+	BatchFailed    BatchStatus = "failed"
+)
+
+func (batchStatus *BatchStatus) Scan(value interface{}) error {
+	*batchStatus = BatchStatus(value.(string))
+	return nil
+}
+
+func (batchStatus BatchStatus) Value() (driver.Value, error) {
+	return string(batchStatus), nil
+}
+
+func (BatchStatus) GormDataType() string {
+	return "batch_status"
+}
@@ -0,0 +1,38 @@
+package types
+
+import (
+	"database/sql/driver"
+)
+
+type CertificateKind string
+
+const (
+	ParticipationCertificate CertificateKind = "participation"
+	FinalistCertificate      CertificateKind = "finalist"
+	WinnerCertificate        CertificateKind = "winner"
+)
+
+func (certificateKind *CertificateKind) Scan(value interface{}) error {
+	*certificateKind = CertificateKind(value.(string))
+	return nil
+}
+
+func (certificateKind CertificateKind) Value() (driver.Value, error) {
+	return string(certificateKind), nil
+}
+
+func (CertificateKind) GormDataType() string {
+	return "certificate_kind"
+}
+
+// Jenis sertifikat ditentukan dari stage terakhir yang dicapai dan status akhir team pada stage tersebut
+func GetCertificateKind(stage SubmissionStage, status TeamStatus) CertificateKind {
+	if stage != FinalStage {
+		return ParticipationCertificate
+	}
+	if status == Passed {
+		return WinnerCertificate
+	}
+
+	return FinalistCertificate
+}
@@ -0,0 +1,18 @@
+package types
+
+// Status item checklist dihitung saat diminta sehingga tidak disimpan di database
+type ChecklistStatus string
+
+const (
+	ChecklistMissing     ChecklistStatus = "missing"
+	ChecklistPending     ChecklistStatus = "pending"
+	ChecklistApproved    ChecklistStatus = "approved"
+	ChecklistDenied      ChecklistStatus = "denied"
+	ChecklistComplete    ChecklistStatus = "complete"
+	ChecklistIncomplete  ChecklistStatus = "incomplete"
+	ChecklistNotRequired ChecklistStatus = "not-required"
+)
+
+func (checklistStatus ChecklistStatus) IsDone() bool {
+	return checklistStatus == ChecklistApproved || checklistStatus == ChecklistComplete || checklistStatus == ChecklistNotRequired
+}
@@ -0,0 +1,26 @@
+package types
+
+import (
+	"database/sql/driver"
+)
+
+type DeletionRequestStatus string
+
+const (
+	DeletionPending   DeletionRequestStatus = "pending"
+	DeletionConfirmed DeletionRequestStatus = "confirmed"
+	DeletionRejected  DeletionRequestStatus = "rejected"
+)
+
+func (deletionRequestStatus *DeletionRequestStatus) Scan(value interface{}) error {
+	*deletionRequestStatus = DeletionRequestStatus(value.(string))
+	return nil
+}
+
+func (deletionRequestStatus DeletionRequestStatus) Value() (driver.Value, error) {
+	return string(deletionRequestStatus), nil
+}
+
+func (DeletionRequestStatus) GormDataType() string {
+	return "deletion_request_status"
+}
@@ -0,0 +1,25 @@
+package types
+
+import (
+	"database/sql/driver"
+)
+
+type DiscountType string
+
+const (
+	PercentageDiscount DiscountType = "percentage"
+	FixedDiscount      DiscountType = "fixed"
+)
+
+func (discountType *DiscountType) Scan(value interface{}) error {
+	*discountType = DiscountType(value.(string))
+	return nil
+}
+
+func (discountType DiscountType) Value() (driver.Value, error) {
+	return string(discountType), nil
+}
+
+func (DiscountType) GormDataType() string {
+	return "discount_type"
+}
@@ -0,0 +1,39 @@
+package types
+
+import (
+	"database/sql/driver"
+)
+
+type EducationLevel string
+
+const (
+	HighSchool EducationLevel = "high-school"
+	University EducationLevel = "university"
+)
+
+func (educationLevel *EducationLevel) Scan(value interface{}) error {
+	*educationLevel = EducationLevel(value.(string))
+	return nil
+}
+
+func (educationLevel EducationLevel) Value() (driver.Value, error) {
+	return string(educationLevel), nil
+}
+
+func (EducationLevel) GormDataType() string {
+	return "education_level"
+}
+
+// Dokumen yang wajib diunggah setiap anggota sesuai jenjang pendidikannya
+var requiredPhotoTypes = map[EducationLevel][]PhotoType{
+	HighSchool: {Pribadi, KartuPelajar},
+	University: {Pribadi, BuktiMahasiswaAktif},
+}
+
+func (educationLevel EducationLevel) GetRequiredPhotoTypes() []PhotoType {
+	if photoTypes, exists := requiredPhotoTypes[educationLevel]; exists {
+		return photoTypes
+	}
+
+	return []PhotoType{Pribadi}
+}
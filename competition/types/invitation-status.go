@@ -0,0 +1,28 @@
+package types
+
+import (
+	"database/sql/driver"
+)
+
+type InvitationStatus string
+
+const (
+	InvitationPending  InvitationStatus = "pending"
+	InvitationAccepted InvitationStatus = "accepted"
+	InvitationDeclined InvitationStatus = "declined"
+	InvitationRevoked  InvitationStatus = "revoked"
+	InvitationExpired  InvitationStatus = "expired"
+)
+
+func (invitationStatus *InvitationStatus) Scan(value interface{}) error {
+	*invitationStatus = InvitationStatus(value.(string))
+	return nil
+}
+
+func (invitationStatus InvitationStatus) Value() (driver.Value, error) {
+	return string(invitationStatus), nil
+}
+
+func (InvitationStatus) GormDataType() string {
+	return "invitation_status"
+}
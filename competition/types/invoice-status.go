@@ -0,0 +1,27 @@
+package types
+
+import (
+	"database/sql/driver"
+)
+
+type InvoiceStatus string
+
+const (
+	InvoicePending   InvoiceStatus = "pending"
+	InvoicePaid      InvoiceStatus = "paid"
+	InvoiceExpired   InvoiceStatus = "expired"
+	InvoiceCancelled InvoiceStatus = "cancelled"
+)
+
+func (invoiceStatus *InvoiceStatus) Scan(value interface{}) error {
+	*invoiceStatus = InvoiceStatus(value.(string))
+	return nil
+}
+
+func (invoiceStatus InvoiceStatus) Value() (driver.Value, error) {
+	return string(invoiceStatus), nil
+}
+
+func (InvoiceStatus) GormDataType() string {
+	return "invoice_status"
+}
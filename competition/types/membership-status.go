@@ -0,0 +1,25 @@
+package types
+
+import (
+	"database/sql/driver"
+)
+
+type MembershipStatus string
+
+const (
+	PendingMembership MembershipStatus = "pending"
+	ActiveMembership  MembershipStatus = "active"
+)
+
+func (membershipStatus *MembershipStatus) Scan(value interface{}) error {
+	*membershipStatus = MembershipStatus(value.(string))
+	return nil
+}
+
+func (membershipStatus MembershipStatus) Value() (driver.Value, error) {
+	return string(membershipStatus), nil
+}
+
+func (MembershipStatus) GormDataType() string {
+	return "membership_status"
+}
@@ -25,3 +25,8 @@ func (photoType PhotoType) Value() (driver.Value, error) {
 func (PhotoType) GormDataType() string {
 	return "photo_type"
 }
+
+// Dokumen identitas dan bukti pembayaran berisi data pribadi sehingga harus dienkripsi pada storage
+func (photoType PhotoType) IsConfidential() bool {
+	return photoType == KartuPelajar || photoType == BuktiMahasiswaAktif || photoType == BuktiPembayaran
+}
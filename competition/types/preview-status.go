@@ -0,0 +1,27 @@
+package types
+
+import (
+	"database/sql/driver"
+)
+
+type PreviewStatus string
+
+const (
+	PreviewPending     PreviewStatus = "pending"
+	PreviewGenerated   PreviewStatus = "generated"
+	PreviewFailed      PreviewStatus = "failed"
+	PreviewUnsupported PreviewStatus = "unsupported"
+)
+
+func (previewStatus *PreviewStatus) Scan(value interface{}) error {
+	*previewStatus = PreviewStatus(value.(string))
+	return nil
+}
+
+func (previewStatus PreviewStatus) Value() (driver.Value, error) {
+	return string(previewStatus), nil
+}
+
+func (PreviewStatus) GormDataType() string {
+	return "preview_status"
+}
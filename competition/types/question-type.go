@@ -0,0 +1,25 @@
+package types
+
+import (
+	"database/sql/driver"
+)
+
+type QuestionType string
+
+const (
+	MultipleChoice QuestionType = "multiple-choice"
+	ShortAnswer    QuestionType = "short-answer"
+)
+
+func (questionType *QuestionType) Scan(value interface{}) error {
+	*questionType = QuestionType(value.(string))
+	return nil
+}
+
+func (questionType QuestionType) Value() (driver.Value, error) {
+	return string(questionType), nil
+}
+
+func (QuestionType) GormDataType() string {
+	return "question_type"
+}
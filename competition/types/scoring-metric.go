@@ -0,0 +1,31 @@
+package types
+
+import (
+	"database/sql/driver"
+)
+
+type ScoringMetric string
+
+const (
+	RMSE     ScoringMetric = "rmse"
+	MAE      ScoringMetric = "mae"
+	Accuracy ScoringMetric = "accuracy"
+	MacroF1  ScoringMetric = "macro-f1"
+)
+
+func (scoringMetric *ScoringMetric) Scan(value interface{}) error {
+	*scoringMetric = ScoringMetric(value.(string))
+	return nil
+}
+
+func (scoringMetric ScoringMetric) Value() (driver.Value, error) {
+	return string(scoringMetric), nil
+}
+
+func (ScoringMetric) GormDataType() string {
+	return "scoring_metric"
+}
+
+func (scoringMetric ScoringMetric) IsHigherBetter() bool {
+	return scoringMetric == Accuracy || scoringMetric == MacroF1
+}
@@ -0,0 +1,28 @@
+package types
+
+import (
+	"database/sql/driver"
+)
+
+type SimilarityKind string
+
+const (
+	SourceSimilarity   SimilarityKind = "source"
+	TextSimilarity     SimilarityKind = "text"
+	NotebookSimilarity SimilarityKind = "notebook"
+	CSVSimilarity      SimilarityKind = "csv"
+	BinarySimilarity   SimilarityKind = "binary"
+)
+
+func (similarityKind *SimilarityKind) Scan(value interface{}) error {
+	*similarityKind = SimilarityKind(value.(string))
+	return nil
+}
+
+func (similarityKind SimilarityKind) Value() (driver.Value, error) {
+	return string(similarityKind), nil
+}
+
+func (SimilarityKind) GormDataType() string {
+	return "similarity_kind"
+}
@@ -2,6 +2,7 @@ package types
 
 import (
 	"database/sql/driver"
+	"regexp"
 )
 
 type TeamCategory string
@@ -25,3 +26,102 @@ func (teamCategory TeamCategory) Value() (driver.Value, error) {
 func (TeamCategory) GormDataType() string {
 	return "team_category"
 }
+
+type TeamCategories []TeamCategory
+
+func (teamCategories *TeamCategories) Scan(values interface{}) error {
+	// Kolom bernilai NULL berarti berlaku untuk seluruh jenis lomba
+	if values == nil {
+		*teamCategories = nil
+		return nil
+	}
+
+	regex, err := regexp.Compile(`[a-zA-Z\-]+`)
+	if err != nil {
+		return nil
+	}
+
+	words := regex.FindAllString(values.(string), -1)
+	*teamCategories = []TeamCategory{}
+	for _, word := range words {
+		*teamCategories = append(*teamCategories, TeamCategory(word))
+	}
+	return nil
+}
+
+func (teamCategories TeamCategories) Value() (driver.Value, error) {
+	var values []string
+	for _, teamCategory := range teamCategories {
+		values = append(values, string(teamCategory))
+	}
+	return values, nil
+}
+
+func (TeamCategories) GormDataType() string {
+	return "team_category[]"
+}
+
+func (teamCategories TeamCategories) Contains(teamCategory TeamCategory) bool {
+	for _, category := range teamCategories {
+		if category == teamCategory {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Urutan stage yang harus dilalui team pada setiap jenis lomba
+var stagePipelines = map[TeamCategory][]SubmissionStage{
+	CP:         {FirstStage, FinalStage},
+	Datavidia:  {FirstStage, SecondStage, FinalStage},
+	UXVidia:    {FirstStage, SecondStage, FinalStage},
+	Arkalogica: {FirstStage, FinalStage},
+}
+
+func (teamCategory TeamCategory) GetStagePipeline() []SubmissionStage {
+	return stagePipelines[teamCategory]
+}
+
+func (teamCategory TeamCategory) GetNextStage(stage SubmissionStage) (SubmissionStage, bool) {
+	pipeline := teamCategory.GetStagePipeline()
+	for i, currentStage := range pipeline {
+		if currentStage == stage && i+1 < len(pipeline) {
+			return pipeline[i+1], true
+		}
+	}
+
+	return "", false
+}
+
+// Aturan komposisi team pada setiap jenis lomba
+type CompositionRule struct {
+	MinMembers    int
+	MaxMembers    int
+	MaxCategories int
+}
+
+var compositionRules = map[TeamCategory]CompositionRule{
+	CP:         {MinMembers: 1, MaxMembers: 3, MaxCategories: 2},
+	Datavidia:  {MinMembers: 2, MaxMembers: 3, MaxCategories: 2},
+	UXVidia:    {MinMembers: 2, MaxMembers: 3, MaxCategories: 2},
+	Arkalogica: {MinMembers: 1, MaxMembers: 3, MaxCategories: 2},
+}
+
+// Jenjang pendidikan yang dapat mengikuti setiap jenis lomba
+var eligibleEducationLevels = map[TeamCategory]EducationLevel{
+	CP:         University,
+	Datavidia:  University,
+	UXVidia:    University,
+	Arkalogica: HighSchool,
+}
+
+func (teamCategory TeamCategory) GetCompositionRule() (CompositionRule, bool) {
+	rule, exists := compositionRules[teamCategory]
+	return rule, exists
+}
+
+func (teamCategory TeamCategory) GetEligibleEducationLevel() (EducationLevel, bool) {
+	educationLevel, exists := eligibleEducationLevels[teamCategory]
+	return educationLevel, exists
+}
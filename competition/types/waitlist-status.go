@@ -0,0 +1,26 @@
+package types
+
+import (
+	"database/sql/driver"
+)
+
+type WaitlistStatus string
+
+const (
+	WaitlistWaiting  WaitlistStatus = "waiting"
+	WaitlistPromoted WaitlistStatus = "promoted"
+	WaitlistLeft     WaitlistStatus = "left"
+)
+
+func (waitlistStatus *WaitlistStatus) Scan(value interface{}) error {
+	*waitlistStatus = WaitlistStatus(value.(string))
+	return nil
+}
+
+func (waitlistStatus WaitlistStatus) Value() (driver.Value, error) {
+	return string(waitlistStatus), nil
+}
+
+func (WaitlistStatus) GormDataType() string {
+	return "waitlist_status"
+}
@@ -0,0 +1,27 @@
+package types
+
+import (
+	"database/sql/driver"
+)
+
+type WithdrawalRecordKind string
+
+const (
+	EnrolmentRecord  WithdrawalRecordKind = "enrolment"
+	MembershipRecord WithdrawalRecordKind = "membership"
+	InvitationRecord WithdrawalRecordKind = "invitation"
+	InvoiceRecord    WithdrawalRecordKind = "invoice"
+)
+
+func (withdrawalRecordKind *WithdrawalRecordKind) Scan(value interface{}) error {
+	*withdrawalRecordKind = WithdrawalRecordKind(value.(string))
+	return nil
+}
+
+func (withdrawalRecordKind WithdrawalRecordKind) Value() (driver.Value, error) {
+	return string(withdrawalRecordKind), nil
+}
+
+func (WithdrawalRecordKind) GormDataType() string {
+	return "withdrawal_record_kind"
+}
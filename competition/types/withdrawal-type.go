@@ -0,0 +1,25 @@
+package types
+
+import (
+	"database/sql/driver"
+)
+
+type WithdrawalType string
+
+const (
+	Withdrawal WithdrawalType = "withdrawal"
+	Disband    WithdrawalType = "disband"
+)
+
+func (withdrawalType *WithdrawalType) Scan(value interface{}) error {
+	*withdrawalType = WithdrawalType(value.(string))
+	return nil
+}
+
+func (withdrawalType WithdrawalType) Value() (driver.Value, error) {
+	return string(withdrawalType), nil
+}
+
+func (WithdrawalType) GormDataType() string {
+	return "withdrawal_type"
+}
@@ -0,0 +1,124 @@
+package certificate
+
+import (
+	"fmt"
+	"log"
+	"sync"
+
+	"gorm.io/gorm"
+
+	messageConfig "arkavidia-backend-8.0/competition/config/message"
+	"arkavidia-backend-8.0/competition/models"
+	databaseService "arkavidia-backend-8.0/competition/services/database"
+	"arkavidia-backend-8.0/competition/types"
+	"arkavidia-backend-8.0/competition/utils/mail"
+)
+
+type CertificateParameters struct {
+	BatchID uint
+}
+
+type CertificateBroker struct {
+	channel chan CertificateParameters
+	wg      sync.WaitGroup
+	once    sync.Once
+}
+
+// Private
+func (certificateBroker *CertificateBroker) lazyInit() {
+	certificateBroker.once.Do(func() {
+		config := messageConfig.Config.GetMetadata()
+
+		// Asynchronous Channel
+		certificateBroker.channel = make(chan CertificateParameters, config.BufferSize)
+	})
+}
+
+// Sertifikat diterbitkan dalam satu transaksi, kemudian PDF dibuat dan dikirim melalui mail broker
+// Sertifikat dari batch sebelumnya yang belum terkirim ikut dikirim ulang, sent_at diisi setelah email berhasil dikirim
+func (certificateBroker *CertificateBroker) generate(certificateParameters CertificateParameters) error {
+	certificateBroker.lazyInit()
+
+	db := databaseService.DB.GetConnection()
+
+	conditionBatch := models.CertificateBatch{Model: gorm.Model{ID: certificateParameters.BatchID}}
+	batch := models.CertificateBatch{}
+	if err := db.Where(&conditionBatch).First(&batch).Error; err != nil {
+		return err
+	}
+
+	certificates := []models.Certificate{}
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		issuedCertificates, err := models.IssueCertificates(tx, batch)
+		if err != nil {
+			return err
+		}
+
+		certificates, err = models.GetUnsentCertificates(tx, batch.TeamCategory)
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&models.CertificateBatch{}).Where(&conditionBatch).Updates(map[string]interface{}{"status": types.BatchCompleted, "issued_count": len(issuedCertificates)}).Error
+	}); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, certificate := range certificates {
+		content, err := GeneratePDF(certificate)
+		if err != nil {
+			log.Printf("ERROR: CERTIFICATE %d CANNOT BE GENERATED: %s", certificate.ID, err.Error())
+			failed++
+			continue
+		}
+
+		// Asynchronously mail the certificate to the participant
+		conditionCertificate := models.Certificate{Model: gorm.Model{ID: certificate.ID}}
+		mail.Broker.AddMailToBroker(mail.MailParameters{Email: certificate.Participant.Email, Subject: "Arkavidia Certificate", Template: "certificate", Data: GetMailData(certificate), Attachments: []mail.MailAttachment{{Filename: GetFilename(certificate), Content: content}}, OnDelivered: func() {
+			if err := db.Model(&models.Certificate{}).Where(&conditionCertificate).Update("sent_at", db.NowFunc()).Error; err != nil {
+				log.Printf("ERROR: CERTIFICATE %d SENT BUT NOT RECORDED: %s", conditionCertificate.ID, err.Error())
+			}
+		}})
+	}
+	if failed > 0 {
+		return fmt.Errorf("ERROR: %d CERTIFICATES CANNOT BE GENERATED", failed)
+	}
+
+	return nil
+}
+
+func (certificateBroker *CertificateBroker) certificateRun() {
+	defer certificateBroker.wg.Done()
+
+	certificateBroker.lazyInit()
+	for certificateParameters := range certificateBroker.channel {
+		if err := certificateBroker.generate(certificateParameters); err != nil {
+			db := databaseService.DB.GetConnection()
+			condition := models.CertificateBatch{Model: gorm.Model{ID: certificateParameters.BatchID}}
+			db.Model(&models.CertificateBatch{}).Where(&condition).Update("status", types.BatchFailed)
+		}
+	}
+}
+
+// Public
+func (certificateBroker *CertificateBroker) AddBatchToBroker(certificateParameters CertificateParameters) {
+	certificateBroker.lazyInit()
+	certificateBroker.channel <- certificateParameters
+}
+
+func (certificateBroker *CertificateBroker) RunCertificateWorker(numOfWorkers int) {
+	certificateBroker.lazyInit()
+	certificateBroker.wg.Add(numOfWorkers)
+	for i := 0; i < numOfWorkers; i++ {
+		go certificateBroker.certificateRun()
+	}
+	certificateBroker.wg.Wait()
+}
+
+func (certificateBroker *CertificateBroker) CloseWorker() {
+	certificateBroker.lazyInit()
+	close(certificateBroker.channel)
+}
+
+var Broker = &CertificateBroker{}
@@ -0,0 +1,107 @@
+package certificate
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/skip2/go-qrcode"
+
+	certificateConfig "arkavidia-backend-8.0/competition/config/certificate"
+	"arkavidia-backend-8.0/competition/models"
+	"arkavidia-backend-8.0/competition/types"
+)
+
+// Template teks sertifikat untuk setiap jenis sertifikat
+type Template struct {
+	Title       string
+	Description string
+}
+
+var templates = map[types.CertificateKind]Template{
+	types.ParticipationCertificate: {Title: "Certificate of Participation", Description: "for participating in %s as a member of team %s"},
+	types.FinalistCertificate:      {Title: "Certificate of Achievement", Description: "as a finalist of %s as a member of team %s"},
+	types.WinnerCertificate:        {Title: "Certificate of Excellence", Description: "as a winner of %s as a member of team %s"},
+}
+
+var categoryNames = map[types.TeamCategory]string{
+	types.CP:         "Competitive Programming",
+	types.Datavidia:  "Datavidia",
+	types.UXVidia:    "UXVidia",
+	types.Arkalogica: "Arkalogica",
+}
+
+func GetVerifyURL(certificate models.Certificate) string {
+	config := certificateConfig.Config.GetMetadata()
+	return fmt.Sprintf("%s?serial=%s", config.VerifyURL, certificate.Serial)
+}
+
+func GetFilename(certificate models.Certificate) string {
+	return fmt.Sprintf("certificate-%s.pdf", certificate.Serial)
+}
+
+func GetMailData(certificate models.Certificate) map[string]interface{} {
+	return map[string]interface{}{
+		"ParticipantName": certificate.ParticipantName,
+		"TeamName":        certificate.TeamName,
+		"TeamCategory":    categoryNames[certificate.TeamCategory],
+		"Title":           templates[certificate.Kind].Title,
+		"Serial":          certificate.Serial,
+		"VerifyURL":       GetVerifyURL(certificate),
+	}
+}
+
+// QR code pada sertifikat mengarah ke endpoint verifikasi publik
+func GeneratePDF(certificate models.Certificate) ([]byte, error) {
+	config := certificateConfig.Config.GetMetadata()
+	template := templates[certificate.Kind]
+	competition := strings.TrimSpace(fmt.Sprintf("%s %s", categoryNames[certificate.TeamCategory], config.EventName))
+
+	qrCode, err := qrcode.Encode(GetVerifyURL(certificate), qrcode.Medium, 256)
+	if err != nil {
+		return nil, err
+	}
+
+	pdf := gofpdf.New("L", "mm", "A4", "")
+	pdf.SetTitle(fmt.Sprintf("%s %s", template.Title, certificate.Serial), true)
+	pdf.SetMargins(20, 25, 20)
+	pdf.AddPage()
+
+	pdf.SetLineWidth(1)
+	pdf.Rect(10, 10, 277, 190, "D")
+
+	pdf.SetFont("Helvetica", "B", 28)
+	pdf.CellFormat(0, 16, template.Title, "", 1, "C", false, 0, "")
+	pdf.Ln(8)
+
+	pdf.SetFont("Helvetica", "", 14)
+	pdf.CellFormat(0, 8, "This certificate is presented to", "", 1, "C", false, 0, "")
+	pdf.Ln(4)
+
+	pdf.SetFont("Helvetica", "B", 24)
+	pdf.CellFormat(0, 14, certificate.ParticipantName, "", 1, "C", false, 0, "")
+	pdf.Ln(4)
+
+	pdf.SetFont("Helvetica", "", 14)
+	pdf.MultiCell(0, 8, fmt.Sprintf(template.Description, competition, certificate.TeamName), "", "C", false)
+	pdf.CellFormat(0, 8, fmt.Sprintf("Issued on %s", certificate.IssuedAt.Format("2 January 2006")), "", 1, "C", false, 0, "")
+
+	imageOptions := gofpdf.ImageOptions{ImageType: "PNG"}
+	pdf.RegisterImageOptionsReader("qr-code", imageOptions, bytes.NewReader(qrCode))
+	pdf.ImageOptions("qr-code", 20, 150, 40, 40, false, imageOptions, 0, "")
+
+	pdf.SetXY(65, 170)
+	pdf.SetFont("Courier", "", 9)
+	pdf.CellFormat(0, 5, fmt.Sprintf("Serial: %s", certificate.Serial), "", 1, "L", false, 0, "")
+	pdf.SetX(65)
+	pdf.SetFont("Helvetica", "", 9)
+	pdf.CellFormat(0, 5, fmt.Sprintf("Verify this certificate at %s", GetVerifyURL(certificate)), "", 1, "L", false, 0, "")
+
+	buffer := bytes.Buffer{}
+	if err := pdf.Output(&buffer); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
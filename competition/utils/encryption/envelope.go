@@ -0,0 +1,141 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"sync"
+
+	encryptionConfig "arkavidia-backend-8.0/competition/config/encryption"
+)
+
+// Setiap object menggunakan data key AES-256 tersendiri untuk mengenkripsi isi file,
+// kemudian data key tersebut dienkripsi (wrap) dengan master key dari konfigurasi
+const dataKeySize = 32
+
+type EnvelopeEncryption struct {
+	masterKey         cipher.AEAD
+	previousMasterKey cipher.AEAD
+	once              sync.Once
+}
+
+// Private
+func (envelopeEncryption *EnvelopeEncryption) lazyInit() {
+	envelopeEncryption.once.Do(func() {
+		config := encryptionConfig.Config.GetMetadata()
+
+		masterKey, err := newAEAD(config.MasterKey)
+		if err != nil {
+			panic(err)
+		}
+		envelopeEncryption.masterKey = masterKey
+
+		if len(config.PreviousMasterKey) > 0 {
+			previousMasterKey, err := newAEAD(config.PreviousMasterKey)
+			if err != nil {
+				panic(err)
+			}
+			envelopeEncryption.previousMasterKey = previousMasterKey
+		}
+	})
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// Hasil enkripsi disimpan dengan format nonce || ciphertext
+func seal(aead cipher.AEAD, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func open(aead cipher.AEAD, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, fmt.Errorf("ERROR: CIPHERTEXT TOO SHORT")
+	}
+
+	nonce, sealed := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	return aead.Open(nil, nonce, sealed, nil)
+}
+
+func (envelopeEncryption *EnvelopeEncryption) unwrapKey(wrappedKey []byte) ([]byte, error) {
+	dataKey, err := open(envelopeEncryption.masterKey, wrappedKey)
+	if err == nil {
+		return dataKey, nil
+	}
+
+	// Data key yang belum di-rotate masih dapat dibuka dengan master key sebelumnya
+	if envelopeEncryption.previousMasterKey != nil {
+		return open(envelopeEncryption.previousMasterKey, wrappedKey)
+	}
+
+	return nil, err
+}
+
+// Public
+func (envelopeEncryption *EnvelopeEncryption) Encrypt(plaintext []byte) ([]byte, []byte, error) {
+	envelopeEncryption.lazyInit()
+
+	dataKey := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return nil, nil, err
+	}
+
+	dataAEAD, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ciphertext, err := seal(dataAEAD, plaintext)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	wrappedKey, err := seal(envelopeEncryption.masterKey, dataKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ciphertext, wrappedKey, nil
+}
+
+func (envelopeEncryption *EnvelopeEncryption) Decrypt(ciphertext []byte, wrappedKey []byte) ([]byte, error) {
+	envelopeEncryption.lazyInit()
+
+	dataKey, err := envelopeEncryption.unwrapKey(wrappedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	dataAEAD, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return open(dataAEAD, ciphertext)
+}
+
+func (envelopeEncryption *EnvelopeEncryption) RewrapKey(wrappedKey []byte) ([]byte, error) {
+	envelopeEncryption.lazyInit()
+
+	dataKey, err := envelopeEncryption.unwrapKey(wrappedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return seal(envelopeEncryption.masterKey, dataKey)
+}
+
+var Envelope = &EnvelopeEncryption{}
@@ -0,0 +1,132 @@
+package encryption
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"testing"
+)
+
+var (
+	testMasterKey         = bytes.Repeat([]byte{1}, 32)
+	testPreviousMasterKey = bytes.Repeat([]byte{2}, 32)
+	testUnknownMasterKey  = bytes.Repeat([]byte{3}, 32)
+)
+
+func TestMain(m *testing.M) {
+	os.Setenv("ENCRYPTION_MASTER_KEY", base64.StdEncoding.EncodeToString(testMasterKey))
+	os.Setenv("ENCRYPTION_PREVIOUS_MASTER_KEY", base64.StdEncoding.EncodeToString(testPreviousMasterKey))
+
+	os.Exit(m.Run())
+}
+
+// Membungkus data key baru dengan master key tertentu untuk mensimulasikan object lama
+func wrapWith(t *testing.T, masterKey []byte, plaintext []byte) ([]byte, []byte) {
+	t.Helper()
+
+	dataKey := bytes.Repeat([]byte{4}, dataKeySize)
+	dataAEAD, err := newAEAD(dataKey)
+	if err != nil {
+		t.Fatalf("newAEAD() error = %v", err)
+	}
+	ciphertext, err := seal(dataAEAD, plaintext)
+	if err != nil {
+		t.Fatalf("seal() error = %v", err)
+	}
+
+	masterAEAD, err := newAEAD(masterKey)
+	if err != nil {
+		t.Fatalf("newAEAD() error = %v", err)
+	}
+	wrappedKey, err := seal(masterAEAD, dataKey)
+	if err != nil {
+		t.Fatalf("seal() error = %v", err)
+	}
+
+	return ciphertext, wrappedKey
+}
+
+func TestEnvelope(t *testing.T) {
+	plaintext := []byte("foto kartu pelajar peserta")
+
+	ciphertext, wrappedKey, err := Envelope.Encrypt(plaintext)
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	if bytes.Contains(ciphertext, plaintext) {
+		t.Fatalf("Encrypt() ciphertext contains plaintext")
+	}
+
+	tamperedCiphertext := append([]byte{}, ciphertext...)
+	tamperedCiphertext[len(tamperedCiphertext)-1] ^= 1
+	tamperedWrappedKey := append([]byte{}, wrappedKey...)
+	tamperedWrappedKey[len(tamperedWrappedKey)-1] ^= 1
+	previousCiphertext, previousWrappedKey := wrapWith(t, testPreviousMasterKey, plaintext)
+	unknownCiphertext, unknownWrappedKey := wrapWith(t, testUnknownMasterKey, plaintext)
+
+	testCases := []struct {
+		name       string
+		ciphertext []byte
+		wrappedKey []byte
+		wantErr    bool
+	}{
+		{name: "round trip", ciphertext: ciphertext, wrappedKey: wrappedKey, wantErr: false},
+		{name: "previous master key", ciphertext: previousCiphertext, wrappedKey: previousWrappedKey, wantErr: false},
+		{name: "tampered ciphertext", ciphertext: tamperedCiphertext, wrappedKey: wrappedKey, wantErr: true},
+		{name: "tampered wrapped key", ciphertext: ciphertext, wrappedKey: tamperedWrappedKey, wantErr: true},
+		{name: "unknown master key", ciphertext: unknownCiphertext, wrappedKey: unknownWrappedKey, wantErr: true},
+		{name: "short wrapped key", ciphertext: ciphertext, wrappedKey: []byte{1, 2, 3}, wantErr: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual, err := Envelope.Decrypt(testCase.ciphertext, testCase.wrappedKey)
+			if (err != nil) != testCase.wantErr {
+				t.Fatalf("Decrypt() error = %v, wantErr %v", err, testCase.wantErr)
+			}
+			if err == nil && !bytes.Equal(actual, plaintext) {
+				t.Errorf("Decrypt() = %s, expected %s", actual, plaintext)
+			}
+		})
+	}
+}
+
+func TestRewrapKey(t *testing.T) {
+	plaintext := []byte("berkas submission")
+	previousCiphertext, previousWrappedKey := wrapWith(t, testPreviousMasterKey, plaintext)
+	_, unknownWrappedKey := wrapWith(t, testUnknownMasterKey, plaintext)
+
+	masterAEAD, err := newAEAD(testMasterKey)
+	if err != nil {
+		t.Fatalf("newAEAD() error = %v", err)
+	}
+
+	testCases := []struct {
+		name       string
+		wrappedKey []byte
+		wantErr    bool
+	}{
+		{name: "previous master key", wrappedKey: previousWrappedKey, wantErr: false},
+		{name: "unknown master key", wrappedKey: unknownWrappedKey, wantErr: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			rewrappedKey, err := Envelope.RewrapKey(testCase.wrappedKey)
+			if (err != nil) != testCase.wantErr {
+				t.Fatalf("RewrapKey() error = %v, wantErr %v", err, testCase.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			// Data key hasil rewrap harus dapat dibuka dengan master key saat ini tanpa master key sebelumnya
+			if _, err := open(masterAEAD, rewrappedKey); err != nil {
+				t.Errorf("RewrapKey() result cannot be opened with master key: %v", err)
+			}
+			if actual, err := Envelope.Decrypt(previousCiphertext, rewrappedKey); err != nil || !bytes.Equal(actual, plaintext) {
+				t.Errorf("Decrypt() after RewrapKey() = %s, %v, expected %s", actual, err, plaintext)
+			}
+		})
+	}
+}
@@ -1,9 +1,14 @@
 package mail
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"html/template"
+	"io"
+	"log"
 	"sync"
+	"time"
 
 	"gopkg.in/gomail.v2"
 
@@ -11,8 +16,19 @@ import (
 	messageConfig "arkavidia-backend-8.0/competition/config/message"
 )
 
+type MailAttachment struct {
+	Filename string
+	Content  []byte
+}
+
 type MailParameters struct {
-	Email string
+	Email       string
+	Subject     string
+	Template    string
+	Data        interface{}
+	Attachments []MailAttachment
+	// Dipanggil setelah email berhasil dikirim ke SMTP server
+	OnDelivered func()
 }
 
 type MailBroker struct {
@@ -46,19 +62,35 @@ func (mailBroker *MailBroker) sendMailToClient(ctx context.Context, mailParamete
 		// TODO: Gunakan templating HTML static file sebagai body email
 		// REFERENCE: https://dasarpemrogramangolang.novalagung.com/B-template-render-html.html
 		// ASSIGNED TO: @samuelswandi
-		// STATUS: IN PROGRESS
+		// STATUS: DONE
 
 		config := mailConfig.Config.GetMetadata()
 
-		const subjectHeader = "Test Mail"
-		const emailBody = "Hello, <b>have a nice day</b>"
+		emailTemplate, err := template.ParseFiles(fmt.Sprintf("static/html/%s.html", mailParameters.Template))
+		if err != nil {
+			errorBroker <- err
+			return
+		}
+
+		emailBody := bytes.Buffer{}
+		if err := emailTemplate.Execute(&emailBody, mailParameters.Data); err != nil {
+			errorBroker <- err
+			return
+		}
 
 		mailer := gomail.NewMessage()
 		mailer.SetHeader("From", fmt.Sprintf("%s <%s>", config.SenderName, config.AuthEmail))
 		mailer.SetHeader("To", mailParameters.Email)
-		mailer.SetHeader("Subject", subjectHeader)
+		mailer.SetHeader("Subject", mailParameters.Subject)
 		mailer.SetAddressHeader("Cc", config.AuthEmail, config.SenderName)
-		mailer.SetBody("text/html", emailBody)
+		mailer.SetBody("text/html", emailBody.String())
+		for _, attachment := range mailParameters.Attachments {
+			content := attachment.Content
+			mailer.Attach(attachment.Filename, gomail.SetCopyFunc(func(w io.Writer) error {
+				_, err := w.Write(content)
+				return err
+			}))
+		}
 
 		dialer := gomail.NewDialer(
 			config.SMTPHost,
@@ -78,26 +110,38 @@ func (mailBroker *MailBroker) sendMailToClient(ctx context.Context, mailParamete
 	}
 }
 
-func (mailBroker *MailBroker) recoverMailToBroker(mailParameters MailParameters) {
+func (mailBroker *MailBroker) tryMailToClient(mailParameters MailParameters) error {
 	mailBroker.lazyInit()
 
-	if r := recover(); r != nil {
-		mailBroker.AddMailToBroker(mailParameters)
-	}
+	config := messageConfig.Config.GetMetadata()
+	ctx, cancel := context.WithTimeout(context.Background(), config.Timeout)
+	defer cancel()
+
+	return mailBroker.sendMailToClient(ctx, mailParameters)
 }
 
+// Pengiriman yang gagal dicoba ulang dengan backoff eksponensial hingga MaxRetry kali, setelah itu email dibuang dan dicatat di log
 func (mailBroker *MailBroker) waitMailToClient(mailParameters MailParameters) {
 	mailBroker.lazyInit()
 
 	config := messageConfig.Config.GetMetadata()
-	ctx, cancel := context.WithTimeout(context.Background(), config.Timeout)
-	defer cancel()
-
-	err := mailBroker.sendMailToClient(ctx, mailParameters)
-
-	defer mailBroker.recoverMailToBroker(mailParameters)
-	if err != nil {
-		panic(err)
+	backoff := config.RetryBackoff
+	for attempt := 1; ; attempt++ {
+		err := mailBroker.tryMailToClient(mailParameters)
+		if err == nil {
+			if mailParameters.OnDelivered != nil {
+				mailParameters.OnDelivered()
+			}
+			return
+		}
+		if attempt >= config.MaxRetry {
+			log.Printf("ERROR: MAIL %q TO %s DROPPED AFTER %d ATTEMPTS: %s", mailParameters.Subject, mailParameters.Email, attempt, err.Error())
+			return
+		}
+
+		log.Printf("WARNING: MAIL %q TO %s FAILED ON ATTEMPT %d: %s", mailParameters.Subject, mailParameters.Email, attempt, err.Error())
+		time.Sleep(backoff)
+		backoff *= 2
 	}
 }
 
@@ -0,0 +1,150 @@
+package payment
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	paymentConfig "arkavidia-backend-8.0/competition/config/payment"
+)
+
+// Integrasi mengikuti pola hosted payment (mis. Xendit Invoice / Midtrans Snap): backend membuat transaksi,
+// participant membayar melalui halaman milik provider, lalu provider mengirimkan webhook bertanda tangan
+const (
+	StatusPending = "PENDING"
+	StatusPaid    = "PAID"
+	StatusExpired = "EXPIRED"
+
+	SignatureHeader = "X-Callback-Signature"
+)
+
+type TransactionRequest struct {
+	ExternalID      string `json:"external_id"`
+	Amount          uint   `json:"amount"`
+	Description     string `json:"description"`
+	PayerEmail      string `json:"payer_email,omitempty"`
+	CallbackURL     string `json:"callback_url,omitempty"`
+	InvoiceDuration int64  `json:"invoice_duration"`
+}
+
+type TransactionResponse struct {
+	ID         string    `json:"id"`
+	ExternalID string    `json:"external_id"`
+	Amount     uint      `json:"amount"`
+	Status     string    `json:"status"`
+	InvoiceURL string    `json:"invoice_url"`
+	ExpiryDate time.Time `json:"expiry_date"`
+}
+
+type WebhookPayload struct {
+	ID         string     `json:"id"`
+	ExternalID string     `json:"external_id"`
+	Amount     uint       `json:"amount"`
+	Status     string     `json:"status"`
+	PaidAt     *time.Time `json:"paid_at"`
+}
+
+// Membuat transaksi pada provider menggunakan server key sebagai basic auth username
+func CreateTransaction(transactionRequest TransactionRequest) (TransactionResponse, error) {
+	config := paymentConfig.Config.GetMetadata()
+	if config.ProviderURL == "" {
+		return TransactionResponse{}, fmt.Errorf("ERROR: PAYMENT PROVIDER URL NOT CONFIGURED")
+	}
+
+	if transactionRequest.CallbackURL == "" {
+		transactionRequest.CallbackURL = config.CallbackURL
+	}
+	if transactionRequest.InvoiceDuration == 0 {
+		transactionRequest.InvoiceDuration = int64(config.InvoiceDuration / time.Second)
+	}
+
+	body, err := json.Marshal(transactionRequest)
+	if err != nil {
+		return TransactionResponse{}, err
+	}
+
+	request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/v2/invoices", strings.TrimSuffix(config.ProviderURL, "/")), bytes.NewReader(body))
+	if err != nil {
+		return TransactionResponse{}, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.SetBasicAuth(config.ServerKey, "")
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	response, err := client.Do(request)
+	if err != nil {
+		return TransactionResponse{}, err
+	}
+	defer response.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return TransactionResponse{}, err
+	}
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusCreated {
+		return TransactionResponse{}, fmt.Errorf("ERROR: PAYMENT PROVIDER RETURNED STATUS %d", response.StatusCode)
+	}
+
+	transactionResponse := TransactionResponse{}
+	if err := json.Unmarshal(responseBody, &transactionResponse); err != nil {
+		return TransactionResponse{}, err
+	}
+	if transactionResponse.ID == "" || transactionResponse.InvoiceURL == "" {
+		return TransactionResponse{}, fmt.Errorf("ERROR: INVALID PAYMENT PROVIDER RESPONSE")
+	}
+
+	return transactionResponse, nil
+}
+
+// Membatalkan transaksi yang belum dibayar pada provider, transaksi yang telah lunas dikembalikan sebagai error
+func ExpireTransaction(providerID string) error {
+	config := paymentConfig.Config.GetMetadata()
+	if config.ProviderURL == "" {
+		return fmt.Errorf("ERROR: PAYMENT PROVIDER URL NOT CONFIGURED")
+	}
+
+	request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/v2/invoices/%s/expire", strings.TrimSuffix(config.ProviderURL, "/"), providerID), nil)
+	if err != nil {
+		return err
+	}
+	request.SetBasicAuth(config.ServerKey, "")
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusConflict:
+		return fmt.Errorf("ERROR: PAYMENT TRANSACTION ALREADY PAID")
+	default:
+		return fmt.Errorf("ERROR: PAYMENT PROVIDER RETURNED STATUS %d", response.StatusCode)
+	}
+}
+
+// Tanda tangan webhook berupa hex(HMAC-SHA256(webhook secret, raw body))
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func VerifySignature(body []byte, signature string) bool {
+	config := paymentConfig.Config.GetMetadata()
+	if config.WebhookSecret == "" || signature == "" {
+		return false
+	}
+
+	return hmac.Equal([]byte(Sign(config.WebhookSecret, body)), []byte(strings.ToLower(signature)))
+}
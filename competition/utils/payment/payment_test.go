@@ -0,0 +1,138 @@
+package payment
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testServerKey     = "test-server-key"
+	testWebhookSecret = "test-webhook-secret"
+)
+
+// Provider tiruan yang menentukan respons berdasarkan external_id atau id transaksi
+func newTestProvider() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/invoices", func(w http.ResponseWriter, r *http.Request) {
+		if username, _, ok := r.BasicAuth(); !ok || username != testServerKey {
+			http.Error(w, "UNAUTHORIZED", http.StatusUnauthorized)
+			return
+		}
+
+		request := TransactionRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, "BAD REQUEST", http.StatusBadRequest)
+			return
+		}
+
+		switch request.ExternalID {
+		case "provider-error":
+			http.Error(w, "INTERNAL SERVER ERROR", http.StatusInternalServerError)
+		case "missing-url":
+			json.NewEncoder(w).Encode(TransactionResponse{ID: "transaction-id", ExternalID: request.ExternalID})
+		default:
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(TransactionResponse{ID: "transaction-id", ExternalID: request.ExternalID, Amount: request.Amount, Status: StatusPending, InvoiceURL: "http://provider/pay/transaction-id", ExpiryDate: time.Now().Add(time.Duration(request.InvoiceDuration) * time.Second)})
+		}
+	})
+	mux.HandleFunc("/v2/invoices/", func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v2/invoices/"), "/expire") {
+		case "pending-transaction":
+			w.WriteHeader(http.StatusOK)
+		case "paid-transaction":
+			http.Error(w, "TRANSACTION ALREADY PAID", http.StatusConflict)
+		default:
+			http.Error(w, "TRANSACTION NOT FOUND", http.StatusNotFound)
+		}
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func TestMain(m *testing.M) {
+	provider := newTestProvider()
+
+	os.Setenv("PAYMENT_PROVIDER_URL", provider.URL)
+	os.Setenv("PAYMENT_SERVER_KEY", testServerKey)
+	os.Setenv("PAYMENT_WEBHOOK_SECRET", testWebhookSecret)
+	os.Setenv("PAYMENT_CALLBACK_URL", "http://localhost/invoice/webhook")
+	os.Setenv("PAYMENT_INVOICE_DURATION", "3600")
+
+	code := m.Run()
+	provider.Close()
+	os.Exit(code)
+}
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte(`{"external_id":"ARKAVIDIA-1","amount":150000,"status":"PAID"}`)
+
+	testCases := []struct {
+		name      string
+		body      []byte
+		signature string
+		expected  bool
+	}{
+		{name: "valid signature", body: body, signature: Sign(testWebhookSecret, body), expected: true},
+		{name: "uppercase signature", body: body, signature: strings.ToUpper(Sign(testWebhookSecret, body)), expected: true},
+		{name: "tampered body", body: []byte(`{"external_id":"ARKAVIDIA-1","amount":1,"status":"PAID"}`), signature: Sign(testWebhookSecret, body), expected: false},
+		{name: "wrong secret", body: body, signature: Sign("another-secret", body), expected: false},
+		{name: "empty signature", body: body, signature: "", expected: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if actual := VerifySignature(testCase.body, testCase.signature); actual != testCase.expected {
+				t.Errorf("VerifySignature() = %v, expected %v", actual, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestCreateTransaction(t *testing.T) {
+	testCases := []struct {
+		name       string
+		externalID string
+		wantErr    bool
+	}{
+		{name: "created", externalID: "ARKAVIDIA-1", wantErr: false},
+		{name: "provider error", externalID: "provider-error", wantErr: true},
+		{name: "missing payment url", externalID: "missing-url", wantErr: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			transaction, err := CreateTransaction(TransactionRequest{ExternalID: testCase.externalID, Amount: 150000})
+			if (err != nil) != testCase.wantErr {
+				t.Fatalf("CreateTransaction() error = %v, wantErr %v", err, testCase.wantErr)
+			}
+			if err == nil && (transaction.ExternalID != testCase.externalID || transaction.InvoiceURL == "") {
+				t.Errorf("CreateTransaction() = %+v, unexpected response", transaction)
+			}
+		})
+	}
+}
+
+func TestExpireTransaction(t *testing.T) {
+	testCases := []struct {
+		name       string
+		providerID string
+		wantErr    bool
+	}{
+		{name: "pending transaction", providerID: "pending-transaction", wantErr: false},
+		{name: "paid transaction", providerID: "paid-transaction", wantErr: true},
+		{name: "unknown transaction", providerID: "unknown-transaction", wantErr: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if err := ExpireTransaction(testCase.providerID); (err != nil) != testCase.wantErr {
+				t.Errorf("ExpireTransaction() error = %v, wantErr %v", err, testCase.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package preview
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+
+	"gorm.io/gorm"
+
+	messageConfig "arkavidia-backend-8.0/competition/config/message"
+	previewConfig "arkavidia-backend-8.0/competition/config/preview"
+	storageConfig "arkavidia-backend-8.0/competition/config/storage"
+	"arkavidia-backend-8.0/competition/models"
+	databaseService "arkavidia-backend-8.0/competition/services/database"
+	storageService "arkavidia-backend-8.0/competition/services/storage"
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type PreviewParameters struct {
+	SubmissionID uint
+}
+
+type PreviewBroker struct {
+	channel chan PreviewParameters
+	wg      sync.WaitGroup
+	once    sync.Once
+}
+
+// Private
+func (previewBroker *PreviewBroker) lazyInit() {
+	previewBroker.once.Do(func() {
+		config := messageConfig.Config.GetMetadata()
+
+		// Asynchronous Channel
+		previewBroker.channel = make(chan PreviewParameters, config.BufferSize)
+	})
+}
+
+func (previewBroker *PreviewBroker) generatePreview(previewParameters PreviewParameters) error {
+	previewBroker.lazyInit()
+
+	db := databaseService.DB.GetConnection()
+	config := storageConfig.Config.GetMetadata()
+	timeout := previewConfig.Config.GetMetadata().RenderTimeout
+
+	conditionSubmission := models.Submission{Model: gorm.Model{ID: previewParameters.SubmissionID}}
+	submission := models.Submission{}
+	if err := db.Where(&conditionSubmission).First(&submission).Error; err != nil {
+		return err
+	}
+
+	conditionPreview := models.Preview{SubmissionID: submission.ID}
+	preview := models.Preview{}
+	if err := db.Where(&conditionPreview).First(&preview).Error; err != nil {
+		return err
+	}
+
+	filename := fmt.Sprintf("%s%s", submission.FileName, submission.FileExtension)
+	content, err := storageService.Client.DownloadFile(filename, config.SubmissionDir)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	result, err := Generate(ctx, filename, content)
+	if err == ErrUnsupported {
+		return db.Model(&preview).Update("status", types.PreviewUnsupported).Error
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := storageService.Client.UploadFile(fmt.Sprintf("%s%s", preview.FileName, result.FileExtension), config.PreviewDir, bytes.NewReader(result.Content)); err != nil {
+		return err
+	}
+
+	newPreview := models.Preview{FileExtension: result.FileExtension, ContentType: result.ContentType, Status: types.PreviewGenerated}
+	return db.Model(&preview).Updates(&newPreview).Error
+}
+
+func (previewBroker *PreviewBroker) previewRun() {
+	defer previewBroker.wg.Done()
+
+	previewBroker.lazyInit()
+	for previewParameters := range previewBroker.channel {
+		if err := previewBroker.generatePreview(previewParameters); err != nil {
+			db := databaseService.DB.GetConnection()
+			condition := models.Preview{SubmissionID: previewParameters.SubmissionID}
+			db.Model(&models.Preview{}).Where(&condition).Update("status", types.PreviewFailed)
+		}
+	}
+}
+
+// Public
+func (previewBroker *PreviewBroker) AddPreviewToBroker(previewParameters PreviewParameters) {
+	previewBroker.lazyInit()
+	previewBroker.channel <- previewParameters
+}
+
+func (previewBroker *PreviewBroker) RunPreviewWorker(numOfWorkers int) {
+	previewBroker.lazyInit()
+	previewBroker.wg.Add(numOfWorkers)
+	for i := 0; i < numOfWorkers; i++ {
+		go previewBroker.previewRun()
+	}
+	previewBroker.wg.Wait()
+}
+
+func (previewBroker *PreviewBroker) CloseWorker() {
+	previewBroker.lazyInit()
+	close(previewBroker.channel)
+}
+
+var Broker = &PreviewBroker{}
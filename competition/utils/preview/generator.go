@@ -0,0 +1,135 @@
+package preview
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/chroma"
+	"github.com/alecthomas/chroma/formatters/html"
+	"github.com/alecthomas/chroma/lexers"
+	"github.com/alecthomas/chroma/styles"
+	"github.com/gabriel-vasile/mimetype"
+)
+
+var ErrUnsupported = fmt.Errorf("ERROR: PREVIEW NOT SUPPORTED")
+
+type PreviewResult struct {
+	Content       []byte
+	FileExtension string
+	ContentType   string
+}
+
+type archiveEntry struct {
+	Name     string
+	Size     uint64
+	Modified time.Time
+}
+
+var archiveTemplate = template.Must(template.New("archive").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>{{ .Name }}</title></head>
+<body>
+<h1>{{ .Name }}</h1>
+<table>
+<tr><th>Name</th><th>Size (bytes)</th><th>Modified</th></tr>
+{{ range .Entries }}<tr><td>{{ .Name }}</td><td>{{ .Size }}</td><td>{{ .Modified.Format "2006-01-02 15:04:05" }}</td></tr>
+{{ end }}</table>
+</body>
+</html>`))
+
+// Halaman pertama PDF dirender menjadi gambar PNG menggunakan pdftoppm (poppler-utils)
+func generatePDFPreview(ctx context.Context, content []byte) (PreviewResult, error) {
+	dir, err := os.MkdirTemp("", "preview")
+	if err != nil {
+		return PreviewResult{}, err
+	}
+	defer os.RemoveAll(dir)
+
+	input := filepath.Join(dir, "input.pdf")
+	output := filepath.Join(dir, "output")
+	if err := os.WriteFile(input, content, 0600); err != nil {
+		return PreviewResult{}, err
+	}
+
+	command := exec.CommandContext(ctx, "pdftoppm", "-png", "-singlefile", "-f", "1", "-l", "1", "-r", "100", input, output)
+	if err := command.Run(); err != nil {
+		return PreviewResult{}, err
+	}
+
+	image, err := os.ReadFile(fmt.Sprintf("%s.png", output))
+	if err != nil {
+		return PreviewResult{}, err
+	}
+
+	return PreviewResult{Content: image, FileExtension: ".png", ContentType: "image/png"}, nil
+}
+
+func generateArchivePreview(filename string, content []byte) (PreviewResult, error) {
+	reader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return PreviewResult{}, err
+	}
+
+	entries := []archiveEntry{}
+	for _, file := range reader.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		entries = append(entries, archiveEntry{Name: file.Name, Size: file.UncompressedSize64, Modified: file.Modified})
+	}
+
+	html := bytes.Buffer{}
+	if err := archiveTemplate.Execute(&html, map[string]interface{}{"Name": filename, "Entries": entries}); err != nil {
+		return PreviewResult{}, err
+	}
+
+	return PreviewResult{Content: html.Bytes(), FileExtension: ".html", ContentType: "text/html; charset=utf-8"}, nil
+}
+
+func generateSourcePreview(filename string, content []byte) (PreviewResult, error) {
+	lexer := lexers.Match(filename)
+	if lexer == nil {
+		lexer = lexers.Analyse(string(content))
+	}
+	if lexer == nil {
+		lexer = lexers.Fallback
+	}
+	lexer = chroma.Coalesce(lexer)
+
+	iterator, err := lexer.Tokenise(nil, string(content))
+	if err != nil {
+		return PreviewResult{}, err
+	}
+
+	formatter := html.New(html.Standalone(true), html.WithLineNumbers(true))
+	highlighted := bytes.Buffer{}
+	if err := formatter.Format(&highlighted, styles.Get("github"), iterator); err != nil {
+		return PreviewResult{}, err
+	}
+
+	return PreviewResult{Content: highlighted.Bytes(), FileExtension: ".html", ContentType: "text/html; charset=utf-8"}, nil
+}
+
+// Public
+func Generate(ctx context.Context, filename string, content []byte) (PreviewResult, error) {
+	mtype := mimetype.Detect(content)
+
+	switch {
+	case mtype.Is("application/pdf"):
+		return generatePDFPreview(ctx, content)
+	case mtype.Is("application/zip"):
+		return generateArchivePreview(filename, content)
+	case strings.HasPrefix(mtype.String(), "text/") || mtype.Is("application/json") || lexers.Match(filename) != nil:
+		return generateSourcePreview(filename, content)
+	default:
+		return PreviewResult{}, ErrUnsupported
+	}
+}
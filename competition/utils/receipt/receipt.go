@@ -0,0 +1,189 @@
+package receipt
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jung-kurt/gofpdf"
+	"gorm.io/gorm"
+
+	receiptConfig "arkavidia-backend-8.0/competition/config/receipt"
+	"arkavidia-backend-8.0/competition/models"
+	"arkavidia-backend-8.0/competition/types"
+)
+
+// Payload ditandatangani dengan Ed25519, kode receipt berformat base64url(payload).base64url(signature)
+type Payload struct {
+	Serial       uuid.UUID             `json:"serial"`
+	SubmissionID uint                  `json:"submission_id"`
+	TeamID       uint                  `json:"team_id"`
+	TeamName     string                `json:"team_name"`
+	Stage        types.SubmissionStage `json:"stage"`
+	Version      uint                  `json:"version"`
+	Checksum     string                `json:"checksum"`
+	FileSize     int64                 `json:"file_size"`
+	SubmittedAt  time.Time             `json:"submitted_at"`
+	IsLate       bool                  `json:"is_late"`
+}
+
+var ErrInvalidReceipt = fmt.Errorf("ERROR: INVALID RECEIPT")
+
+func sign(payload Payload) (string, error) {
+	config := receiptConfig.Config.GetMetadata()
+
+	message, err := json.Marshal(&payload)
+	if err != nil {
+		return "", err
+	}
+	signature := ed25519.Sign(config.SigningKey, message)
+
+	return fmt.Sprintf("%s.%s", base64.RawURLEncoding.EncodeToString(message), base64.RawURLEncoding.EncodeToString(signature)), nil
+}
+
+// Public
+func Verify(code string) (Payload, error) {
+	config := receiptConfig.Config.GetMetadata()
+
+	parts := strings.Split(strings.TrimSpace(code), ".")
+	if len(parts) != 2 {
+		return Payload{}, ErrInvalidReceipt
+	}
+
+	message, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return Payload{}, ErrInvalidReceipt
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return Payload{}, ErrInvalidReceipt
+	}
+	if !ed25519.Verify(config.SigningKey.Public().(ed25519.PublicKey), message, signature) {
+		return Payload{}, ErrInvalidReceipt
+	}
+
+	payload := Payload{}
+	if err := json.Unmarshal(message, &payload); err != nil {
+		return Payload{}, ErrInvalidReceipt
+	}
+
+	return payload, nil
+}
+
+// Membuat receipt untuk submission yang baru saja diterima, waktu submission diambil dari server
+func Issue(tx *gorm.DB, submission models.Submission, content []byte) (models.Receipt, error) {
+	conditionTeam := models.Team{Model: gorm.Model{ID: submission.TeamID}}
+	team := models.Team{}
+	if err := tx.Where(&conditionTeam).First(&team).Error; err != nil {
+		return models.Receipt{}, err
+	}
+
+	checksum := sha256.Sum256(content)
+	payload := Payload{
+		Serial:       uuid.New(),
+		SubmissionID: submission.ID,
+		TeamID:       team.ID,
+		TeamName:     team.TeamName,
+		Stage:        submission.Stage,
+		Version:      submission.Version,
+		Checksum:     hex.EncodeToString(checksum[:]),
+		FileSize:     int64(len(content)),
+		SubmittedAt:  submission.CreatedAt.UTC().Truncate(time.Microsecond),
+		IsLate:       submission.IsLate,
+	}
+
+	code, err := sign(payload)
+	if err != nil {
+		return models.Receipt{}, err
+	}
+
+	receipt := models.Receipt{
+		Serial:       payload.Serial,
+		SubmissionID: payload.SubmissionID,
+		TeamID:       payload.TeamID,
+		TeamName:     payload.TeamName,
+		Stage:        payload.Stage,
+		Version:      payload.Version,
+		Checksum:     payload.Checksum,
+		FileSize:     payload.FileSize,
+		SubmittedAt:  payload.SubmittedAt,
+		IsLate:       payload.IsLate,
+		Code:         code,
+	}
+	if err := tx.Create(&receipt).Error; err != nil {
+		return models.Receipt{}, err
+	}
+
+	return receipt, nil
+}
+
+func GetVerifyURL(receipt models.Receipt) string {
+	config := receiptConfig.Config.GetMetadata()
+	return fmt.Sprintf("%s?serial=%s", config.VerifyURL, receipt.Serial)
+}
+
+func GetMailData(receipt models.Receipt) map[string]interface{} {
+	return map[string]interface{}{
+		"TeamName":    receipt.TeamName,
+		"Stage":       receipt.Stage,
+		"Version":     receipt.Version,
+		"Checksum":    receipt.Checksum,
+		"FileSize":    receipt.FileSize,
+		"SubmittedAt": receipt.SubmittedAt.Format(time.RFC3339),
+		"IsLate":      receipt.IsLate,
+		"Serial":      receipt.Serial,
+		"Code":        receipt.Code,
+		"VerifyURL":   GetVerifyURL(receipt),
+	}
+}
+
+func GeneratePDF(receipt models.Receipt) ([]byte, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle(fmt.Sprintf("Submission Receipt %s", receipt.Serial), true)
+	pdf.AddPage()
+
+	pdf.SetFont("Helvetica", "B", 18)
+	pdf.CellFormat(0, 12, "Submission Receipt", "", 1, "L", false, 0, "")
+	pdf.Ln(4)
+
+	rows := [][]string{
+		{"Serial", receipt.Serial.String()},
+		{"Team", receipt.TeamName},
+		{"Stage", string(receipt.Stage)},
+		{"Version", fmt.Sprintf("%d", receipt.Version)},
+		{"Submitted At", receipt.SubmittedAt.Format(time.RFC3339Nano)},
+		{"Late", fmt.Sprintf("%t", receipt.IsLate)},
+		{"File Size", fmt.Sprintf("%d bytes", receipt.FileSize)},
+		{"SHA-256", receipt.Checksum},
+	}
+	for _, row := range rows {
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.CellFormat(35, 7, row[0], "1", 0, "L", false, 0, "")
+		pdf.SetFont("Courier", "", 9)
+		pdf.CellFormat(0, 7, row[1], "1", 1, "L", false, 0, "")
+	}
+
+	pdf.Ln(6)
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.CellFormat(0, 7, "Signed Receipt Code", "", 1, "L", false, 0, "")
+	pdf.SetFont("Courier", "", 7)
+	pdf.MultiCell(0, 3.5, receipt.Code, "", "L", false)
+
+	pdf.Ln(4)
+	pdf.SetFont("Helvetica", "", 9)
+	pdf.MultiCell(0, 5, fmt.Sprintf("Verify this receipt at %s", GetVerifyURL(receipt)), "", "L", false)
+
+	buffer := bytes.Buffer{}
+	if err := pdf.Output(&buffer); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
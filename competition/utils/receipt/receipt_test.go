@@ -0,0 +1,92 @@
+package receipt
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+var testSigningKey = bytes.Repeat([]byte{7}, ed25519.SeedSize)
+
+func TestMain(m *testing.M) {
+	os.Setenv("RECEIPT_SIGNING_KEY", base64.StdEncoding.EncodeToString(testSigningKey))
+	os.Setenv("RECEIPT_VERIFY_URL", "http://localhost/receipt/verify")
+
+	os.Exit(m.Run())
+}
+
+// Menandatangani payload dengan kunci lain untuk mensimulasikan receipt palsu
+func signWith(t *testing.T, seed []byte, payload Payload) string {
+	t.Helper()
+
+	message, err := json.Marshal(&payload)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	signature := ed25519.Sign(ed25519.NewKeyFromSeed(seed), message)
+
+	return fmt.Sprintf("%s.%s", base64.RawURLEncoding.EncodeToString(message), base64.RawURLEncoding.EncodeToString(signature))
+}
+
+func TestVerify(t *testing.T) {
+	payload := Payload{
+		Serial:       uuid.New(),
+		SubmissionID: 1,
+		TeamID:       2,
+		TeamName:     "Arkavidia",
+		Stage:        types.FinalStage,
+		Version:      3,
+		Checksum:     "checksum",
+		FileSize:     1024,
+		SubmittedAt:  time.Date(2023, time.February, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	code, err := sign(payload)
+	if err != nil {
+		t.Fatalf("sign() error = %v", err)
+	}
+
+	tamperedPayload := payload
+	tamperedPayload.IsLate = true
+	tamperedMessage, err := json.Marshal(&tamperedPayload)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	parts := strings.Split(code, ".")
+
+	testCases := []struct {
+		name    string
+		code    string
+		wantErr bool
+	}{
+		{name: "valid receipt", code: code, wantErr: false},
+		{name: "surrounding whitespace", code: fmt.Sprintf(" %s\n", code), wantErr: false},
+		{name: "tampered payload", code: fmt.Sprintf("%s.%s", base64.RawURLEncoding.EncodeToString(tamperedMessage), parts[1]), wantErr: true},
+		{name: "tampered signature", code: fmt.Sprintf("%s.%s", parts[0], base64.RawURLEncoding.EncodeToString(make([]byte, ed25519.SignatureSize))), wantErr: true},
+		{name: "another signing key", code: signWith(t, bytes.Repeat([]byte{8}, ed25519.SeedSize), payload), wantErr: true},
+		{name: "missing signature", code: parts[0], wantErr: true},
+		{name: "invalid encoding", code: "!!!.???", wantErr: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual, err := Verify(testCase.code)
+			if (err != nil) != testCase.wantErr {
+				t.Fatalf("Verify() error = %v, wantErr %v", err, testCase.wantErr)
+			}
+			if err == nil && (actual.Serial != payload.Serial || actual.Checksum != payload.Checksum || !actual.SubmittedAt.Equal(payload.SubmittedAt)) {
+				t.Errorf("Verify() = %+v, expected %+v", actual, payload)
+			}
+		})
+	}
+}
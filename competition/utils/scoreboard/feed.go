@@ -0,0 +1,161 @@
+package scoreboard
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Event feed mengikuti format CLICS Contest API (NDJSON), baik versi lama (dengan op) maupun versi baru
+// REFERENCE: https://ccs-specs.icpc.io/2021-11/contest_api#event-feed
+type Event struct {
+	ID   string          `json:"id"`
+	Type string          `json:"type"`
+	Op   string          `json:"op"`
+	Data json.RawMessage `json:"data"`
+}
+
+func (event Event) IsDelete() bool {
+	return event.Op == "delete" || len(event.Data) == 0 || string(event.Data) == "null"
+}
+
+// Nama tipe event pada versi baru menggunakan bentuk tunggal untuk contest dan state
+func (event Event) GetType() string {
+	switch event.Type {
+	case "contest":
+		return "contests"
+	case "state":
+		return "state"
+	default:
+		return event.Type
+	}
+}
+
+type ContestData struct {
+	ID                       string          `json:"id"`
+	Name                     string          `json:"name"`
+	FormalName               string          `json:"formal_name"`
+	StartTime                *string         `json:"start_time"`
+	Duration                 string          `json:"duration"`
+	ScoreboardFreezeDuration *string         `json:"scoreboard_freeze_duration"`
+	PenaltyTime              json.RawMessage `json:"penalty_time"`
+}
+
+type JudgementTypeData struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Penalty bool   `json:"penalty"`
+	Solved  bool   `json:"solved"`
+}
+
+type ProblemData struct {
+	ID      string `json:"id"`
+	Label   string `json:"label"`
+	Name    string `json:"name"`
+	Ordinal int    `json:"ordinal"`
+}
+
+type TeamData struct {
+	ID          string  `json:"id"`
+	ICPCID      *string `json:"icpc_id"`
+	Name        string  `json:"name"`
+	DisplayName *string `json:"display_name"`
+	Hidden      bool    `json:"hidden"`
+}
+
+type SubmissionData struct {
+	ID          string `json:"id"`
+	TeamID      string `json:"team_id"`
+	ProblemID   string `json:"problem_id"`
+	ContestTime string `json:"contest_time"`
+}
+
+type JudgementData struct {
+	ID              string  `json:"id"`
+	SubmissionID    string  `json:"submission_id"`
+	JudgementTypeID *string `json:"judgement_type_id"`
+}
+
+type StateData struct {
+	Frozen *string `json:"frozen"`
+	Thawed *string `json:"thawed"`
+}
+
+func ParseEventFeed(content []byte) ([]Event, error) {
+	events := []Event{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		// Baris kosong dikirimkan sebagai keep-alive oleh event feed
+		if len(line) == 0 {
+			continue
+		}
+
+		event := Event{}
+		if err := json.Unmarshal(line, &event); err != nil {
+			return nil, fmt.Errorf("ERROR: INVALID EVENT FEED")
+		}
+		events = append(events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("ERROR: INVALID EVENT FEED")
+	}
+
+	return events, nil
+}
+
+// Format RELTIME adalah (-)?(h)*h:mm:ss(.uuu)?
+func ParseRelTime(value string) (time.Duration, error) {
+	isNegative := strings.HasPrefix(value, "-")
+	value = strings.TrimPrefix(value, "-")
+
+	parts := strings.Split(value, ":")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("ERROR: INVALID RELATIVE TIME %s", value)
+	}
+
+	hours, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, fmt.Errorf("ERROR: INVALID RELATIVE TIME %s", value)
+	}
+	minutes, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, fmt.Errorf("ERROR: INVALID RELATIVE TIME %s", value)
+	}
+	seconds, err := strconv.ParseFloat(parts[2], 64)
+	if err != nil {
+		return 0, fmt.Errorf("ERROR: INVALID RELATIVE TIME %s", value)
+	}
+
+	duration := time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds*float64(time.Second))
+	if isNegative {
+		duration = -duration
+	}
+
+	return duration, nil
+}
+
+// Penalty time dapat berupa jumlah menit (versi lama) atau RELTIME (versi baru)
+func ParsePenaltyTime(value json.RawMessage) (time.Duration, error) {
+	if len(value) == 0 || string(value) == "null" {
+		return 20 * time.Minute, nil
+	}
+
+	minutes := 0
+	if err := json.Unmarshal(value, &minutes); err == nil {
+		return time.Duration(minutes) * time.Minute, nil
+	}
+
+	relTime := ""
+	if err := json.Unmarshal(value, &relTime); err != nil {
+		return 0, fmt.Errorf("ERROR: INVALID PENALTY TIME")
+	}
+
+	return ParseRelTime(relTime)
+}
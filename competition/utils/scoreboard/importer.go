@@ -0,0 +1,240 @@
+package scoreboard
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"gorm.io/gorm"
+
+	scoreboardConfig "arkavidia-backend-8.0/competition/config/scoreboard"
+	"arkavidia-backend-8.0/competition/models"
+)
+
+// Event feed diambil dari endpoint lokal (mis. DOMjudge /api/contests/{id}/event-feed?stream=false)
+func FetchEventFeed() ([]byte, error) {
+	config := scoreboardConfig.Config.GetMetadata()
+	if config.EventFeedURL == "" {
+		return nil, fmt.Errorf("ERROR: EVENT FEED URL NOT CONFIGURED")
+	}
+
+	request, err := http.NewRequest(http.MethodGet, config.EventFeedURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if config.EventFeedUsername != "" {
+		request.SetBasicAuth(config.EventFeedUsername, config.EventFeedPassword)
+	}
+
+	client := &http.Client{Timeout: time.Minute}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ERROR: EVENT FEED RETURNED STATUS %d", response.StatusCode)
+	}
+
+	return ioutil.ReadAll(response.Body)
+}
+
+// Import bersifat idempotent: setiap entitas di-upsert berdasarkan ID pada event feed
+func Import(tx *gorm.DB, events []Event) (models.Contest, error) {
+	contest := models.Contest{}
+	for _, event := range events {
+		if event.GetType() == "contests" && !event.IsDelete() {
+			if err := importContest(tx, event, &contest); err != nil {
+				return models.Contest{}, err
+			}
+		}
+	}
+	if contest.ID == 0 {
+		return models.Contest{}, fmt.Errorf("ERROR: CONTEST NOT FOUND IN EVENT FEED")
+	}
+
+	for _, event := range events {
+		var err error
+		switch event.GetType() {
+		case "judgement-types":
+			err = importJudgementType(tx, contest, event)
+		case "problems":
+			err = importProblem(tx, contest, event)
+		case "teams":
+			err = importTeam(tx, contest, event)
+		case "submissions":
+			err = importSubmission(tx, contest, event)
+		case "judgements":
+			err = importJudgement(tx, contest, event)
+		case "state":
+			err = importState(tx, &contest, event)
+		}
+		if err != nil {
+			return models.Contest{}, err
+		}
+	}
+
+	return contest, nil
+}
+
+func importContest(tx *gorm.DB, event Event, contest *models.Contest) error {
+	data := ContestData{}
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return fmt.Errorf("ERROR: INVALID CONTEST EVENT")
+	}
+
+	duration, err := ParseRelTime(data.Duration)
+	if err != nil {
+		return err
+	}
+	freezeDuration := time.Duration(0)
+	if data.ScoreboardFreezeDuration != nil {
+		freezeDuration, err = ParseRelTime(*data.ScoreboardFreezeDuration)
+		if err != nil {
+			return err
+		}
+	}
+	penaltyTime, err := ParsePenaltyTime(data.PenaltyTime)
+	if err != nil {
+		return err
+	}
+
+	var startTime *time.Time
+	if data.StartTime != nil {
+		parsedTime, err := time.Parse(time.RFC3339, *data.StartTime)
+		if err != nil {
+			return fmt.Errorf("ERROR: INVALID CONTEST START TIME")
+		}
+		startTime = &parsedTime
+	}
+
+	name := data.FormalName
+	if name == "" {
+		name = data.Name
+	}
+
+	condition := models.Contest{ExternalID: data.ID}
+	newContest := models.Contest{Name: name, StartTime: startTime, Duration: duration, FreezeDuration: freezeDuration, PenaltyTime: penaltyTime}
+	return tx.Where(&condition).Assign(&newContest).FirstOrCreate(contest).Error
+}
+
+func importJudgementType(tx *gorm.DB, contest models.Contest, event Event) error {
+	data := JudgementTypeData{}
+	if event.IsDelete() {
+		condition := models.ContestJudgementType{ContestID: contest.ID, ExternalID: event.ID}
+		return tx.Where(&condition).Delete(&models.ContestJudgementType{}).Error
+	}
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return fmt.Errorf("ERROR: INVALID JUDGEMENT TYPE EVENT")
+	}
+
+	condition := models.ContestJudgementType{ContestID: contest.ID, ExternalID: data.ID}
+	newJudgementType := models.ContestJudgementType{Name: data.Name}
+	judgementType := models.ContestJudgementType{}
+	if err := tx.Where(&condition).Assign(&newJudgementType).FirstOrCreate(&judgementType).Error; err != nil {
+		return err
+	}
+
+	// Nilai boolean false tidak ikut di-assign oleh gorm sehingga diperbarui secara eksplisit
+	return tx.Model(&judgementType).Updates(map[string]interface{}{"is_penalty": data.Penalty, "is_solved": data.Solved}).Error
+}
+
+func importProblem(tx *gorm.DB, contest models.Contest, event Event) error {
+	data := ProblemData{}
+	if event.IsDelete() {
+		condition := models.ContestProblem{ContestID: contest.ID, ExternalID: event.ID}
+		return tx.Where(&condition).Delete(&models.ContestProblem{}).Error
+	}
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return fmt.Errorf("ERROR: INVALID PROBLEM EVENT")
+	}
+
+	condition := models.ContestProblem{ContestID: contest.ID, ExternalID: data.ID}
+	newProblem := models.ContestProblem{Label: data.Label, Name: data.Name, Ordinal: data.Ordinal}
+	problem := models.ContestProblem{}
+	return tx.Where(&condition).Assign(&newProblem).FirstOrCreate(&problem).Error
+}
+
+func importTeam(tx *gorm.DB, contest models.Contest, event Event) error {
+	data := TeamData{}
+	if event.IsDelete() {
+		condition := models.ContestTeam{ContestID: contest.ID, ExternalID: event.ID}
+		return tx.Where(&condition).Delete(&models.ContestTeam{}).Error
+	}
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return fmt.Errorf("ERROR: INVALID TEAM EVENT")
+	}
+
+	name := data.Name
+	if data.DisplayName != nil && *data.DisplayName != "" {
+		name = *data.DisplayName
+	}
+
+	condition := models.ContestTeam{ContestID: contest.ID, ExternalID: data.ID}
+	team := models.ContestTeam{}
+	if err := tx.Where(&condition).Attrs(&models.ContestTeam{Name: name}).FirstOrInit(&team).Error; err != nil {
+		return err
+	}
+
+	team.ICPCID = data.ICPCID
+	team.Name = name
+	team.IsHidden = data.Hidden
+	if err := team.AutoMap(tx); err != nil {
+		return err
+	}
+
+	return tx.Save(&team).Error
+}
+
+func importSubmission(tx *gorm.DB, contest models.Contest, event Event) error {
+	data := SubmissionData{}
+	if event.IsDelete() {
+		condition := models.ContestSubmission{ContestID: contest.ID, ExternalID: event.ID}
+		return tx.Where(&condition).Delete(&models.ContestSubmission{}).Error
+	}
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return fmt.Errorf("ERROR: INVALID SUBMISSION EVENT")
+	}
+
+	contestTime, err := ParseRelTime(data.ContestTime)
+	if err != nil {
+		return err
+	}
+
+	condition := models.ContestSubmission{ContestID: contest.ID, ExternalID: data.ID}
+	newSubmission := models.ContestSubmission{TeamExternalID: data.TeamID, ProblemExternalID: data.ProblemID, ContestTime: contestTime}
+	submission := models.ContestSubmission{}
+	return tx.Where(&condition).Assign(&newSubmission).FirstOrCreate(&submission).Error
+}
+
+// Judgement terakhir untuk sebuah submission (termasuk rejudge) menentukan hasil submission tersebut
+func importJudgement(tx *gorm.DB, contest models.Contest, event Event) error {
+	if event.IsDelete() {
+		return nil
+	}
+
+	data := JudgementData{}
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return fmt.Errorf("ERROR: INVALID JUDGEMENT EVENT")
+	}
+
+	condition := models.ContestSubmission{ContestID: contest.ID, ExternalID: data.SubmissionID}
+	return tx.Model(&models.ContestSubmission{}).Where(&condition).Update("judgement_type_id", data.JudgementTypeID).Error
+}
+
+func importState(tx *gorm.DB, contest *models.Contest, event Event) error {
+	if event.IsDelete() {
+		return nil
+	}
+
+	data := StateData{}
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return fmt.Errorf("ERROR: INVALID STATE EVENT")
+	}
+
+	contest.IsFrozen = data.Frozen != nil && data.Thawed == nil
+	return tx.Model(contest).Update("is_frozen", contest.IsFrozen).Error
+}
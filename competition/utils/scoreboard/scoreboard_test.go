@@ -0,0 +1,194 @@
+package scoreboard
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"arkavidia-backend-8.0/competition/models"
+)
+
+func getJudgement(id string) *string {
+	return &id
+}
+
+func getContestTeam(externalID string, teamID uint, teamName string) models.ContestTeam {
+	return models.ContestTeam{ExternalID: externalID, Name: teamName, TeamID: &teamID, Team: &models.Team{TeamName: teamName}}
+}
+
+func TestRankScoreboard(t *testing.T) {
+	contest := models.Contest{Duration: 5 * time.Hour, FreezeDuration: time.Hour, PenaltyTime: 20 * time.Minute, IsFrozen: true}
+	contestProblems := []models.ContestProblem{{ExternalID: "a", Label: "A"}, {ExternalID: "b", Label: "B"}}
+	contestJudgementTypes := []models.ContestJudgementType{{ExternalID: "AC", IsSolved: true}, {ExternalID: "WA", IsPenalty: true}, {ExternalID: "CE"}}
+	contestTeams := []models.ContestTeam{
+		getContestTeam("t1", 1, "Alpha"),
+		getContestTeam("t2", 2, "Beta"),
+		getContestTeam("t3", 3, "Gamma"),
+		getContestTeam("t4", 4, "Delta"),
+		getContestTeam("t5", 5, "Epsilon"),
+	}
+	// Submission diurutkan berdasarkan contest_time seperti hasil query GetScoreboard
+	contestSubmissions := []models.ContestSubmission{
+		{TeamExternalID: "t1", ProblemExternalID: "a", ContestTime: 10 * time.Minute, JudgementTypeID: getJudgement("WA")},
+		{TeamExternalID: "t2", ProblemExternalID: "a", ContestTime: 20 * time.Minute, JudgementTypeID: getJudgement("AC")},
+		{TeamExternalID: "t1", ProblemExternalID: "a", ContestTime: 30 * time.Minute, JudgementTypeID: getJudgement("AC")},
+		{TeamExternalID: "t3", ProblemExternalID: "a", ContestTime: 45 * time.Minute, JudgementTypeID: getJudgement("AC")},
+		{TeamExternalID: "t2", ProblemExternalID: "b", ContestTime: 50 * time.Minute, JudgementTypeID: getJudgement("CE")},
+		{TeamExternalID: "t1", ProblemExternalID: "b", ContestTime: 100 * time.Minute, JudgementTypeID: getJudgement("AC")},
+		{TeamExternalID: "t2", ProblemExternalID: "b", ContestTime: 130 * time.Minute, JudgementTypeID: getJudgement("AC")},
+		{TeamExternalID: "t4", ProblemExternalID: "a", ContestTime: 200 * time.Minute, JudgementTypeID: nil},
+		{TeamExternalID: "t3", ProblemExternalID: "b", ContestTime: 250 * time.Minute, JudgementTypeID: getJudgement("AC")},
+		{TeamExternalID: "t5", ProblemExternalID: "a", ContestTime: 310 * time.Minute, JudgementTypeID: getJudgement("AC")},
+		{TeamExternalID: "unknown", ProblemExternalID: "a", ContestTime: 5 * time.Minute, JudgementTypeID: getJudgement("AC")},
+	}
+
+	type expectedRow struct {
+		teamName string
+		rank     int
+		solved   int
+		penalty  int
+	}
+
+	testCases := []struct {
+		name       string
+		isUnfrozen bool
+		expected   []expectedRow
+		pendingB   int
+	}{
+		{
+			name:       "frozen scoreboard",
+			isUnfrozen: false,
+			expected:   []expectedRow{{"Alpha", 1, 2, 150}, {"Beta", 2, 2, 150}, {"Gamma", 3, 1, 45}, {"Delta", 4, 0, 0}, {"Epsilon", 4, 0, 0}},
+			pendingB:   1,
+		},
+		{
+			name:       "unfrozen scoreboard",
+			isUnfrozen: true,
+			expected:   []expectedRow{{"Alpha", 1, 2, 150}, {"Beta", 2, 2, 150}, {"Gamma", 3, 2, 295}, {"Delta", 4, 0, 0}, {"Epsilon", 4, 0, 0}},
+			pendingB:   0,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			scoreboard := models.RankScoreboard(contest, contestProblems, contestJudgementTypes, contestTeams, contestSubmissions, testCase.isUnfrozen)
+			if len(scoreboard) != len(testCase.expected) {
+				t.Fatalf("RankScoreboard() returned %d rows, expected %d", len(scoreboard), len(testCase.expected))
+			}
+
+			for i, expected := range testCase.expected {
+				row := scoreboard[i]
+				if row.TeamName != expected.teamName || row.Rank != expected.rank || row.Solved != expected.solved || row.Penalty != expected.penalty {
+					t.Errorf("row %d = {%s rank %d solved %d penalty %d}, expected %+v", i, row.TeamName, row.Rank, row.Solved, row.Penalty, expected)
+				}
+			}
+
+			// Beta menyelesaikan soal A paling awal, sedangkan submission Gamma pada soal B berada setelah freeze
+			if !scoreboard[1].Problems[0].IsFirstToSolve || scoreboard[0].Problems[0].IsFirstToSolve {
+				t.Errorf("first to solve A is not Beta")
+			}
+			if pending := scoreboard[2].Problems[1].NumPending; pending != testCase.pendingB {
+				t.Errorf("Gamma pending on B = %d, expected %d", pending, testCase.pendingB)
+			}
+			if pending := scoreboard[3].Problems[0].NumPending; pending != 1 {
+				t.Errorf("Delta pending on A = %d, expected 1", pending)
+			}
+		})
+	}
+}
+
+func TestParseRelTime(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{name: "hours minutes seconds", value: "1:02:03", expected: time.Hour + 2*time.Minute + 3*time.Second},
+		{name: "milliseconds", value: "0:00:01.500", expected: 1500 * time.Millisecond},
+		{name: "negative", value: "-0:30:00", expected: -30 * time.Minute},
+		{name: "long contest", value: "125:00:00", expected: 125 * time.Hour},
+		{name: "missing part", value: "1:00", wantErr: true},
+		{name: "not numeric", value: "a:00:00", wantErr: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual, err := ParseRelTime(testCase.value)
+			if (err != nil) != testCase.wantErr {
+				t.Fatalf("ParseRelTime() error = %v, wantErr %v", err, testCase.wantErr)
+			}
+			if err == nil && actual != testCase.expected {
+				t.Errorf("ParseRelTime() = %v, expected %v", actual, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestParsePenaltyTime(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    json.RawMessage
+		expected time.Duration
+		wantErr  bool
+	}{
+		{name: "default", value: nil, expected: 20 * time.Minute},
+		{name: "null", value: json.RawMessage(`null`), expected: 20 * time.Minute},
+		{name: "minutes", value: json.RawMessage(`10`), expected: 10 * time.Minute},
+		{name: "relative time", value: json.RawMessage(`"0:15:00"`), expected: 15 * time.Minute},
+		{name: "invalid", value: json.RawMessage(`{}`), wantErr: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual, err := ParsePenaltyTime(testCase.value)
+			if (err != nil) != testCase.wantErr {
+				t.Fatalf("ParsePenaltyTime() error = %v, wantErr %v", err, testCase.wantErr)
+			}
+			if err == nil && actual != testCase.expected {
+				t.Errorf("ParsePenaltyTime() = %v, expected %v", actual, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestParseEventFeed(t *testing.T) {
+	testCases := []struct {
+		name         string
+		content      string
+		expected     []string
+		expectDelete []bool
+		wantErr      bool
+	}{
+		{
+			name:         "legacy feed with keep alive",
+			content:      "{\"id\":\"1\",\"type\":\"contests\",\"op\":\"create\",\"data\":{\"id\":\"wf\"}}\n\n{\"id\":\"2\",\"type\":\"teams\",\"op\":\"delete\",\"data\":{\"id\":\"t1\"}}\n",
+			expected:     []string{"contests", "teams"},
+			expectDelete: []bool{false, true},
+		},
+		{
+			name:         "new feed",
+			content:      "{\"type\":\"contest\",\"id\":\"wf\",\"data\":{\"id\":\"wf\"}}\n{\"type\":\"problems\",\"id\":\"a\",\"data\":null}\n",
+			expected:     []string{"contests", "problems"},
+			expectDelete: []bool{false, true},
+		},
+		{name: "invalid line", content: "{\"type\":\"teams\"\n", wantErr: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			events, err := ParseEventFeed([]byte(testCase.content))
+			if (err != nil) != testCase.wantErr {
+				t.Fatalf("ParseEventFeed() error = %v, wantErr %v", err, testCase.wantErr)
+			}
+			if len(events) != len(testCase.expected) {
+				t.Fatalf("ParseEventFeed() returned %d events, expected %d", len(events), len(testCase.expected))
+			}
+			for i, event := range events {
+				if event.GetType() != testCase.expected[i] || event.IsDelete() != testCase.expectDelete[i] {
+					t.Errorf("event %d = {%s delete %v}, expected {%s delete %v}", i, event.GetType(), event.IsDelete(), testCase.expected[i], testCase.expectDelete[i])
+				}
+			}
+		})
+	}
+}
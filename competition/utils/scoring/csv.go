@@ -0,0 +1,86 @@
+package scoring
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strings"
+)
+
+// Kolom usage pada ground truth menentukan apakah baris termasuk split public atau private (format Kaggle)
+const usageColumn = "usage"
+
+type GroundTruthRow struct {
+	Target   string
+	IsPublic bool
+}
+
+func readCSV(content []byte, columns ...string) ([][]string, []int, error) {
+	reader := csv.NewReader(bytes.NewReader(content))
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("ERROR: INVALID CSV")
+	}
+	if len(records) < 2 {
+		return nil, nil, fmt.Errorf("ERROR: CSV HAS NO ROWS")
+	}
+
+	header := map[string]int{}
+	for i, column := range records[0] {
+		header[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+
+	indexes := []int{}
+	for _, column := range columns {
+		index, exists := header[strings.ToLower(column)]
+		if !exists {
+			return nil, nil, fmt.Errorf("ERROR: COLUMN %s NOT FOUND", strings.ToUpper(column))
+		}
+		indexes = append(indexes, index)
+	}
+
+	return records[1:], indexes, nil
+}
+
+func ParseGroundTruth(content []byte, idColumn string, targetColumn string) (map[string]GroundTruthRow, error) {
+	records, indexes, err := readCSV(content, idColumn, targetColumn, usageColumn)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := map[string]GroundTruthRow{}
+	for _, record := range records {
+		id := strings.TrimSpace(record[indexes[0]])
+		if _, exists := rows[id]; exists {
+			return nil, fmt.Errorf("ERROR: DUPLICATE ID %s", id)
+		}
+		rows[id] = GroundTruthRow{Target: strings.TrimSpace(record[indexes[1]]), IsPublic: strings.EqualFold(strings.TrimSpace(record[indexes[2]]), "public")}
+	}
+
+	return rows, nil
+}
+
+// Prediksi harus memiliki tepat satu baris untuk setiap id pada ground truth
+func ParsePrediction(content []byte, idColumn string, targetColumn string, groundTruth map[string]GroundTruthRow) (map[string]string, error) {
+	records, indexes, err := readCSV(content, idColumn, targetColumn)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) != len(groundTruth) {
+		return nil, fmt.Errorf("ERROR: EXPECTED %d ROWS BUT GOT %d", len(groundTruth), len(records))
+	}
+
+	predictions := map[string]string{}
+	for _, record := range records {
+		id := strings.TrimSpace(record[indexes[0]])
+		if _, exists := groundTruth[id]; !exists {
+			return nil, fmt.Errorf("ERROR: UNKNOWN ID %s", id)
+		}
+		if _, exists := predictions[id]; exists {
+			return nil, fmt.Errorf("ERROR: DUPLICATE ID %s", id)
+		}
+		predictions[id] = strings.TrimSpace(record[indexes[1]])
+	}
+
+	return predictions, nil
+}
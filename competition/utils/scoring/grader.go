@@ -0,0 +1,94 @@
+package scoring
+
+import (
+	"fmt"
+	"log"
+
+	"gorm.io/gorm"
+
+	storageConfig "arkavidia-backend-8.0/competition/config/storage"
+	"arkavidia-backend-8.0/competition/models"
+	storageService "arkavidia-backend-8.0/competition/services/storage"
+)
+
+// Private
+func loadGroundTruth(groundTruth models.GroundTruth) (map[string]GroundTruthRow, error) {
+	config := storageConfig.Config.GetMetadata()
+
+	filename := fmt.Sprintf("%s%s", groundTruth.FileName, groundTruth.FileExtension)
+	groundTruthContent, err := storageService.Client.DownloadFile(filename, config.GroundTruthDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseGroundTruth(groundTruthContent, groundTruth.IDColumn, groundTruth.TargetColumn)
+}
+
+func gradeRows(groundTruth models.GroundTruth, rows map[string]GroundTruthRow, content []byte) (models.DatavidiaScore, error) {
+	predictions, err := ParsePrediction(content, groundTruth.IDColumn, groundTruth.TargetColumn, rows)
+	if err != nil {
+		return models.DatavidiaScore{}, err
+	}
+
+	publicScore, privateScore, err := Evaluate(groundTruth.Metric, rows, predictions)
+	if err != nil {
+		return models.DatavidiaScore{}, err
+	}
+
+	return models.DatavidiaScore{
+		GroundTruthID: groundTruth.ID,
+		Metric:        groundTruth.Metric,
+		PublicScore:   publicScore,
+		PrivateScore:  privateScore,
+	}, nil
+}
+
+// Public
+// Menilai file prediksi terhadap ground truth, hasil penilaian belum memiliki SubmissionID
+func Grade(groundTruth models.GroundTruth, content []byte) (models.DatavidiaScore, error) {
+	rows, err := loadGroundTruth(groundTruth)
+	if err != nil {
+		return models.DatavidiaScore{}, err
+	}
+
+	return gradeRows(groundTruth, rows, content)
+}
+
+// Menilai ulang seluruh submission pada stage ground truth setelah ground truth diganti,
+// nilai submission yang tidak lagi sesuai dengan ground truth baru dihapus
+func Rescore(tx *gorm.DB, groundTruth models.GroundTruth) error {
+	config := storageConfig.Config.GetMetadata()
+
+	rows, err := loadGroundTruth(groundTruth)
+	if err != nil {
+		return err
+	}
+
+	datavidiaScores := []models.DatavidiaScore{}
+	if err := tx.Preload("Submission").Joins("JOIN submissions ON submissions.id = datavidia_scores.submission_id AND submissions.deleted_at IS NULL").Where("submissions.stage = ?", groundTruth.Stage).Find(&datavidiaScores).Error; err != nil {
+		return err
+	}
+
+	for _, datavidiaScore := range datavidiaScores {
+		submission := datavidiaScore.Submission
+		content, err := storageService.Client.DownloadFile(fmt.Sprintf("%s%s", submission.FileName, submission.FileExtension), config.SubmissionDir)
+		if err != nil {
+			return err
+		}
+
+		newScore, err := gradeRows(groundTruth, rows, content)
+		if err != nil {
+			log.Printf("WARNING: SUBMISSION %d CANNOT BE RESCORED: %s", submission.ID, err.Error())
+			if err := tx.Unscoped().Delete(&datavidiaScore).Error; err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := tx.Model(&datavidiaScore).Updates(map[string]interface{}{"ground_truth_id": newScore.GroundTruthID, "metric": newScore.Metric, "public_score": newScore.PublicScore, "private_score": newScore.PrivateScore}).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package scoring
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+func toFloat(value string) (float64, error) {
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("ERROR: VALUE %s IS NOT NUMERIC", value)
+	}
+
+	return number, nil
+}
+
+func rmse(actual []string, predicted []string) (float64, error) {
+	total := 0.0
+	for i := range actual {
+		actualValue, err := toFloat(actual[i])
+		if err != nil {
+			return 0, err
+		}
+		predictedValue, err := toFloat(predicted[i])
+		if err != nil {
+			return 0, err
+		}
+		total += (actualValue - predictedValue) * (actualValue - predictedValue)
+	}
+
+	return math.Sqrt(total / float64(len(actual))), nil
+}
+
+func mae(actual []string, predicted []string) (float64, error) {
+	total := 0.0
+	for i := range actual {
+		actualValue, err := toFloat(actual[i])
+		if err != nil {
+			return 0, err
+		}
+		predictedValue, err := toFloat(predicted[i])
+		if err != nil {
+			return 0, err
+		}
+		total += math.Abs(actualValue - predictedValue)
+	}
+
+	return total / float64(len(actual)), nil
+}
+
+func accuracy(actual []string, predicted []string) float64 {
+	correct := 0
+	for i := range actual {
+		if actual[i] == predicted[i] {
+			correct++
+		}
+	}
+
+	return float64(correct) / float64(len(actual))
+}
+
+// F1 dihitung untuk setiap kelas pada ground truth maupun prediksi, kemudian dirata-ratakan tanpa bobot
+func macroF1(actual []string, predicted []string) float64 {
+	truePositive := map[string]int{}
+	falsePositive := map[string]int{}
+	falseNegative := map[string]int{}
+	classes := map[string]bool{}
+
+	for i := range actual {
+		classes[actual[i]] = true
+		classes[predicted[i]] = true
+		if actual[i] == predicted[i] {
+			truePositive[actual[i]]++
+		} else {
+			falsePositive[predicted[i]]++
+			falseNegative[actual[i]]++
+		}
+	}
+
+	total := 0.0
+	for class := range classes {
+		denominator := 2*truePositive[class] + falsePositive[class] + falseNegative[class]
+		if denominator > 0 {
+			total += float64(2*truePositive[class]) / float64(denominator)
+		}
+	}
+
+	return total / float64(len(classes))
+}
+
+func compute(metric types.ScoringMetric, actual []string, predicted []string) (float64, error) {
+	if len(actual) == 0 {
+		return 0, nil
+	}
+
+	switch metric {
+	case types.RMSE:
+		return rmse(actual, predicted)
+	case types.MAE:
+		return mae(actual, predicted)
+	case types.Accuracy:
+		return accuracy(actual, predicted), nil
+	case types.MacroF1:
+		return macroF1(actual, predicted), nil
+	default:
+		return 0, fmt.Errorf("ERROR: UNKNOWN METRIC")
+	}
+}
+
+// Public
+func Evaluate(metric types.ScoringMetric, groundTruth map[string]GroundTruthRow, predictions map[string]string) (float64, float64, error) {
+	publicActual, publicPredicted := []string{}, []string{}
+	privateActual, privatePredicted := []string{}, []string{}
+	for id, row := range groundTruth {
+		if row.IsPublic {
+			publicActual = append(publicActual, row.Target)
+			publicPredicted = append(publicPredicted, predictions[id])
+		} else {
+			privateActual = append(privateActual, row.Target)
+			privatePredicted = append(privatePredicted, predictions[id])
+		}
+	}
+
+	publicScore, err := compute(metric, publicActual, publicPredicted)
+	if err != nil {
+		return 0, 0, err
+	}
+	privateScore, err := compute(metric, privateActual, privatePredicted)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return publicScore, privateScore, nil
+}
@@ -0,0 +1,82 @@
+package scoring
+
+import (
+	"math"
+	"testing"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+const epsilon = 1e-9
+
+func TestCompute(t *testing.T) {
+	testCases := []struct {
+		name      string
+		metric    types.ScoringMetric
+		actual    []string
+		predicted []string
+		expected  float64
+		wantErr   bool
+	}{
+		{name: "rmse exact", metric: types.RMSE, actual: []string{"1", "2", "3"}, predicted: []string{"1", "2", "3"}, expected: 0},
+		{name: "rmse", metric: types.RMSE, actual: []string{"1", "2", "3"}, predicted: []string{"1", "2", "5"}, expected: math.Sqrt(4.0 / 3.0)},
+		{name: "rmse non numeric", metric: types.RMSE, actual: []string{"1"}, predicted: []string{"one"}, wantErr: true},
+		{name: "mae", metric: types.MAE, actual: []string{"1", "2", "3"}, predicted: []string{"2", "2", "1"}, expected: 1},
+		{name: "mae non numeric", metric: types.MAE, actual: []string{"x"}, predicted: []string{"1"}, wantErr: true},
+		{name: "accuracy", metric: types.Accuracy, actual: []string{"a", "b", "c"}, predicted: []string{"a", "b", "d"}, expected: 2.0 / 3.0},
+		{name: "macro f1", metric: types.MacroF1, actual: []string{"a", "a", "b", "b"}, predicted: []string{"a", "b", "b", "b"}, expected: (2.0/3.0 + 4.0/5.0) / 2},
+		{name: "macro f1 unseen class", metric: types.MacroF1, actual: []string{"a", "a"}, predicted: []string{"a", "c"}, expected: (2.0 / 3.0) / 2},
+		{name: "empty split", metric: types.RMSE, actual: []string{}, predicted: []string{}, expected: 0},
+		{name: "unknown metric", metric: types.ScoringMetric("unknown"), actual: []string{"1"}, predicted: []string{"1"}, wantErr: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual, err := compute(testCase.metric, testCase.actual, testCase.predicted)
+			if (err != nil) != testCase.wantErr {
+				t.Fatalf("compute() error = %v, wantErr %v", err, testCase.wantErr)
+			}
+			if err == nil && math.Abs(actual-testCase.expected) > epsilon {
+				t.Errorf("compute() = %v, expected %v", actual, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	groundTruth, err := ParseGroundTruth([]byte("id,target,usage\n1,a,Public\n2,b,Public\n3,a,Private\n4,b,Private\n"), "id", "target")
+	if err != nil {
+		t.Fatalf("ParseGroundTruth() error = %v", err)
+	}
+
+	testCases := []struct {
+		name            string
+		prediction      string
+		expectedPublic  float64
+		expectedPrivate float64
+		wantErr         bool
+	}{
+		{name: "all correct", prediction: "id,target\n1,a\n2,b\n3,a\n4,b\n", expectedPublic: 1, expectedPrivate: 1},
+		{name: "private wrong", prediction: "id,target\n1,a\n2,b\n3,b\n4,a\n", expectedPublic: 1, expectedPrivate: 0},
+		{name: "public half", prediction: "id,target\n2,b\n1,b\n4,b\n3,a\n", expectedPublic: 0.5, expectedPrivate: 1},
+		{name: "missing row", prediction: "id,target\n1,a\n2,b\n3,a\n", wantErr: true},
+		{name: "unknown id", prediction: "id,target\n1,a\n2,b\n3,a\n5,b\n", wantErr: true},
+		{name: "duplicate id", prediction: "id,target\n1,a\n1,b\n3,a\n4,b\n", wantErr: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			predictions, err := ParsePrediction([]byte(testCase.prediction), "id", "target", groundTruth)
+			if err == nil {
+				var publicScore, privateScore float64
+				publicScore, privateScore, err = Evaluate(types.Accuracy, groundTruth, predictions)
+				if err == nil && (math.Abs(publicScore-testCase.expectedPublic) > epsilon || math.Abs(privateScore-testCase.expectedPrivate) > epsilon) {
+					t.Errorf("Evaluate() = (%v, %v), expected (%v, %v)", publicScore, privateScore, testCase.expectedPublic, testCase.expectedPrivate)
+				}
+			}
+			if (err != nil) != testCase.wantErr {
+				t.Errorf("Evaluate() error = %v, wantErr %v", err, testCase.wantErr)
+			}
+		})
+	}
+}
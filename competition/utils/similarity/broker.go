@@ -0,0 +1,128 @@
+package similarity
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sync"
+
+	"gorm.io/gorm"
+
+	messageConfig "arkavidia-backend-8.0/competition/config/message"
+	storageConfig "arkavidia-backend-8.0/competition/config/storage"
+	"arkavidia-backend-8.0/competition/models"
+	databaseService "arkavidia-backend-8.0/competition/services/database"
+	storageService "arkavidia-backend-8.0/competition/services/storage"
+	"arkavidia-backend-8.0/competition/types"
+)
+
+type AnalysisParameters struct {
+	AnalysisID uint
+}
+
+type AnalysisBroker struct {
+	channel chan AnalysisParameters
+	wg      sync.WaitGroup
+	once    sync.Once
+}
+
+// Private
+func (analysisBroker *AnalysisBroker) lazyInit() {
+	analysisBroker.once.Do(func() {
+		config := messageConfig.Config.GetMetadata()
+
+		// Asynchronous Channel
+		analysisBroker.channel = make(chan AnalysisParameters, config.BufferSize)
+	})
+}
+
+// Hanya submission final dari setiap team pada jenis lomba dan stage yang sama yang dibandingkan
+func (analysisBroker *AnalysisBroker) analyze(analysisParameters AnalysisParameters) error {
+	analysisBroker.lazyInit()
+
+	db := databaseService.DB.GetConnection()
+	config := storageConfig.Config.GetMetadata()
+
+	conditionAnalysis := models.SimilarityAnalysis{Model: gorm.Model{ID: analysisParameters.AnalysisID}}
+	analysis := models.SimilarityAnalysis{}
+	if err := db.Where(&conditionAnalysis).First(&analysis).Error; err != nil {
+		return err
+	}
+
+	submissions := []models.Submission{}
+	if err := db.Joins("JOIN enrolments ON enrolments.id = submissions.enrolment_id AND enrolments.deleted_at IS NULL").Where("enrolments.team_category = ? AND submissions.stage = ? AND submissions.is_final = ?", analysis.TeamCategory, analysis.Stage, true).Find(&submissions).Error; err != nil {
+		return err
+	}
+
+	// Submission diunduh satu per satu dan hanya signature-nya yang disimpan, isi file dilepas setelah diekstrak
+	signatures := []Signature{}
+	for _, submission := range submissions {
+		filename := fmt.Sprintf("%s%s", submission.FileName, submission.FileExtension)
+		content, err := storageService.Client.DownloadFile(filename, config.SubmissionDir)
+		if err != nil {
+			return err
+		}
+
+		document, err := Extract(submission.ID, filename, content)
+		if err != nil {
+			// File yang tidak dapat diekstrak tetap dibandingkan secara exact
+			document = Document{SubmissionID: submission.ID, Kind: types.BinarySimilarity, Hash: sha256.Sum256(content)}
+		}
+		signatures = append(signatures, Sign(document))
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for i := range signatures {
+			for j := i + 1; j < len(signatures); j++ {
+				score, regions := Compare(signatures[i], signatures[j])
+				if score < analysis.Threshold || score == 0 {
+					continue
+				}
+
+				report := models.SimilarityReport{AnalysisID: analysis.ID, LeftSubmissionID: signatures[i].SubmissionID, RightSubmissionID: signatures[j].SubmissionID, Kind: signatures[i].Kind, Score: score}
+				for _, region := range regions {
+					report.Regions = append(report.Regions, models.SimilarityRegion{LeftStartLine: region.LeftStartLine, LeftEndLine: region.LeftEndLine, RightStartLine: region.RightStartLine, RightEndLine: region.RightEndLine})
+				}
+				if err := tx.Create(&report).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		return tx.Model(&analysis).Update("status", types.AnalysisCompleted).Error
+	})
+}
+
+func (analysisBroker *AnalysisBroker) analysisRun() {
+	defer analysisBroker.wg.Done()
+
+	analysisBroker.lazyInit()
+	for analysisParameters := range analysisBroker.channel {
+		if err := analysisBroker.analyze(analysisParameters); err != nil {
+			db := databaseService.DB.GetConnection()
+			condition := models.SimilarityAnalysis{Model: gorm.Model{ID: analysisParameters.AnalysisID}}
+			db.Model(&models.SimilarityAnalysis{}).Where(&condition).Update("status", types.AnalysisFailed)
+		}
+	}
+}
+
+// Public
+func (analysisBroker *AnalysisBroker) AddAnalysisToBroker(analysisParameters AnalysisParameters) {
+	analysisBroker.lazyInit()
+	analysisBroker.channel <- analysisParameters
+}
+
+func (analysisBroker *AnalysisBroker) RunAnalysisWorker(numOfWorkers int) {
+	analysisBroker.lazyInit()
+	analysisBroker.wg.Add(numOfWorkers)
+	for i := 0; i < numOfWorkers; i++ {
+		go analysisBroker.analysisRun()
+	}
+	analysisBroker.wg.Wait()
+}
+
+func (analysisBroker *AnalysisBroker) CloseWorker() {
+	analysisBroker.lazyInit()
+	close(analysisBroker.channel)
+}
+
+var Broker = &AnalysisBroker{}
@@ -0,0 +1,187 @@
+package similarity
+
+import (
+	"crypto/sha256"
+	"hash/fnv"
+	"sort"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+// Region menyatakan rentang baris yang cocok pada kedua dokumen
+type Region struct {
+	LeftStartLine  int
+	LeftEndLine    int
+	RightStartLine int
+	RightEndLine   int
+}
+
+type fingerprint struct {
+	Hash      uint64
+	Position  int
+	StartLine int
+	EndLine   int
+}
+
+// Signature adalah ringkasan dokumen berupa fingerprint hasil winnowing, hanya signature yang disimpan selama analisis
+// sehingga memori tidak bergantung pada ukuran isi submission
+type Signature struct {
+	SubmissionID uint
+	Kind         types.SimilarityKind
+	Hash         [sha256.Size]byte
+	LastLine     int
+	Fingerprints []fingerprint
+}
+
+// Panjang k-gram dan ukuran window winnowing untuk setiap jenis dokumen
+// REFERENCE: https://theory.stanford.edu/~aiken/publications/papers/sigmod03.pdf
+func getParameters(kind types.SimilarityKind) (int, int) {
+	switch kind {
+	case types.SourceSimilarity:
+		return 12, 4
+	case types.TextSimilarity:
+		return 8, 4
+	default:
+		return 1, 1
+	}
+}
+
+func hashKGram(tokens []Token) uint64 {
+	hash := fnv.New64a()
+	for _, token := range tokens {
+		hash.Write([]byte(token.Value))
+		hash.Write([]byte{0})
+	}
+
+	return hash.Sum64()
+}
+
+func winnow(tokens []Token, k int, w int) []fingerprint {
+	if len(tokens) < k {
+		return nil
+	}
+
+	hashes := make([]uint64, len(tokens)-k+1)
+	for i := range hashes {
+		hashes[i] = hashKGram(tokens[i : i+k])
+	}
+
+	fingerprints := []fingerprint{}
+	last := -1
+	windows := len(hashes) - w + 1
+	if windows < 1 {
+		windows = 1
+	}
+	for start := 0; start < windows; start++ {
+		end := start + w
+		if end > len(hashes) {
+			end = len(hashes)
+		}
+
+		// Hash minimum paling kanan pada window dipilih sebagai fingerprint
+		minimum := start
+		for i := start; i < end; i++ {
+			if hashes[i] <= hashes[minimum] {
+				minimum = i
+			}
+		}
+		if minimum != last {
+			fingerprints = append(fingerprints, fingerprint{Hash: hashes[minimum], Position: minimum, StartLine: tokens[minimum].Line, EndLine: tokens[minimum+k-1].Line})
+			last = minimum
+		}
+	}
+
+	return fingerprints
+}
+
+func getLastLine(document Document) int {
+	if len(document.Tokens) == 0 {
+		return 0
+	}
+
+	return document.Tokens[len(document.Tokens)-1].Line
+}
+
+// Public
+func Sign(document Document) Signature {
+	signature := Signature{SubmissionID: document.SubmissionID, Kind: document.Kind, Hash: document.Hash, LastLine: getLastLine(document)}
+	if document.Kind != types.BinarySimilarity {
+		k, w := getParameters(document.Kind)
+		signature.Fingerprints = winnow(document.Tokens, k, w)
+	}
+
+	return signature
+}
+
+// Mengembalikan skor kemiripan (Jaccard dari himpunan fingerprint) beserta region yang cocok
+func Compare(left Signature, right Signature) (float64, []Region) {
+	if left.Hash == right.Hash {
+		// File biner tidak memiliki baris sehingga tidak ada region yang dapat ditandai
+		if left.LastLine == 0 {
+			return 1, []Region{}
+		}
+		return 1, []Region{{LeftStartLine: 1, LeftEndLine: left.LastLine, RightStartLine: 1, RightEndLine: right.LastLine}}
+	}
+	if left.Kind != right.Kind || left.Kind == types.BinarySimilarity {
+		return 0, nil
+	}
+
+	k, _ := getParameters(left.Kind)
+	if len(left.Fingerprints) == 0 || len(right.Fingerprints) == 0 {
+		return 0, nil
+	}
+
+	rightFingerprints := map[uint64]fingerprint{}
+	for _, rightFingerprint := range right.Fingerprints {
+		if _, exists := rightFingerprints[rightFingerprint.Hash]; !exists {
+			rightFingerprints[rightFingerprint.Hash] = rightFingerprint
+		}
+	}
+
+	leftHashes := map[uint64]bool{}
+	matches := [][2]fingerprint{}
+	for _, leftFingerprint := range left.Fingerprints {
+		if leftHashes[leftFingerprint.Hash] {
+			continue
+		}
+		leftHashes[leftFingerprint.Hash] = true
+
+		if rightFingerprint, exists := rightFingerprints[leftFingerprint.Hash]; exists {
+			matches = append(matches, [2]fingerprint{leftFingerprint, rightFingerprint})
+		}
+	}
+
+	union := len(leftHashes) + len(rightFingerprints) - len(matches)
+	score := float64(len(matches)) / float64(union)
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i][0].Position < matches[j][0].Position
+	})
+
+	// Fingerprint yang berdekatan pada kedua dokumen digabung menjadi satu region
+	regions := []Region{}
+	leftEnd, rightStart, rightEnd := -1, -1, -1
+	for _, match := range matches {
+		leftMatch, rightMatch := match[0], match[1]
+
+		if len(regions) > 0 && leftMatch.Position <= leftEnd+k && rightMatch.Position >= rightStart && rightMatch.Position <= rightEnd+k {
+			leftEnd = leftMatch.Position + k - 1
+			regions[len(regions)-1].LeftEndLine = leftMatch.EndLine
+			if rightMatch.Position+k-1 > rightEnd {
+				rightEnd = rightMatch.Position + k - 1
+				regions[len(regions)-1].RightEndLine = rightMatch.EndLine
+			}
+			continue
+		}
+
+		leftEnd, rightStart, rightEnd = leftMatch.Position+k-1, rightMatch.Position, rightMatch.Position+k-1
+		regions = append(regions, Region{
+			LeftStartLine:  leftMatch.StartLine,
+			LeftEndLine:    leftMatch.EndLine,
+			RightStartLine: rightMatch.StartLine,
+			RightEndLine:   rightMatch.EndLine,
+		})
+	}
+
+	return score, regions
+}
@@ -0,0 +1,141 @@
+package similarity
+
+import (
+	"testing"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+const original = `package main
+
+import "fmt"
+
+// Menghitung jumlah bilangan genap
+func sumEven(numbers []int) int {
+	total := 0
+	for _, number := range numbers {
+		if number%2 == 0 {
+			total += number
+		}
+	}
+	return total
+}
+
+func main() {
+	fmt.Println(sumEven([]int{1, 2, 3, 4}))
+}
+`
+
+// Nama variabel dan komentar diganti namun struktur kode tetap sama
+const renamed = `package main
+
+import "fmt"
+
+func hitung(xs []int) int {
+	hasil := 0
+	for _, x := range xs {
+		if x%2 == 0 {
+			hasil += x
+		}
+	}
+	return hasil
+}
+
+func main() {
+	fmt.Println(hitung([]int{5, 6, 7, 8}))
+}
+`
+
+const unrelated = `package main
+
+import (
+	"net/http"
+	"strings"
+)
+
+type server struct {
+	routes map[string]http.HandlerFunc
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	handler, ok := s.routes[strings.TrimSuffix(r.URL.Path, "/")]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	handler(w, r)
+}
+`
+
+func mustSign(t *testing.T, submissionID uint, filename string, content string) Signature {
+	t.Helper()
+
+	document, err := Extract(submissionID, filename, []byte(content))
+	if err != nil {
+		t.Fatalf("Extract(%s) error = %v", filename, err)
+	}
+
+	return Sign(document)
+}
+
+func TestExtract(t *testing.T) {
+	testCases := []struct {
+		name     string
+		filename string
+		content  string
+		expected types.SimilarityKind
+	}{
+		{name: "source code", filename: "main.go", content: original, expected: types.SourceSimilarity},
+		{name: "notebook", filename: "solution.ipynb", content: `{"cells":[{"cell_type":"code","source":["import pandas as pd\n","df = pd.read_csv('train.csv')"]}]}`, expected: types.NotebookSimilarity},
+		{name: "csv", filename: "prediction.csv", content: "id,target\n1,a\n2,b\n", expected: types.CSVSimilarity},
+		{name: "plain text", filename: "report", content: "laporan akhir tim arkavidia\n", expected: types.TextSimilarity},
+		{name: "binary", filename: "archive", content: "\x00\x01\x02\x03\xff\xfe", expected: types.BinarySimilarity},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			document, err := Extract(1, testCase.filename, []byte(testCase.content))
+			if err != nil {
+				t.Fatalf("Extract() error = %v", err)
+			}
+			if document.Kind != testCase.expected {
+				t.Errorf("Extract() kind = %v, expected %v", document.Kind, testCase.expected)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	testCases := []struct {
+		name     string
+		left     Signature
+		right    Signature
+		minScore float64
+		maxScore float64
+		regions  bool
+	}{
+		{name: "identical file", left: mustSign(t, 1, "a.go", original), right: mustSign(t, 2, "b.go", original), minScore: 1, maxScore: 1, regions: true},
+		{name: "renamed identifiers", left: mustSign(t, 1, "a.go", original), right: mustSign(t, 2, "b.go", renamed), minScore: 0.8, maxScore: 1, regions: true},
+		{name: "unrelated source", left: mustSign(t, 1, "a.go", original), right: mustSign(t, 2, "b.go", unrelated), minScore: 0, maxScore: 0.3},
+		{name: "different kind", left: mustSign(t, 1, "a.go", original), right: mustSign(t, 2, "b.csv", "id,target\n1,a\n"), minScore: 0, maxScore: 0},
+		{name: "different binary", left: mustSign(t, 1, "a", "\x00\x01\x02"), right: mustSign(t, 2, "b", "\x00\x01\x03"), minScore: 0, maxScore: 0},
+		{name: "identical binary", left: mustSign(t, 1, "a", "\x00\x01\x02"), right: mustSign(t, 2, "b", "\x00\x01\x02"), minScore: 1, maxScore: 1},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			score, regions := Compare(testCase.left, testCase.right)
+			if score < testCase.minScore || score > testCase.maxScore {
+				t.Errorf("Compare() score = %v, expected between %v and %v", score, testCase.minScore, testCase.maxScore)
+			}
+			if testCase.regions && len(regions) == 0 {
+				t.Errorf("Compare() returned no regions")
+			}
+			for _, region := range regions {
+				if region.LeftStartLine > region.LeftEndLine || region.RightStartLine > region.RightEndLine {
+					t.Errorf("Compare() region %+v is not ordered", region)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package similarity
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/alecthomas/chroma"
+	"github.com/alecthomas/chroma/lexers"
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/ledongthuc/pdf"
+
+	"arkavidia-backend-8.0/competition/types"
+)
+
+// Token menyimpan nilai yang telah dinormalisasi beserta nomor baris asalnya untuk menentukan region yang cocok
+type Token struct {
+	Value string
+	Line  int
+}
+
+type Document struct {
+	SubmissionID uint
+	Kind         types.SimilarityKind
+	Hash         [sha256.Size]byte
+	Tokens       []Token
+}
+
+type notebook struct {
+	Cells []struct {
+		CellType string          `json:"cell_type"`
+		Source   json.RawMessage `json:"source"`
+	} `json:"cells"`
+}
+
+func normalizeLine(line string) string {
+	return strings.ToLower(strings.Join(strings.Fields(line), " "))
+}
+
+func tokenizeLines(content string) []Token {
+	tokens := []Token{}
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if value := normalizeLine(scanner.Text()); value != "" {
+			tokens = append(tokens, Token{Value: value, Line: line})
+		}
+	}
+
+	return tokens
+}
+
+func tokenizeWords(content string) []Token {
+	tokens := []Token{}
+	for i, line := range strings.Split(content, "\n") {
+		words := strings.FieldsFunc(strings.ToLower(line), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+		})
+		for _, word := range words {
+			tokens = append(tokens, Token{Value: word, Line: i + 1})
+		}
+	}
+
+	return tokens
+}
+
+// Identifier dan literal dinormalisasi agar penggantian nama variabel tidak menyembunyikan kemiripan,
+// sedangkan komentar dan whitespace diabaikan
+func tokenizeSource(lexer chroma.Lexer, content string) ([]Token, error) {
+	iterator, err := lexer.Tokenise(nil, content)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := []Token{}
+	line := 1
+	for _, token := range iterator.Tokens() {
+		value := ""
+		switch {
+		case token.Type.InCategory(chroma.Comment), token.Type == chroma.Text, token.Type == chroma.TextWhitespace:
+			value = ""
+		case token.Type.InCategory(chroma.Name) && token.Type != chroma.NameBuiltin:
+			value = "N"
+		case token.Type.InCategory(chroma.Literal):
+			value = "L"
+		default:
+			value = strings.TrimSpace(token.Value)
+		}
+
+		if value != "" {
+			tokens = append(tokens, Token{Value: value, Line: line})
+		}
+		line += strings.Count(token.Value, "\n")
+	}
+
+	return tokens, nil
+}
+
+func extractNotebook(content []byte) (string, error) {
+	parsed := notebook{}
+	if err := json.Unmarshal(content, &parsed); err != nil {
+		return "", err
+	}
+
+	// Output cell diabaikan, hanya source code dan markdown yang dibandingkan
+	lines := []string{}
+	for _, cell := range parsed.Cells {
+		sources := []string{}
+		if err := json.Unmarshal(cell.Source, &sources); err != nil {
+			source := ""
+			if err := json.Unmarshal(cell.Source, &source); err != nil {
+				return "", err
+			}
+			sources = []string{source}
+		}
+		lines = append(lines, strings.Split(strings.Join(sources, ""), "\n")...)
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+func extractPDF(content []byte) (string, error) {
+	reader, err := pdf.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return "", err
+	}
+
+	plainText, err := reader.GetPlainText()
+	if err != nil {
+		return "", err
+	}
+
+	text, err := ioutil.ReadAll(plainText)
+	if err != nil {
+		return "", err
+	}
+
+	return string(text), nil
+}
+
+// Public
+func Extract(submissionID uint, filename string, content []byte) (Document, error) {
+	document := Document{SubmissionID: submissionID, Hash: sha256.Sum256(content)}
+	mtype := mimetype.Detect(content)
+	extension := strings.ToLower(filepath.Ext(filename))
+
+	switch {
+	case extension == ".ipynb":
+		text, err := extractNotebook(content)
+		if err != nil {
+			return Document{}, err
+		}
+		document.Kind = types.NotebookSimilarity
+		document.Tokens = tokenizeLines(text)
+	case extension == ".csv" || mtype.Is("text/csv"):
+		document.Kind = types.CSVSimilarity
+		document.Tokens = tokenizeLines(string(content))
+	case mtype.Is("application/pdf"):
+		text, err := extractPDF(content)
+		if err != nil {
+			return Document{}, err
+		}
+		document.Kind = types.TextSimilarity
+		document.Tokens = tokenizeWords(text)
+	case lexers.Match(filename) != nil:
+		tokens, err := tokenizeSource(lexers.Match(filename), string(content))
+		if err != nil {
+			return Document{}, err
+		}
+		document.Kind = types.SourceSimilarity
+		document.Tokens = tokens
+	case strings.HasPrefix(mtype.String(), "text/"):
+		document.Kind = types.TextSimilarity
+		document.Tokens = tokenizeWords(string(content))
+	default:
+		document.Kind = types.BinarySimilarity
+	}
+
+	return document, nil
+}
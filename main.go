@@ -1,15 +1,22 @@
 package main
 
 import (
+	"log"
+	"os"
 	"runtime"
 
 	"github.com/gin-contrib/gzip"
 	"github.com/gin-gonic/gin"
 
+	"arkavidia-backend-8.0/competition/commands"
+	encryptionConfig "arkavidia-backend-8.0/competition/config/encryption"
 	messageConfig "arkavidia-backend-8.0/competition/config/message"
 	"arkavidia-backend-8.0/competition/middlewares"
 	"arkavidia-backend-8.0/competition/routes"
+	"arkavidia-backend-8.0/competition/utils/certificate"
 	"arkavidia-backend-8.0/competition/utils/mail"
+	"arkavidia-backend-8.0/competition/utils/preview"
+	"arkavidia-backend-8.0/competition/utils/similarity"
 )
 
 // TODO: Gunakan gzip untuk mengkompresi size HTTP Response
@@ -56,6 +63,23 @@ func main() {
 	// Configure runtime
 	runtime.GOMAXPROCS(runtime.NumCPU())
 
+	// Commands
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "rotate-key":
+			if err := commands.RotateKey(); err != nil {
+				log.Fatalf("ERROR: KEY ROTATION FAILED: %s", err.Error())
+			}
+			return
+		case "payment-stub":
+			commands.PaymentStub()
+			return
+		}
+	}
+
+	// Validate Configuration
+	encryptionConfig.Config.GetMetadata()
+
 	// Gin Framework
 	engine := gin.Default()
 
@@ -67,13 +91,29 @@ func main() {
 	routes.AdminRoute(engine)
 	routes.TeamRoute(engine)
 	routes.ParticipantRoute(engine)
+	routes.InvitationRoute(engine)
+	routes.WaitlistRoute(engine)
+	routes.InvoiceRoute(engine)
+	routes.VoucherRoute(engine)
+	routes.CertificateRoute(engine)
 	routes.SubmissionRoute(engine)
 	routes.PhotoRoute(engine)
+	routes.JudgeRoute(engine)
+	routes.RubricRoute(engine)
+	routes.AssignmentRoute(engine)
+	routes.ScoreRoute(engine)
+	routes.LeaderboardRoute(engine)
+	routes.ScoreboardRoute(engine)
+	routes.ExamRoute(engine)
+	routes.SimilarityRoute(engine)
 	routes.NotFoundRoute(engine)
 
 	// Goroutine Worker
 	configMessage := messageConfig.Config.GetMetadata()
 	go mail.Broker.RunMailWorker(configMessage.WorkerSize)
+	go preview.Broker.RunPreviewWorker(configMessage.WorkerSize)
+	go similarity.Broker.RunAnalysisWorker(configMessage.WorkerSize)
+	go certificate.Broker.RunCertificateWorker(configMessage.WorkerSize)
 
 	// Run App
 	engine.Run()
@@ -0,0 +1,18 @@
+-- Kolom lama dikembalikan tanpa constraint NOT NULL karena team dapat memiliki lebih dari satu enrolment
+DO $$ BEGIN
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'teams' AND column_name = 'team_category') THEN
+        ALTER TABLE teams
+            ADD COLUMN team_category team_category DEFAULT NULL,
+            ADD COLUMN stage submission_stage DEFAULT NULL,
+            ADD COLUMN admin_id bigint DEFAULT NULL,
+            ADD COLUMN status team_status DEFAULT 'waiting-for-evaluation';
+        ALTER TABLE teams RENAME COLUMN is_disbanded TO is_withdrawn;
+
+        UPDATE teams SET team_category = enrolments.team_category, stage = enrolments.stage, admin_id = enrolments.admin_id, status = enrolments.status
+        FROM (SELECT DISTINCT ON (team_id) * FROM enrolments WHERE deleted_at IS NULL ORDER BY team_id, id) AS enrolments
+        WHERE enrolments.team_id = teams.id;
+
+        DROP INDEX IF EXISTS submission_version_index;
+        CREATE UNIQUE INDEX submission_version_index ON submissions (team_id, stage, version);
+    END IF;
+END $$
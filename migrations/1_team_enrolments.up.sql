@@ -0,0 +1,48 @@
+-- Pendaftaran jenis lomba dipindahkan dari kolom teams ke tabel enrolments.
+-- Migration dijalankan sebelum AutoMigrate sehingga seluruh langkah hanya dilakukan apabila kolom lama masih ada.
+DO $$ BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'teams' AND column_name = 'team_category') THEN
+        CREATE TABLE IF NOT EXISTS enrolments (
+            id bigserial PRIMARY KEY,
+            created_at timestamptz,
+            updated_at timestamptz,
+            deleted_at timestamptz,
+            team_id bigint NOT NULL REFERENCES teams (id),
+            team_category team_category NOT NULL,
+            stage submission_stage DEFAULT NULL,
+            admin_id bigint DEFAULT NULL REFERENCES admins (id),
+            status team_status NOT NULL
+        );
+        CREATE UNIQUE INDEX IF NOT EXISTS enrolment_index ON enrolments (team_id, team_category);
+        CREATE INDEX IF NOT EXISTS idx_enrolments_deleted_at ON enrolments (deleted_at);
+
+        -- Team yang telah mengundurkan diri tetap tercatat sebagai enrolment yang dihapus (soft delete)
+        INSERT INTO enrolments (created_at, updated_at, deleted_at, team_id, team_category, stage, admin_id, status)
+        SELECT teams.created_at, teams.updated_at, CASE WHEN teams.is_withdrawn THEN teams.updated_at ELSE teams.deleted_at END, teams.id, teams.team_category, teams.stage, teams.admin_id, teams.status
+        FROM teams
+        WHERE teams.team_category IS NOT NULL
+        ON CONFLICT (team_id, team_category) DO NOTHING;
+
+        ALTER TABLE submissions ADD COLUMN IF NOT EXISTS enrolment_id bigint DEFAULT NULL;
+        UPDATE submissions SET enrolment_id = enrolments.id
+        FROM enrolments
+        WHERE enrolments.team_id = submissions.team_id AND submissions.enrolment_id IS NULL;
+
+        ALTER TABLE stage_transitions ADD COLUMN IF NOT EXISTS enrolment_id bigint DEFAULT NULL;
+        UPDATE stage_transitions SET enrolment_id = enrolments.id
+        FROM enrolments
+        WHERE enrolments.team_id = stage_transitions.team_id AND stage_transitions.enrolment_id IS NULL;
+
+        ALTER TABLE team_withdrawals ADD COLUMN IF NOT EXISTS enrolment_id bigint DEFAULT NULL;
+        UPDATE team_withdrawals SET enrolment_id = enrolments.id
+        FROM enrolments
+        WHERE enrolments.team_id = team_withdrawals.team_id AND enrolments.team_category = team_withdrawals.previous_category AND team_withdrawals.enrolment_id IS NULL;
+
+        -- Index versi submission sebelumnya dibentuk dari (team_id, stage, version)
+        DROP INDEX IF EXISTS submission_version_index;
+        CREATE UNIQUE INDEX submission_version_index ON submissions (enrolment_id, stage, version);
+
+        ALTER TABLE teams RENAME COLUMN is_withdrawn TO is_disbanded;
+        ALTER TABLE teams DROP COLUMN team_category, DROP COLUMN stage, DROP COLUMN admin_id, DROP COLUMN status;
+    END IF;
+END $$
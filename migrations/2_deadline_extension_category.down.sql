@@ -0,0 +1,8 @@
+DO $$ BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'deadline_extensions' AND column_name = 'team_category') THEN
+        DROP INDEX IF EXISTS deadline_extension_index;
+        DELETE FROM deadline_extensions WHERE id NOT IN (SELECT MIN(id) FROM deadline_extensions GROUP BY team_id, stage);
+        CREATE UNIQUE INDEX deadline_extension_index ON deadline_extensions (team_id, stage);
+        ALTER TABLE deadline_extensions DROP COLUMN team_category;
+    END IF;
+END $$
@@ -0,0 +1,17 @@
+-- Perpanjangan tenggat dicatat per jenis lomba karena sebuah team dapat memiliki beberapa enrolment
+DO $$ BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.tables WHERE table_name = 'deadline_extensions')
+        AND NOT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'deadline_extensions' AND column_name = 'team_category') THEN
+        ALTER TABLE deadline_extensions ADD COLUMN team_category team_category DEFAULT NULL;
+
+        UPDATE deadline_extensions SET team_category = enrolments.team_category
+        FROM (SELECT DISTINCT ON (team_id) team_id, team_category FROM enrolments ORDER BY team_id, id) AS enrolments
+        WHERE enrolments.team_id = deadline_extensions.team_id;
+
+        DELETE FROM deadline_extensions WHERE team_category IS NULL;
+        ALTER TABLE deadline_extensions ALTER COLUMN team_category SET NOT NULL;
+
+        DROP INDEX IF EXISTS deadline_extension_index;
+        CREATE UNIQUE INDEX deadline_extension_index ON deadline_extensions (team_id, team_category, stage);
+    END IF;
+END $$
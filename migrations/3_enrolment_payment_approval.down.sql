@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS enrolments DROP COLUMN IF EXISTS payment_approved_at
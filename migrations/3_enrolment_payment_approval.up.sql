@@ -0,0 +1,12 @@
+-- Persetujuan pembayaran enrolment dicatat dari invoice yang telah lunas sebelum kolom ini tersedia
+DO $$ BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.tables WHERE table_name = 'enrolments') THEN
+        ALTER TABLE enrolments ADD COLUMN IF NOT EXISTS payment_approved_at timestamptz DEFAULT NULL;
+
+        IF EXISTS (SELECT 1 FROM information_schema.tables WHERE table_name = 'invoices') THEN
+            UPDATE enrolments SET payment_approved_at = invoices.paid_at
+            FROM (SELECT enrolment_id, MIN(paid_at) AS paid_at FROM invoices WHERE status = 'paid' GROUP BY enrolment_id) AS invoices
+            WHERE invoices.enrolment_id = enrolments.id AND enrolments.payment_approved_at IS NULL;
+        END IF;
+    END IF;
+END $$
@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS datavidia_scores DROP COLUMN IF EXISTS ground_truth_id
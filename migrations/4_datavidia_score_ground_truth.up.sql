@@ -0,0 +1,13 @@
+-- Nilai Datavidia dihubungkan dengan ground truth yang digunakan saat penilaian
+DO $$ BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.tables WHERE table_name = 'datavidia_scores') THEN
+        ALTER TABLE datavidia_scores ADD COLUMN IF NOT EXISTS ground_truth_id bigint;
+
+        UPDATE datavidia_scores SET ground_truth_id = ground_truths.id
+        FROM submissions, ground_truths
+        WHERE submissions.id = datavidia_scores.submission_id AND ground_truths.stage = submissions.stage AND datavidia_scores.ground_truth_id IS NULL;
+
+        DELETE FROM datavidia_scores WHERE ground_truth_id IS NULL;
+        ALTER TABLE datavidia_scores ALTER COLUMN ground_truth_id SET NOT NULL;
+    END IF;
+END $$
@@ -0,0 +1,9 @@
+DO $$ BEGIN
+    CREATE TYPE analysis_status AS ENUM (
+        'pending',
+        'completed',
+        'failed'
+    );
+EXCEPTION
+    WHEN duplicate_object THEN NULL;
+END $$
\ No newline at end of file
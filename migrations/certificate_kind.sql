@@ -0,0 +1,9 @@
+DO $$ BEGIN
+    CREATE TYPE certificate_kind AS ENUM (
+        'participation',
+        'finalist',
+        'winner'
+    );
+EXCEPTION
+    WHEN duplicate_object THEN NULL;
+END $$
\ No newline at end of file
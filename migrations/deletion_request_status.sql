@@ -0,0 +1,9 @@
+DO $$ BEGIN
+    CREATE TYPE deletion_request_status AS ENUM (
+        'pending',
+        'confirmed',
+        'rejected'
+    );
+EXCEPTION
+    WHEN duplicate_object THEN NULL;
+END $$
\ No newline at end of file
@@ -0,0 +1,8 @@
+DO $$ BEGIN
+    CREATE TYPE discount_type AS ENUM (
+        'percentage',
+        'fixed'
+    );
+EXCEPTION
+    WHEN duplicate_object THEN NULL;
+END $$
\ No newline at end of file
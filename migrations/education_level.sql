@@ -0,0 +1,8 @@
+DO $$ BEGIN
+    CREATE TYPE education_level AS ENUM (
+        'high-school',
+        'university'
+    );
+EXCEPTION
+    WHEN duplicate_object THEN NULL;
+END $$
\ No newline at end of file
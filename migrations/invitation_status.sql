@@ -0,0 +1,11 @@
+DO $$ BEGIN
+    CREATE TYPE invitation_status AS ENUM (
+        'pending',
+        'accepted',
+        'declined',
+        'revoked',
+        'expired'
+    );
+EXCEPTION
+    WHEN duplicate_object THEN NULL;
+END $$
\ No newline at end of file
@@ -0,0 +1,10 @@
+DO $$ BEGIN
+    CREATE TYPE invoice_status AS ENUM (
+        'pending',
+        'paid',
+        'expired',
+        'cancelled'
+    );
+EXCEPTION
+    WHEN duplicate_object THEN NULL;
+END $$
\ No newline at end of file
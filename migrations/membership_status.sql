@@ -0,0 +1,8 @@
+DO $$ BEGIN
+    CREATE TYPE membership_status AS ENUM (
+        'pending',
+        'active'
+    );
+EXCEPTION
+    WHEN duplicate_object THEN NULL;
+END $$
\ No newline at end of file
@@ -0,0 +1,10 @@
+DO $$ BEGIN
+    CREATE TYPE preview_status AS ENUM (
+        'pending',
+        'generated',
+        'failed',
+        'unsupported'
+    );
+EXCEPTION
+    WHEN duplicate_object THEN NULL;
+END $$
\ No newline at end of file
@@ -0,0 +1,8 @@
+DO $$ BEGIN
+    CREATE TYPE question_type AS ENUM (
+        'multiple-choice',
+        'short-answer'
+    );
+EXCEPTION
+    WHEN duplicate_object THEN NULL;
+END $$
\ No newline at end of file
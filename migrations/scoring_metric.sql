@@ -0,0 +1,10 @@
+DO $$ BEGIN
+    CREATE TYPE scoring_metric AS ENUM (
+        'rmse',
+        'mae',
+        'accuracy',
+        'macro-f1'
+    );
+EXCEPTION
+    WHEN duplicate_object THEN NULL;
+END $$
\ No newline at end of file
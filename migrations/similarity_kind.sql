@@ -0,0 +1,11 @@
+DO $$ BEGIN
+    CREATE TYPE similarity_kind AS ENUM (
+        'source',
+        'text',
+        'notebook',
+        'csv',
+        'binary'
+    );
+EXCEPTION
+    WHEN duplicate_object THEN NULL;
+END $$
\ No newline at end of file
@@ -0,0 +1,9 @@
+DO $$ BEGIN
+    CREATE TYPE waitlist_status AS ENUM (
+        'waiting',
+        'promoted',
+        'left'
+    );
+EXCEPTION
+    WHEN duplicate_object THEN NULL;
+END $$
\ No newline at end of file
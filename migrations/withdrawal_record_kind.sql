@@ -0,0 +1,10 @@
+DO $$ BEGIN
+    CREATE TYPE withdrawal_record_kind AS ENUM (
+        'enrolment',
+        'membership',
+        'invitation',
+        'invoice'
+    );
+EXCEPTION
+    WHEN duplicate_object THEN NULL;
+END $$
\ No newline at end of file
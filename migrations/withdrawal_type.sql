@@ -0,0 +1,8 @@
+DO $$ BEGIN
+    CREATE TYPE withdrawal_type AS ENUM (
+        'withdrawal',
+        'disband'
+    );
+EXCEPTION
+    WHEN duplicate_object THEN NULL;
+END $$
\ No newline at end of file